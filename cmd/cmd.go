@@ -1,35 +1,31 @@
 package cmd
 
 import (
+	"context"
+	"log"
 	"os"
-	"tiga-putra-cashier-be/database"
+	"slices"
+	"tiga-putra-cashier-be/job"
 
 	"gorm.io/gorm"
 )
 
-func Command(db *gorm.DB) {
-	migrateUp := false
-	migrateDown := false
-
-	for _, arg := range os.Args[1:] {
-		if arg == "migrate-up" {
-			migrateUp = true
-		}
-		if arg == "migrate-down" {
-			migrateDown = true
-		}
-	}
-	if migrateUp {
-		if err := database.MigrateUp(db); err != nil {
+func Command(db *gorm.DB, imageGCJob *job.ImageGCJob) {
+	args := os.Args[1:]
+	switch args[0] {
+	case "migrate":
+		if err := migrate(db, args[1:]); err != nil {
+			log.Printf("migrate failed: %v", err)
 			os.Exit(1)
 		}
 		os.Exit(0)
-
-	}
-	if migrateDown {
-		if err := database.MigrateDown(db); err != nil {
+	case "gc-images":
+		report, err := imageGCJob.Collect(context.Background(), imageGCJob.GracePeriod(), slices.Contains(args[1:], "--dry-run"))
+		if err != nil {
+			log.Printf("image gc failed: %v", err)
 			os.Exit(1)
 		}
+		job.LogImageGCReport(report)
 		os.Exit(0)
 	}
 }
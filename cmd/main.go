@@ -7,8 +7,11 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/database"
+	"tiga-putra-cashier-be/job"
+	"tiga-putra-cashier-be/middleware"
 	"tiga-putra-cashier-be/router"
 	"time"
 
@@ -25,16 +28,37 @@ type Server struct {
 func (s *Server) Start() {
 	err := s.Container.Invoke(func(
 		r *gin.Engine,
+		cfg *config.Config,
 		db *gorm.DB,
 		pc controller.ProductController,
+		pic controller.ProductImageController,
+		scc controller.StockCountController,
+		sc controller.StockController,
+		spc controller.SupplierController,
+		tc controller.TransactionController,
+		stc controller.StoreController,
+		sttc controller.StockTransferController,
+		rc controller.ReportController,
+		syc controller.SyncController,
+		ic controller.ImageController,
+		im *middleware.IdempotencyMiddleware,
+		lowStockJob *job.LowStockJob,
+		imageGCJob *job.ImageGCJob,
 	) {
 		defer database.CloseDB(db)
 		if len(os.Args) > 1 {
-			Command(db)
+			Command(db, imageGCJob)
 		}
-		router.AppRouter(r, pc)
+		migrator, err := database.NewMigrator(db, database.MigrationFiles)
+		if err != nil {
+			log.Fatalf("failed to read migrations: %v", err)
+		}
+		if err := migrator.Check(); err != nil {
+			log.Fatalf("refusing to start: %v", err)
+		}
+		router.AppRouter(r, cfg, pc, pic, scc, sc, spc, tc, stc, sttc, rc, syc, ic, im)
 		srv := &http.Server{
-			Addr:    ":8080",
+			Addr:    cfg.Server.Addr,
 			Handler: r,
 		}
 		go func() {
@@ -43,6 +67,15 @@ func (s *Server) Start() {
 			}
 		}()
 
+		jobCtx, stopJobs := context.WithCancel(context.Background())
+		defer stopJobs()
+		if interval := lowStockJob.Interval(); interval > 0 {
+			go lowStockJob.Run(jobCtx, interval)
+		}
+		if interval := imageGCJob.Interval(); interval > 0 {
+			go imageGCJob.Run(jobCtx, interval)
+		}
+
 		if s.ServerReady != nil {
 			s.ServerReady <- true
 		}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"tiga-putra-cashier-be/database"
+	"tiga-putra-cashier-be/dto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var errMigrateUsage = errors.New("usage: migrate status | up [N] | down [N] | to <version>")
+
+// migrate runs "migrate status", "migrate up [N]" (all pending by default),
+// "migrate down [N]" (the last one by default) and "migrate to <version>".
+func migrate(db *gorm.DB, args []string) error {
+	migrator, err := database.NewMigrator(db, database.MigrationFiles)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || len(args) > 2 {
+		return errMigrateUsage
+	}
+	var number int
+	if len(args) == 2 {
+		if number, err = strconv.Atoi(args[1]); err != nil || number < 0 {
+			return errMigrateUsage
+		}
+	}
+
+	switch {
+	case args[0] == "status" && len(args) == 1:
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(status)
+		return nil
+	case args[0] == "up":
+		return migrator.Up(number)
+	case args[0] == "down":
+		if len(args) == 1 {
+			number = 1
+		}
+		return migrator.Down(number)
+	case args[0] == "to" && len(args) == 2:
+		return migrator.To(uint(number))
+	}
+	return errMigrateUsage
+}
+
+func printMigrationStatus(status []dto.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, migration := range status {
+		appliedAt := "pending"
+		if migration.AppliedAt != nil {
+			appliedAt = migration.AppliedAt.Format(time.RFC3339)
+		}
+		if migration.Missing {
+			appliedAt += " (no migration file)"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", migration.Version, migration.Name, appliedAt)
+	}
+	w.Flush()
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"tiga-putra-cashier-be/constant"
+	"time"
+)
+
+// Config is the whole configuration of the app. Every setting can come from
+// the YAML file under its yaml key or from the environment variable next to
+// it; see Load for which one wins.
+type (
+	Config struct {
+		App         AppConfig         `yaml:"app"`
+		Server      ServerConfig      `yaml:"server"`
+		Database    DatabaseConfig    `yaml:"database"`
+		Storage     StorageConfig     `yaml:"storage"`
+		Idempotency IdempotencyConfig `yaml:"idempotency"`
+		Jobs        JobsConfig        `yaml:"jobs"`
+	}
+	AppConfig struct {
+		Env            string `yaml:"env"`              // APP_ENV
+		RequireIfMatch bool   `yaml:"require_if_match"` // REQUIRE_IF_MATCH
+	}
+	ServerConfig struct {
+		Addr             string   `yaml:"addr"`               // SERVER_ADDR
+		CORSAllowOrigins []string `yaml:"cors_allow_origins"` // CORS_ALLOW_ORIGINS, comma separated
+	}
+	DatabaseConfig struct {
+		User     string         `yaml:"user"` // DB_USER
+		Pass     string         `yaml:"pass"` // DB_PASS
+		Host     string         `yaml:"host"` // DB_HOST
+		Name     string         `yaml:"name"` // DB_NAME
+		Port     string         `yaml:"port"` // DB_PORT
+		Timeouts TimeoutsConfig `yaml:"timeouts"`
+	}
+	// TimeoutsConfig bounds the queries of a repository by the kind of work
+	// they do: single queries, report aggregations, imports and exports (which
+	// work through the whole catalog or stream for as long as the download
+	// takes), and transactions composed with WithTx, which may include an
+	// image upload.
+	TimeoutsConfig struct {
+		Query  time.Duration `yaml:"query"`  // DB_QUERY_TIMEOUT
+		Report time.Duration `yaml:"report"` // DB_REPORT_TIMEOUT
+		Bulk   time.Duration `yaml:"bulk"`   // DB_BULK_TIMEOUT
+		Tx     time.Duration `yaml:"tx"`     // DB_TX_TIMEOUT
+	}
+	StorageConfig struct {
+		Driver        string   `yaml:"driver"`          // STORAGE_DRIVER
+		ImageDir      string   `yaml:"image_dir"`       // IMAGE_DIR
+		MaxUploadSize int64    `yaml:"max_upload_size"` // MAX_UPLOAD_SIZE, in bytes
+		S3            S3Config `yaml:"s3"`
+	}
+	S3Config struct {
+		Endpoint  string `yaml:"endpoint"`   // S3_ENDPOINT
+		AccessKey string `yaml:"access_key"` // S3_ACCESS_KEY
+		SecretKey string `yaml:"secret_key"` // S3_SECRET_KEY
+		Bucket    string `yaml:"bucket"`     // S3_BUCKET
+		Region    string `yaml:"region"`     // S3_REGION
+		UseSSL    bool   `yaml:"use_ssl"`    // S3_USE_SSL
+		// PresignExpiry of zero disables presigned URLs so images are
+		// streamed through the app instead.
+		PresignExpiry time.Duration `yaml:"presign_expiry"` // S3_PRESIGN_EXPIRY
+	}
+	IdempotencyConfig struct {
+		KeyTTL      time.Duration `yaml:"key_ttl"`       // IDEMPOTENCY_KEY_TTL
+		MaxBodySize int64         `yaml:"max_body_size"` // IDEMPOTENCY_MAX_BODY_SIZE, in bytes
+	}
+	JobsConfig struct {
+		LowStock LowStockConfig `yaml:"low_stock"`
+		ImageGC  ImageGCConfig  `yaml:"image_gc"`
+	}
+	// An interval of zero disables the scheduled run of a job.
+	LowStockConfig struct {
+		CheckInterval time.Duration `yaml:"check_interval"` // LOW_STOCK_CHECK_INTERVAL
+		WebhookURL    string        `yaml:"webhook_url"`    // LOW_STOCK_WEBHOOK_URL
+	}
+	ImageGCConfig struct {
+		Interval    time.Duration `yaml:"interval"`     // IMAGE_GC_INTERVAL
+		GracePeriod time.Duration `yaml:"grace_period"` // IMAGE_GC_GRACE_PERIOD
+		DryRun      bool          `yaml:"dry_run"`      // IMAGE_GC_DRY_RUN
+	}
+)
+
+// Default is the configuration before any file or variable is applied.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:             constant.DefaultServerAddr,
+			CORSAllowOrigins: []string{"*"},
+		},
+		Database: DatabaseConfig{
+			Timeouts: TimeoutsConfig{
+				Query:  constant.DefaultQueryTimeout,
+				Report: constant.DefaultReportTimeout,
+				Bulk:   constant.DefaultBulkTimeout,
+				Tx:     constant.DefaultTxTimeout,
+			},
+		},
+		Storage: StorageConfig{
+			Driver:        constant.StorageDriverLocal,
+			ImageDir:      constant.DefaultImageDir,
+			MaxUploadSize: constant.DefaultMaxUploadSize,
+			S3: S3Config{
+				PresignExpiry: constant.DefaultPresignExpiry,
+			},
+		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL:      constant.DefaultIdempotencyKeyTTL,
+			MaxBodySize: constant.DefaultIdempotencyMaxBodySize,
+		},
+		Jobs: JobsConfig{
+			ImageGC: ImageGCConfig{
+				GracePeriod: constant.DefaultImageGCGracePeriod,
+			},
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"tiga-putra-cashier-be/constant"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError lists every problem found in the configuration, so they can
+// all be fixed in one go.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load builds the configuration from, lowest precedence first, the defaults,
+// the YAML file named by CONFIG_FILE (config.yaml when it exists and
+// CONFIG_FILE is not set), the .env file and the process environment. Empty
+// variables count as unset. The .env file is optional and skipped when
+// APP_ENV is TEST.
+func Load() (*Config, error) {
+	s := &source{}
+	if os.Getenv("APP_ENV") != constant.AppEnvTest {
+		dotenv, err := godotenv.Read(constant.DotEnvFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, &ValidationError{[]string{fmt.Sprintf("%s: %v", constant.DotEnvFile, err)}}
+		}
+		s.dotenv = dotenv
+	}
+
+	cfg := Default()
+	s.file(cfg)
+	s.env(cfg)
+	problems := append(s.problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{problems}
+	}
+	return cfg, nil
+}
+
+type source struct {
+	dotenv   map[string]string
+	problems []string
+}
+
+func (s *source) lookup(name string) (string, bool) {
+	if value := os.Getenv(name); value != "" {
+		return value, true
+	}
+	value := s.dotenv[name]
+	return value, value != ""
+}
+
+func (s *source) file(cfg *Config) {
+	name, explicit := s.lookup("CONFIG_FILE")
+	if !explicit {
+		name = constant.DefaultConfigFile
+	}
+	content, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return
+	}
+	if err != nil {
+		s.problems = append(s.problems, fmt.Sprintf("CONFIG_FILE: %v", err))
+		return
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && err != io.EOF {
+		s.problems = append(s.problems, fmt.Sprintf("%s: %v", name, err))
+	}
+}
+
+func (s *source) env(cfg *Config) {
+	s.string(&cfg.App.Env, "APP_ENV")
+	s.bool(&cfg.App.RequireIfMatch, "REQUIRE_IF_MATCH")
+
+	s.string(&cfg.Server.Addr, "SERVER_ADDR")
+	s.list(&cfg.Server.CORSAllowOrigins, "CORS_ALLOW_ORIGINS")
+
+	s.string(&cfg.Database.User, "DB_USER")
+	s.string(&cfg.Database.Pass, "DB_PASS")
+	s.string(&cfg.Database.Host, "DB_HOST")
+	s.string(&cfg.Database.Name, "DB_NAME")
+	s.string(&cfg.Database.Port, "DB_PORT")
+	s.duration(&cfg.Database.Timeouts.Query, "DB_QUERY_TIMEOUT")
+	s.duration(&cfg.Database.Timeouts.Report, "DB_REPORT_TIMEOUT")
+	s.duration(&cfg.Database.Timeouts.Bulk, "DB_BULK_TIMEOUT")
+	s.duration(&cfg.Database.Timeouts.Tx, "DB_TX_TIMEOUT")
+
+	s.string(&cfg.Storage.Driver, "STORAGE_DRIVER")
+	s.string(&cfg.Storage.ImageDir, "IMAGE_DIR")
+	s.int64(&cfg.Storage.MaxUploadSize, "MAX_UPLOAD_SIZE")
+	s.string(&cfg.Storage.S3.Endpoint, "S3_ENDPOINT")
+	s.string(&cfg.Storage.S3.AccessKey, "S3_ACCESS_KEY")
+	s.string(&cfg.Storage.S3.SecretKey, "S3_SECRET_KEY")
+	s.string(&cfg.Storage.S3.Bucket, "S3_BUCKET")
+	s.string(&cfg.Storage.S3.Region, "S3_REGION")
+	s.bool(&cfg.Storage.S3.UseSSL, "S3_USE_SSL")
+	s.duration(&cfg.Storage.S3.PresignExpiry, "S3_PRESIGN_EXPIRY")
+
+	s.duration(&cfg.Idempotency.KeyTTL, "IDEMPOTENCY_KEY_TTL")
+	s.int64(&cfg.Idempotency.MaxBodySize, "IDEMPOTENCY_MAX_BODY_SIZE")
+
+	s.duration(&cfg.Jobs.LowStock.CheckInterval, "LOW_STOCK_CHECK_INTERVAL")
+	s.string(&cfg.Jobs.LowStock.WebhookURL, "LOW_STOCK_WEBHOOK_URL")
+	s.duration(&cfg.Jobs.ImageGC.Interval, "IMAGE_GC_INTERVAL")
+	s.duration(&cfg.Jobs.ImageGC.GracePeriod, "IMAGE_GC_GRACE_PERIOD")
+	s.bool(&cfg.Jobs.ImageGC.DryRun, "IMAGE_GC_DRY_RUN")
+}
+
+func (s *source) string(dst *string, name string) {
+	if value, ok := s.lookup(name); ok {
+		*dst = value
+	}
+}
+
+func (s *source) list(dst *[]string, name string) {
+	value, ok := s.lookup(name)
+	if !ok {
+		return
+	}
+	*dst = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*dst = append(*dst, item)
+		}
+	}
+}
+
+func (s *source) bool(dst *bool, name string) {
+	value, ok := s.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		s.problems = append(s.problems, fmt.Sprintf("%s: %q is not true or false", name, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (s *source) int64(dst *int64, name string) {
+	value, ok := s.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		s.problems = append(s.problems, fmt.Sprintf("%s: %q is not a number", name, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (s *source) duration(dst *time.Duration, name string) {
+	value, ok := s.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		s.problems = append(s.problems, fmt.Sprintf("%s: %q is not a duration like \"30s\" or \"24h\"", name, value))
+		return
+	}
+	*dst = parsed
+}
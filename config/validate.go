@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"tiga-putra-cashier-be/constant"
+	"time"
+)
+
+// validate names each setting by its environment variable, which is how most
+// deployments set them.
+func (c *Config) validate() []string {
+	var problems []string
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	positive := func(name string, value time.Duration) {
+		if value <= 0 {
+			fail("%s must be greater than zero", name)
+		}
+	}
+	required := func(name, value string) {
+		if value == "" {
+			fail("%s is required", name)
+		}
+	}
+	notNegative := func(name string, value time.Duration) {
+		if value < 0 {
+			fail("%s can't be negative", name)
+		}
+	}
+
+	switch c.App.Env {
+	case "", constant.AppEnvProduction, constant.AppEnvDevelopment, constant.AppEnvTest:
+	default:
+		fail("APP_ENV must be %s, %s or %s, got %q", constant.AppEnvProduction, constant.AppEnvDevelopment, constant.AppEnvTest, c.App.Env)
+	}
+
+	required("SERVER_ADDR", c.Server.Addr)
+	if len(c.Server.CORSAllowOrigins) == 0 {
+		fail("CORS_ALLOW_ORIGINS needs at least one origin, or * for any")
+	}
+
+	required("DB_USER", c.Database.User)
+	required("DB_HOST", c.Database.Host)
+	required("DB_NAME", c.Database.Name)
+	required("DB_PORT", c.Database.Port)
+	if c.Database.Port != "" {
+		if port, err := strconv.Atoi(c.Database.Port); err != nil || port <= 0 || port > 65535 {
+			fail("DB_PORT must be a port number, got %q", c.Database.Port)
+		}
+	}
+	positive("DB_QUERY_TIMEOUT", c.Database.Timeouts.Query)
+	positive("DB_REPORT_TIMEOUT", c.Database.Timeouts.Report)
+	positive("DB_BULK_TIMEOUT", c.Database.Timeouts.Bulk)
+	positive("DB_TX_TIMEOUT", c.Database.Timeouts.Tx)
+
+	switch c.Storage.Driver {
+	case constant.StorageDriverLocal:
+	case constant.StorageDriverS3:
+		required("S3_ENDPOINT", c.Storage.S3.Endpoint)
+		required("S3_ACCESS_KEY", c.Storage.S3.AccessKey)
+		required("S3_SECRET_KEY", c.Storage.S3.SecretKey)
+		required("S3_BUCKET", c.Storage.S3.Bucket)
+	default:
+		fail("STORAGE_DRIVER must be %s or %s, got %q", constant.StorageDriverLocal, constant.StorageDriverS3, c.Storage.Driver)
+	}
+	if dir := c.Storage.ImageDir; dir == "" || path.IsAbs(dir) || path.Clean(dir) != dir || strings.HasPrefix(dir, "..") {
+		fail("IMAGE_DIR must be a relative path inside the storage, got %q", dir)
+	}
+	if c.Storage.MaxUploadSize <= 0 {
+		fail("MAX_UPLOAD_SIZE must be greater than zero")
+	}
+
+	positive("IDEMPOTENCY_KEY_TTL", c.Idempotency.KeyTTL)
+	if c.Idempotency.MaxBodySize <= 0 {
+		fail("IDEMPOTENCY_MAX_BODY_SIZE must be greater than zero")
+	}
+
+	notNegative("LOW_STOCK_CHECK_INTERVAL", c.Jobs.LowStock.CheckInterval)
+	if webhook := c.Jobs.LowStock.WebhookURL; webhook != "" {
+		if parsed, err := url.Parse(webhook); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			fail("LOW_STOCK_WEBHOOK_URL must be an http or https URL, got %q", webhook)
+		}
+	}
+	notNegative("IMAGE_GC_INTERVAL", c.Jobs.ImageGC.Interval)
+	notNegative("IMAGE_GC_GRACE_PERIOD", c.Jobs.ImageGC.GracePeriod)
+	return problems
+}
@@ -0,0 +1,13 @@
+package constant
+
+const (
+	// DefaultConfigFile is read when it exists and CONFIG_FILE is not set.
+	DefaultConfigFile = "config.yaml"
+	DotEnvFile        = ".env"
+
+	DefaultServerAddr = ":8080"
+
+	AppEnvProduction  = "production"
+	AppEnvDevelopment = "development"
+	AppEnvTest        = "TEST"
+)
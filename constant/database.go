@@ -0,0 +1,24 @@
+package constant
+
+import "time"
+
+const (
+	// Defaults for how long a repository lets its queries run, by kind of
+	// work. A request that ends earlier cancels them sooner.
+	DefaultQueryTimeout  = 3 * time.Second
+	DefaultReportTimeout = 30 * time.Second
+	DefaultBulkTimeout   = time.Minute
+	DefaultTxTimeout     = 30 * time.Second
+)
+
+// StatusClientClosedRequest answers a request whose client went away before it
+// finished, so it is not counted as a server error.
+const StatusClientClosedRequest = 499
+
+// MigrationLockId is the advisory lock migrations hold, so app instances
+// migrating at the same time take turns.
+const MigrationLockId = 7031
+
+// PgUniqueViolation is the SQLSTATE Postgres fails an insert with when it
+// would duplicate a unique index.
+const PgUniqueViolation = "23505"
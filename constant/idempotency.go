@@ -0,0 +1,17 @@
+package constant
+
+import "time"
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength  = 255
+	DefaultIdempotencyKeyTTL = 24 * time.Hour
+	// DefaultIdempotencyMaxBodySize fits a product with its image and an
+	// import file without images; the body is held in memory to be hashed.
+	DefaultIdempotencyMaxBodySize = 32 * 1024 * 1024
+
+	// IdempotencyLockTimeout is how long a key stays locked by a request that
+	// never finished, e.g. because the server restarted halfway through.
+	IdempotencyLockTimeout = time.Minute
+)
@@ -0,0 +1,36 @@
+package constant
+
+import "time"
+
+const (
+	ImageSizeOriginal  = "original"
+	ImageSizeMedium    = "medium"
+	ImageSizeThumbnail = "thumbnail"
+
+	// Longest side in pixels of the generated sizes. The original keeps its
+	// own dimensions.
+	MediumImageSize    = 800
+	ThumbnailImageSize = 200
+
+	ImageQuality   = 85
+	ImageExtension = "jpg"
+
+	// MaxImagePixels rejects images that are small on disk but would take
+	// gigabytes of memory to decode.
+	MaxImagePixels = 40_000_000
+
+	MaxProductImages = 10
+
+	// Defaults for where images are kept in the storage backend and how large
+	// an uploaded one may be.
+	DefaultImageDir      = "assets/image"
+	DefaultMaxUploadSize = 6 * 1024 * 1024
+
+	// ImageURLPath is where the app serves images, whatever ImageDir they are
+	// kept in.
+	ImageURLPath = "/assets/image"
+
+	// DefaultImageGCGracePeriod keeps unreferenced files this long, covering
+	// uploads whose product write has not committed yet.
+	DefaultImageGCGracePeriod = 24 * time.Hour
+)
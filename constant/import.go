@@ -0,0 +1,18 @@
+package constant
+
+const (
+	ImportBatchSize      = 500
+	MaxImportFileSize    = 20 * 1024 * 1024
+	MaxImportArchiveSize = 500 * 1024 * 1024
+
+	ImportStatusCreated   = "created"
+	ImportStatusUpdated   = "updated"
+	ImportStatusRevived   = "revived"
+	ImportStatusInvalid   = "invalid"
+	ImportStatusFailed    = "failed"
+	ImportStatusSkipped   = "skipped"
+	ImportStatusUnchanged = "unchanged"
+
+	ProductStatusActive  = "active"
+	ProductStatusDeleted = "deleted"
+)
@@ -0,0 +1,17 @@
+package constant
+
+const (
+	OfflineSaleAccepted  = "accepted"
+	OfflineSaleConflict  = "conflict"
+	OfflineSaleDuplicate = "duplicate"
+	OfflineSaleFailed    = "failed"
+
+	ConflictPriceMismatch     = "price_mismatch"
+	ConflictProductDeleted    = "product_deleted"
+	ConflictUnknownProduct    = "unknown_product"
+	ConflictInsufficientStock = "insufficient_stock"
+	ConflictExpiredStock      = "expired_stock"
+
+	// TransactionClientIdIndex keeps a sale from being recorded twice.
+	TransactionClientIdIndex = "idx_transactions_client_id"
+)
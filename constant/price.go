@@ -0,0 +1,14 @@
+package constant
+
+const (
+	PriceAdjustmentPercentage = "percentage"
+	PriceAdjustmentFixed      = "fixed"
+
+	PriceRoundingNearest = "nearest"
+	PriceRoundingUp      = "up"
+	PriceRoundingDown    = "down"
+
+	// PriceDecimalPlaces is what adjusted prices are rounded to when the
+	// request doesn't ask for a rounding step.
+	PriceDecimalPlaces = 2
+)
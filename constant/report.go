@@ -0,0 +1,16 @@
+package constant
+
+const (
+	// ReportTimeZone is the zone sales are bucketed in, the same one the
+	// database connection is pinned to.
+	ReportTimeZone = "Asia/Jakarta"
+
+	ReportPeriodDay   = "day"
+	ReportPeriodWeek  = "week"
+	ReportPeriodMonth = "month"
+
+	ReportRankByQuantity = "quantity"
+	ReportRankByRevenue  = "revenue"
+
+	UncategorizedCategory = "Uncategorized"
+)
@@ -0,0 +1,11 @@
+package constant
+
+const (
+	// ProductSearchDocument is the text searched for products. Migrations index
+	// this exact expression, so queries have to use it verbatim to hit the index.
+	ProductSearchDocument = "to_tsvector('simple', title || ' ' || description)"
+
+	ProductsPerPage    = 12
+	MaxProductsPerPage = 100
+	AutocompleteLimit  = 10
+)
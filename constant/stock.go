@@ -0,0 +1,16 @@
+package constant
+
+const (
+	MovementAdjustment  = "adjustment"
+	MovementSale        = "sale"
+	MovementReceipt     = "receipt"
+	MovementWriteOff    = "write_off"
+	MovementTransferOut = "transfer_out"
+	MovementTransferIn  = "transfer_in"
+
+	StockCountOpen     = "open"
+	StockCountApproved = "approved"
+
+	TransferInTransit = "in_transit"
+	TransferReceived  = "received"
+)
@@ -0,0 +1,12 @@
+package constant
+
+import "time"
+
+const (
+	StorageDriverLocal = "local"
+	StorageDriverS3    = "s3"
+
+	// DefaultPresignExpiry is how long a presigned image URL stays valid when
+	// S3_PRESIGN_EXPIRY is not set.
+	DefaultPresignExpiry = 15 * time.Minute
+)
@@ -0,0 +1,10 @@
+package constant
+
+const (
+	DefaultStoreId   uint = 1
+	DefaultStoreCode      = "MAIN"
+	DefaultStoreName      = "Main Store"
+
+	StoreTypeStore     = "store"
+	StoreTypeWarehouse = "warehouse"
+)
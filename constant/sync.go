@@ -0,0 +1,16 @@
+package constant
+
+import "time"
+
+const (
+	SyncBatchSize = 500
+
+	// SyncSafetyMargin is added to the longest a write can take before it is
+	// committed or rolled back, to cover clocks of app instances drifting
+	// apart.
+	SyncSafetyMargin = 5 * time.Second
+
+	// ProductChangedAt is when a product last changed. Soft deletes only set
+	// deleted_at, so it has to be taken into account next to updated_at.
+	ProductChangedAt = "GREATEST(products.updated_at, COALESCE(products.deleted_at, products.updated_at))"
+)
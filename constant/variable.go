@@ -1,7 +0,0 @@
-package constant
-
-const (
-	ImageDir = "assets/image"
-	MaxUploadSize = 6 * 1024 * 1024
-
-)
\ No newline at end of file
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// abortInternalError answers an error the client can't fix. Repositories wrap
+// most failures in their own errors, so a cancelled request is recognised by
+// its context as well as by the error.
+func abortInternalError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(ctx.Request.Context().Err(), context.Canceled):
+		res := utils.ReturnResponseError(constant.StatusClientClosedRequest, dto.ErrRequestCanceled.Error())
+		ctx.AbortWithStatusJSON(constant.StatusClientClosedRequest, res)
+	case errors.Is(err, context.DeadlineExceeded):
+		res := utils.ReturnResponseError(504, dto.ErrRequestTimeout.Error())
+		ctx.AbortWithStatusJSON(http.StatusGatewayTimeout, res)
+	default:
+		res := utils.ReturnResponseError(500, err.Error())
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+	}
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func isExportFormat(format string) bool {
+	return format != "" && format != constant.ExportFormatJSON
+}
+
+func sendExport(ctx *gin.Context, name, title, format string, write func(utils.ExportWriter) error, onError func(*gin.Context, error)) {
+	sendAttachment(ctx, utils.ExportFileName(name, format), utils.ExportContentType(format), func(out io.Writer) error {
+		w, err := utils.NewExportWriter(format, title, out)
+		if err != nil {
+			return err
+		}
+		if err := write(w); err != nil {
+			w.Discard()
+			return err
+		}
+		return w.Close()
+	}, onError)
+}
+
+// sendAttachment streams a file as an attachment. Nothing reaches the client
+// before the first buffer is flushed, so a failure up to that point is still
+// answered by onError with a normal JSON error. Later failures can only cut the
+// download.
+func sendAttachment(ctx *gin.Context, filename, contentType string, write func(io.Writer) error, onError func(*gin.Context, error)) {
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	err := write(ctx.Writer)
+	if err == nil {
+		return
+	}
+	if ctx.Writer.Written() {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.Writer.Header().Del("Content-Type")
+	ctx.Writer.Header().Del("Content-Disposition")
+	onError(ctx, err)
+}
+
+func reportFileName(name string, req dto.ReportQuery) string {
+	return fmt.Sprintf("%s_%s_%s", name, req.From, req.To)
+}
+
+func abortExportError(ctx *gin.Context, err error) {
+	abortInternalError(ctx, err)
+}
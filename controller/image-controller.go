@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ImageController interface {
+		GetImage(ctx *gin.Context)
+	}
+	imageController struct {
+		storage  utils.Storage
+		imageDir string
+	}
+)
+
+func NewImageController(storage utils.Storage, cfg *config.Config) ImageController {
+	return &imageController{storage, cfg.Storage.ImageDir}
+}
+
+// GetImage redirects to a presigned URL when the storage backend hands them
+// out and streams the image through the app otherwise.
+func (i *imageController) GetImage(ctx *gin.Context) {
+	key := path.Join(i.imageDir, path.Base(ctx.Param("filename")))
+	url, err := i.storage.SignedURL(ctx.Request.Context(), key)
+	if err != nil {
+		res := utils.ReturnResponseError(500, dto.ErrISEImage.Error())
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+	if url != "" {
+		ctx.Redirect(http.StatusFound, url)
+		return
+	}
+	file, err := i.storage.Get(ctx.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			res := utils.ReturnResponseError(404, dto.ErrImageNotFound.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		res := utils.ReturnResponseError(500, dto.ErrISEImage.Error())
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+	defer file.Close()
+	if content, ok := file.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, key, time.Time{}, content)
+		return
+	}
+	ctx.DataFromReader(http.StatusOK, -1, mime.TypeByExtension(path.Ext(key)), file, nil)
+}
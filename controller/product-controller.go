@@ -1,7 +1,10 @@
 package controller
 
 import (
+	"io"
 	"net/http"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/service"
 	"tiga-putra-cashier-be/utils"
@@ -14,35 +17,89 @@ type (
 		GetProduct(ctx *gin.Context)
 		GetProductDetail(ctx *gin.Context)
 		SearchProduct(ctx *gin.Context)
+		AutocompleteProduct(ctx *gin.Context)
 		AddProduct(ctx *gin.Context)
 		UpdateProduct(ctx *gin.Context)
 		DeleteProduct(ctx *gin.Context)
+		ExportProduct(ctx *gin.Context)
+		ImportProduct(ctx *gin.Context)
+		AdjustPrices(ctx *gin.Context)
 	}
 	productController struct {
 		productService service.ProductService
+		requireIfMatch bool
 	}
 )
 
-func NewProductController(productService service.ProductService) ProductController {
-	return &productController{productService}
+// NewProductController requires If-Match on product updates and deletes when
+// the app is configured to.
+func NewProductController(productService service.ProductService, cfg *config.Config) ProductController {
+	return &productController{productService, cfg.App.RequireIfMatch}
+}
+
+// ifMatch returns the product version the request was made against, nil when
+// it doesn't name one. It aborts the request when the header is missing but
+// required, or holds an ETag no product ever had.
+func (p *productController) ifMatch(ctx *gin.Context) (*uint, bool) {
+	header := ctx.GetHeader("If-Match")
+	if header == "" && p.requireIfMatch {
+		res := utils.ReturnResponseError(428, dto.ErrIfMatchRequired.Error())
+		ctx.AbortWithStatusJSON(http.StatusPreconditionRequired, res)
+		return nil, false
+	}
+	version, ok := utils.ParseIfMatch(header)
+	if !ok {
+		res := utils.ReturnResponseError(412, dto.ErrVersionMismatch.Error())
+		ctx.AbortWithStatusJSON(http.StatusPreconditionFailed, res)
+		return nil, false
+	}
+	return version, true
 }
 
 func (p *productController) GetProduct(ctx *gin.Context) {
-	var req dto.PaginationRequest
+	var req dto.ProductListQuery
 	err := ctx.ShouldBindQuery(&req)
 	if err != nil {
 		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	products, err := p.productService.GetProductService(&req.Page)
-	if err == dto.ErrProductsNotFound {
+	if req.MinPrice != nil && req.MaxPrice != nil && req.MinPrice.GreaterThan(*req.MaxPrice) {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if req.Page == 0 {
+		p.getProductByCursor(ctx, &req)
+		return
+	}
+	products, err := p.productService.GetProductService(ctx.Request.Context(), &req)
+	if err == dto.ErrProductsNotFound || err == dto.ErrStoreDoesntExist {
 		res := utils.ReturnResponseError(404, err.Error())
 		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
 		return
 	} else if err == dto.ErrISEProducts {
-		res := utils.ReturnResponseError(500, err.Error())
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_ALL_PRODUCTS, products)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (p *productController) getProductByCursor(ctx *gin.Context, req *dto.ProductListQuery) {
+	products, err := p.productService.GetProductsByCursorService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrInvalidCursor {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrProductsNotFound || err == dto.ErrStoreDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
 		return
 	}
 	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_ALL_PRODUCTS, products)
@@ -56,14 +113,19 @@ func (p *productController) GetProductDetail(ctx *gin.Context) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	product, err := p.productService.GetProductDetailService(&req.BarcodeId)
+	var store dto.StoreQuery
+	_ = ctx.ShouldBindQuery(&store)
+	product, err := p.productService.GetProductDetailService(ctx.Request.Context(), &req.BarcodeId, store.StoreId)
 	if err != nil {
-		if err == dto.ErrProductDoesntExist {
+		if err == dto.ErrProductDoesntExist || err == dto.ErrStoreDoesntExist {
 			res := utils.ReturnResponseError(404, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
 			return
 		}
+		abortInternalError(ctx, err)
+		return
 	}
+	ctx.Header("ETag", utils.ETag(product.Version))
 	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_PRODUCT_DETAIL, product)
 	ctx.JSON(http.StatusOK, res)
 }
@@ -71,26 +133,41 @@ func (p *productController) GetProductDetail(ctx *gin.Context) {
 func (p *productController) SearchProduct(ctx *gin.Context) {
 	var req dto.SearchProductQuery
 	_ = ctx.ShouldBindQuery(&req)
-	if req.BarcodeId == nil && req.Title == nil {
+	if req.Query == "" && req.BarcodeId == nil && req.Title == nil {
 		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	products, err := p.productService.SearchProductService(&req)
+	products, err := p.productService.SearchProductService(ctx.Request.Context(), &req)
 	if err != nil {
-		if err == dto.ErrProductsNotFound {
+		if err == dto.ErrProductsNotFound || err == dto.ErrStoreDoesntExist {
 			res := utils.ReturnResponseError(404, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
 			return
 		}
-		res := utils.ReturnResponseError(500, err.Error())
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		abortInternalError(ctx, err)
 		return
 	}
 	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_SEARCH_PRODUCTS, products)
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (p *productController) AutocompleteProduct(ctx *gin.Context) {
+	var req dto.AutocompleteQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	suggestions, err := p.productService.AutocompleteProductService(ctx.Request.Context(), req.Query)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_AUTOCOMPLETE, suggestions)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (p *productController) AddProduct(ctx *gin.Context) {
 	var req dto.AddProductRequest
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -98,7 +175,7 @@ func (p *productController) AddProduct(ctx *gin.Context) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	if err := p.productService.CreateProductService(req); err != nil {
+	if err := p.productService.CreateProductService(ctx.Request.Context(), req); err != nil {
 		if err == dto.ErrWrongFileExtension {
 			res := utils.ReturnResponseError(400, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -109,13 +186,17 @@ func (p *productController) AddProduct(ctx *gin.Context) {
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 			return
 		}
+		if err == dto.ErrInvalidImage || err == dto.ErrImageTooLarge {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
 		if err == dto.ErrProductExist {
 			res := utils.ReturnResponseError(409, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusConflict, res)
 			return
 		}
-		res := utils.ReturnResponseError(500, err.Error())
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		abortInternalError(ctx, err)
 		return
 	}
 	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_ADD_PRODUCT)
@@ -129,22 +210,40 @@ func (p *productController) UpdateProduct(ctx *gin.Context) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
+	version, ok := p.ifMatch(ctx)
+	if !ok {
+		return
+	}
 	var req dto.UpdateProductRequest
 	_ = ctx.ShouldBind(&req)
-	err := p.productService.UpdateProductService(barcodeId.BarcodeId, req)
+	err := p.productService.UpdateProductService(ctx.Request.Context(), barcodeId.BarcodeId, req, version)
 	if err != nil {
 		if err == dto.ErrProductDoesntExist {
 			res := utils.ReturnResponseError(404, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
 			return
 		}
+		if err == dto.ErrVersionMismatch {
+			res := utils.ReturnResponseError(412, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusPreconditionFailed, res)
+			return
+		}
+		if err == dto.ErrInvalidStockLevel {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrWrongFileExtension || err == dto.ErrLimitSizeExceeded || err == dto.ErrInvalidImage || err == dto.ErrImageTooLarge {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
 		if err == dto.ErrNoChangesRequest {
 			res := utils.ReturnResponseError(304, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusNotModified, res)
 			return
 		}
-		res := utils.ReturnResponseError(500, err.Error())
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		abortInternalError(ctx, err)
 		return
 	}
 	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_UPDATE_PRODUCT)
@@ -158,16 +257,102 @@ func (p *productController) DeleteProduct(ctx *gin.Context) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	if err := p.productService.DeleteProductService(&req.BarcodeId); err != nil {
+	version, ok := p.ifMatch(ctx)
+	if !ok {
+		return
+	}
+	if err := p.productService.DeleteProductService(ctx.Request.Context(), &req.BarcodeId, version); err != nil {
 		if err == dto.ErrProductDoesntExist {
 			res := utils.ReturnResponseError(404, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
 			return
 		}
-		res := utils.ReturnResponseError(500, err.Error())
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		if err == dto.ErrVersionMismatch {
+			res := utils.ReturnResponseError(412, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusPreconditionFailed, res)
+			return
+		}
+		abortInternalError(ctx, err)
 		return
 	}
 	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_DELETE_PRODUCT)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (p *productController) ExportProduct(ctx *gin.Context) {
+	var req dto.ExportProductQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if !isExportFormat(req.Format) {
+		req.Format = constant.ExportFormatCSV
+	}
+	if req.WithImages {
+		sendAttachment(ctx, "products.zip", "application/zip", func(w io.Writer) error {
+			return p.productService.ExportProductArchiveService(ctx.Request.Context(), req, w)
+		}, abortExportError)
+		return
+	}
+	sendExport(ctx, "products", "Products", req.Format, func(w utils.ExportWriter) error {
+		_, err := p.productService.ExportProductsService(ctx.Request.Context(), req.IncludeDeleted, w)
+		return err
+	}, abortExportError)
+}
+
+func (p *productController) ImportProduct(ctx *gin.Context) {
+	var req dto.ImportProductRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	result, err := p.productService.ImportProductsService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrWrongImportFileExtension || err == dto.ErrImportFileTooLarge || err == dto.ErrInvalidImportFile ||
+			err == dto.ErrInvalidImportHeader || err == dto.ErrInvalidImageArchive {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	message := dto.MESSAGE_SUCCESS_IMPORT_PRODUCTS
+	if result.DryRun {
+		message = dto.MESSAGE_SUCCESS_VALIDATE_PRODUCTS
+	}
+	res := utils.ReturnResponseSuccess(200, message, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (p *productController) AdjustPrices(ctx *gin.Context) {
+	var req dto.PriceAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	result, err := p.productService.AdjustPricesService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrNoPriceFilter || err == dto.ErrInvalidPriceAdjustment || err == dto.ErrNegativeAdjustedPrice {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrProductsNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	message := dto.MESSAGE_SUCCESS_ADJUST_PRICES
+	if result.Preview {
+		message = dto.MESSAGE_SUCCESS_PREVIEW_PRICE_ADJUSTMENT
+	}
+	res := utils.ReturnResponseSuccess(200, message, result)
+	ctx.JSON(http.StatusOK, res)
+}
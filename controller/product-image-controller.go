@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ProductImageController interface {
+		GetProductImages(ctx *gin.Context)
+		AddProductImage(ctx *gin.Context)
+		ReorderProductImages(ctx *gin.Context)
+		SetPrimaryProductImage(ctx *gin.Context)
+		DeleteProductImage(ctx *gin.Context)
+	}
+	productImageController struct {
+		productImageService service.ProductImageService
+	}
+)
+
+func NewProductImageController(productImageService service.ProductImageService) ProductImageController {
+	return &productImageController{productImageService}
+}
+
+func (p *productImageController) GetProductImages(ctx *gin.Context) {
+	var uri dto.ProductBarcodeIdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	images, err := p.productImageService.GetProductImagesService(ctx.Request.Context(), uri.BarcodeId)
+	if err != nil {
+		abortProductImageError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_PRODUCT_IMAGES, images)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (p *productImageController) AddProductImage(ctx *gin.Context) {
+	var uri dto.ProductBarcodeIdURI
+	var req dto.AddProductImageRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	image, err := p.productImageService.AddProductImageService(ctx.Request.Context(), uri.BarcodeId, req.Image)
+	if err != nil {
+		abortProductImageError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_ADD_PRODUCT_IMAGE, image)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (p *productImageController) ReorderProductImages(ctx *gin.Context) {
+	var uri dto.ProductBarcodeIdURI
+	var req dto.ReorderProductImagesRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	images, err := p.productImageService.ReorderProductImagesService(ctx.Request.Context(), uri.BarcodeId, req.ImageIds)
+	if err != nil {
+		abortProductImageError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_REORDER_PRODUCT_IMAGES, images)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (p *productImageController) SetPrimaryProductImage(ctx *gin.Context) {
+	var uri dto.ProductImageURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err := p.productImageService.SetPrimaryProductImageService(ctx.Request.Context(), uri.BarcodeId, uri.ImageId); err != nil {
+		abortProductImageError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_SET_PRIMARY_IMAGE)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (p *productImageController) DeleteProductImage(ctx *gin.Context) {
+	var uri dto.ProductImageURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err := p.productImageService.DeleteProductImageService(ctx.Request.Context(), uri.BarcodeId, uri.ImageId); err != nil {
+		abortProductImageError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_DELETE_PRODUCT_IMAGE)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func abortProductImageError(ctx *gin.Context, err error) {
+	switch err {
+	case dto.ErrProductDoesntExist, dto.ErrProductImageNotFound:
+		res := utils.ReturnResponseError(404, err.Error())
+		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+	case dto.ErrWrongFileExtension, dto.ErrLimitSizeExceeded, dto.ErrInvalidImage, dto.ErrImageTooLarge,
+		dto.ErrTooManyProductImages, dto.ErrInvalidImageOrder:
+		res := utils.ReturnResponseError(400, err.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+	default:
+		abortInternalError(ctx, err)
+	}
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ReportController interface {
+		GetSalesSummary(ctx *gin.Context)
+		GetHourlySales(ctx *gin.Context)
+		GetCashierSales(ctx *gin.Context)
+		GetPaymentMethodSales(ctx *gin.Context)
+		GetCategorySales(ctx *gin.Context)
+		GetTopProducts(ctx *gin.Context)
+		GetStockValuation(ctx *gin.Context)
+	}
+	reportController struct {
+		reportService service.ReportService
+	}
+)
+
+func NewReportController(reportService service.ReportService) ReportController {
+	return &reportController{reportService}
+}
+
+func (r *reportController) GetSalesSummary(ctx *gin.Context) {
+	var req dto.SalesSummaryQuery
+	var export dto.ExportQuery
+	if ctx.ShouldBindQuery(&req) != nil || ctx.ShouldBindQuery(&export) != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if isExportFormat(export.Format) {
+		sendExport(ctx, reportFileName("sales-summary", req.ReportQuery), "Sales Summary", export.Format, func(w utils.ExportWriter) error {
+			return r.reportService.ExportSalesSummaryService(ctx.Request.Context(), req, w)
+		}, abortReportError)
+		return
+	}
+	totals, err := r.reportService.GetSalesSummaryService(ctx.Request.Context(), req)
+	if err != nil {
+		abortReportError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_SALES_SUMMARY, totals)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (r *reportController) GetHourlySales(ctx *gin.Context) {
+	var req dto.ReportQuery
+	var export dto.ExportQuery
+	if ctx.ShouldBindQuery(&req) != nil || ctx.ShouldBindQuery(&export) != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if isExportFormat(export.Format) {
+		sendExport(ctx, reportFileName("hourly-sales", req), "Hourly Sales", export.Format, func(w utils.ExportWriter) error {
+			return r.reportService.ExportHourlySalesService(ctx.Request.Context(), req, w)
+		}, abortReportError)
+		return
+	}
+	sales, err := r.reportService.GetHourlySalesService(ctx.Request.Context(), req)
+	if err != nil {
+		abortReportError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_HOURLY_SALES, sales)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (r *reportController) GetCashierSales(ctx *gin.Context) {
+	var req dto.ReportQuery
+	var export dto.ExportQuery
+	if ctx.ShouldBindQuery(&req) != nil || ctx.ShouldBindQuery(&export) != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if isExportFormat(export.Format) {
+		sendExport(ctx, reportFileName("cashier-sales", req), "Sales By Cashier", export.Format, func(w utils.ExportWriter) error {
+			return r.reportService.ExportCashierSalesService(ctx.Request.Context(), req, w)
+		}, abortReportError)
+		return
+	}
+	sales, err := r.reportService.GetCashierSalesService(ctx.Request.Context(), req)
+	if err != nil {
+		abortReportError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_CASHIER_SALES, sales)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (r *reportController) GetPaymentMethodSales(ctx *gin.Context) {
+	var req dto.ReportQuery
+	var export dto.ExportQuery
+	if ctx.ShouldBindQuery(&req) != nil || ctx.ShouldBindQuery(&export) != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if isExportFormat(export.Format) {
+		sendExport(ctx, reportFileName("payment-method-sales", req), "Sales By Payment Method", export.Format, func(w utils.ExportWriter) error {
+			return r.reportService.ExportPaymentMethodSalesService(ctx.Request.Context(), req, w)
+		}, abortReportError)
+		return
+	}
+	sales, err := r.reportService.GetPaymentMethodSalesService(ctx.Request.Context(), req)
+	if err != nil {
+		abortReportError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_PAYMENT_METHOD_SALES, sales)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (r *reportController) GetCategorySales(ctx *gin.Context) {
+	var req dto.ReportQuery
+	var export dto.ExportQuery
+	if ctx.ShouldBindQuery(&req) != nil || ctx.ShouldBindQuery(&export) != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if isExportFormat(export.Format) {
+		sendExport(ctx, reportFileName("category-sales", req), "Sales By Category", export.Format, func(w utils.ExportWriter) error {
+			return r.reportService.ExportCategorySalesService(ctx.Request.Context(), req, w)
+		}, abortReportError)
+		return
+	}
+	sales, err := r.reportService.GetCategorySalesService(ctx.Request.Context(), req)
+	if err != nil {
+		abortReportError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_CATEGORY_SALES, sales)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (r *reportController) GetTopProducts(ctx *gin.Context) {
+	var req dto.TopProductsQuery
+	var export dto.ExportQuery
+	if ctx.ShouldBindQuery(&req) != nil || ctx.ShouldBindQuery(&export) != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if isExportFormat(export.Format) {
+		sendExport(ctx, reportFileName("top-products", req.ReportQuery), "Top Products", export.Format, func(w utils.ExportWriter) error {
+			return r.reportService.ExportTopProductsService(ctx.Request.Context(), req, w)
+		}, abortReportError)
+		return
+	}
+	products, err := r.reportService.GetTopProductsService(ctx.Request.Context(), req)
+	if err != nil {
+		abortReportError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_TOP_PRODUCTS, products)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (r *reportController) GetStockValuation(ctx *gin.Context) {
+	var req dto.StoreQuery
+	var export dto.ExportQuery
+	if ctx.ShouldBindQuery(&req) != nil || ctx.ShouldBindQuery(&export) != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if isExportFormat(export.Format) {
+		sendExport(ctx, "stock-valuation", "Stock Valuation", export.Format, func(w utils.ExportWriter) error {
+			return r.reportService.ExportStockValuationService(ctx.Request.Context(), req.StoreId, w)
+		}, abortReportError)
+		return
+	}
+	valuations, err := r.reportService.GetStockValuationService(ctx.Request.Context(), req.StoreId)
+	if err != nil {
+		abortReportError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_STOCK_VALUATION, valuations)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func abortReportError(ctx *gin.Context, err error) {
+	if err == dto.ErrInvalidReportRange {
+		res := utils.ReturnResponseError(400, err.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	abortInternalError(ctx, err)
+}
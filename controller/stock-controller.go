@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	StockController interface {
+		GetLowStockProducts(ctx *gin.Context)
+		GetReorderSuggestion(ctx *gin.Context)
+		ReceiveStock(ctx *gin.Context)
+		GetExpiringBatches(ctx *gin.Context)
+		WriteOffBatch(ctx *gin.Context)
+		GetStockLevels(ctx *gin.Context)
+	}
+	stockController struct {
+		stockService service.StockService
+	}
+)
+
+func NewStockController(stockService service.StockService) StockController {
+	return &stockController{stockService}
+}
+
+func (s *stockController) GetLowStockProducts(ctx *gin.Context) {
+	var req dto.StoreQuery
+	_ = ctx.ShouldBindQuery(&req)
+	products, err := s.stockService.GetLowStockProductsService(ctx.Request.Context(), req.StoreId)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_LOW_STOCK_PRODUCTS, products)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockController) GetReorderSuggestion(ctx *gin.Context) {
+	var req dto.ReorderSuggestionQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	suggestion, err := s.stockService.GetReorderSuggestionService(ctx.Request.Context(), req)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_REORDER_SUGGESTION, suggestion)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockController) ReceiveStock(ctx *gin.Context) {
+	var req dto.ReceiveStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	batch, err := s.stockService.ReceiveStockService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrInvalidExpiryDate {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrProductDoesntExist || err == dto.ErrStoreDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_RECEIVE_STOCK, batch)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockController) GetExpiringBatches(ctx *gin.Context) {
+	var req dto.ExpiringBatchQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	batches, err := s.stockService.GetExpiringBatchesService(ctx.Request.Context(), req)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_EXPIRING_BATCHES, batches)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockController) WriteOffBatch(ctx *gin.Context) {
+	var uri dto.StockBatchURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.WriteOffBatchRequest
+	_ = ctx.ShouldBindJSON(&req)
+	batch, err := s.stockService.WriteOffBatchService(ctx.Request.Context(), uri.BatchId, req)
+	if err != nil {
+		if err == dto.ErrBatchNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrBatchEmpty {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_WRITE_OFF_BATCH, batch)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockController) GetStockLevels(ctx *gin.Context) {
+	var uri dto.StockLevelURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	levels, err := s.stockService.GetStockLevelsService(ctx.Request.Context(), uri.BarcodeId)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_STOCK_LEVELS, levels)
+	ctx.JSON(http.StatusOK, res)
+}
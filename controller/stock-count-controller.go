@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	StockCountController interface {
+		StartStockCount(ctx *gin.Context)
+		SubmitStockCount(ctx *gin.Context)
+		GetStockCountReport(ctx *gin.Context)
+		GetUncountedProducts(ctx *gin.Context)
+		ApproveStockCount(ctx *gin.Context)
+	}
+	stockCountController struct {
+		stockCountService service.StockCountService
+	}
+)
+
+func NewStockCountController(stockCountService service.StockCountService) StockCountController {
+	return &stockCountController{stockCountService}
+}
+
+func (s *stockCountController) StartStockCount(ctx *gin.Context) {
+	var req dto.StartStockCountRequest
+	_ = ctx.ShouldBindJSON(&req)
+	session, err := s.stockCountService.StartStockCountService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrStockCountAlreadyOpen {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		if err == dto.ErrStoreDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_START_STOCK_COUNT, session)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockCountController) SubmitStockCount(ctx *gin.Context) {
+	var uri dto.StockCountSessionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.SubmitStockCountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err := s.stockCountService.SubmitStockCountService(ctx.Request.Context(), uri.SessionId, req); err != nil {
+		if err == dto.ErrUnknownBarcode {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrStockCountNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrStockCountNotOpen {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_SUBMIT_STOCK_COUNT)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockCountController) GetStockCountReport(ctx *gin.Context) {
+	var uri dto.StockCountSessionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	report, err := s.stockCountService.GetStockCountReportService(ctx.Request.Context(), uri.SessionId)
+	if err != nil {
+		if err == dto.ErrStockCountNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_STOCK_COUNT_REPORT, report)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockCountController) GetUncountedProducts(ctx *gin.Context) {
+	var uri dto.StockCountSessionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	uncounted, err := s.stockCountService.GetUncountedProductsService(ctx.Request.Context(), uri.SessionId)
+	if err != nil {
+		if err == dto.ErrStockCountNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_UNCOUNTED_PRODUCTS, uncounted)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockCountController) ApproveStockCount(ctx *gin.Context) {
+	var uri dto.StockCountSessionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.ApproveStockCountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	variances, err := s.stockCountService.ApproveStockCountService(ctx.Request.Context(), uri.SessionId, req)
+	if err != nil {
+		if err == dto.ErrStockCountNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrStockCountNotOpen || err == dto.ErrUncountedProducts {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_APPROVE_STOCK_COUNT, variances)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	StockTransferController interface {
+		CreateStockTransfer(ctx *gin.Context)
+		GetStockTransfers(ctx *gin.Context)
+		GetStockTransferDetail(ctx *gin.Context)
+		ReceiveStockTransfer(ctx *gin.Context)
+	}
+	stockTransferController struct {
+		stockTransferService service.StockTransferService
+	}
+)
+
+func NewStockTransferController(stockTransferService service.StockTransferService) StockTransferController {
+	return &stockTransferController{stockTransferService}
+}
+
+func (s *stockTransferController) CreateStockTransfer(ctx *gin.Context) {
+	var req dto.CreateStockTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	transfer, err := s.stockTransferService.CreateStockTransferService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrSameStoreTransfer {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrStoreDoesntExist || err == dto.ErrProductDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrInsufficientStock || err == dto.ErrExpiredBatch {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_CREATE_STOCK_TRANSFER, transfer)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockTransferController) GetStockTransfers(ctx *gin.Context) {
+	var req dto.StockTransferQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	transfers, err := s.stockTransferService.GetStockTransfersService(ctx.Request.Context(), req)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_STOCK_TRANSFERS, transfers)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockTransferController) GetStockTransferDetail(ctx *gin.Context) {
+	var uri dto.StockTransferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	transfer, err := s.stockTransferService.GetStockTransferDetailService(ctx.Request.Context(), uri.TransferId)
+	if err != nil {
+		if err == dto.ErrTransferNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_STOCK_TRANSFER_DETAIL, transfer)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *stockTransferController) ReceiveStockTransfer(ctx *gin.Context) {
+	var uri dto.StockTransferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.ReceiveStockTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	transfer, err := s.stockTransferService.ReceiveStockTransferService(ctx.Request.Context(), uri.TransferId, req)
+	if err != nil {
+		if err == dto.ErrTransferNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrTransferNotInTransit {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_RECEIVE_STOCK_TRANSFER, transfer)
+	ctx.JSON(http.StatusOK, res)
+}
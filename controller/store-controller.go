@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	StoreController interface {
+		GetStores(ctx *gin.Context)
+		AddStore(ctx *gin.Context)
+		UpdateStore(ctx *gin.Context)
+		GetStorePrices(ctx *gin.Context)
+		SetStorePrice(ctx *gin.Context)
+		DeleteStorePrice(ctx *gin.Context)
+	}
+	storeController struct {
+		storeService service.StoreService
+	}
+)
+
+func NewStoreController(storeService service.StoreService) StoreController {
+	return &storeController{storeService}
+}
+
+func (s *storeController) GetStores(ctx *gin.Context) {
+	stores, err := s.storeService.GetStoresService(ctx.Request.Context())
+	if err != nil {
+		if err == dto.ErrStoresNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_ALL_STORES, stores)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *storeController) AddStore(ctx *gin.Context) {
+	var req dto.AddStoreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	store, err := s.storeService.CreateStoreService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrInvalidStoreType {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrStoreExist {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_ADD_STORE, store)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *storeController) UpdateStore(ctx *gin.Context) {
+	var uri dto.StoreIdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.UpdateStoreRequest
+	_ = ctx.ShouldBindJSON(&req)
+	if err := s.storeService.UpdateStoreService(ctx.Request.Context(), uri.StoreId, req); err != nil {
+		if err == dto.ErrStoreDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrInvalidStoreType {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrNoChangesRequest {
+			res := utils.ReturnResponseError(304, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotModified, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_UPDATE_STORE)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *storeController) GetStorePrices(ctx *gin.Context) {
+	var uri dto.StoreIdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	prices, err := s.storeService.GetStorePricesService(ctx.Request.Context(), uri.StoreId)
+	if err != nil {
+		if err == dto.ErrStoreDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_STORE_PRICES, prices)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *storeController) SetStorePrice(ctx *gin.Context) {
+	var uri dto.StorePriceURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.SetStorePriceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err := s.storeService.SetStorePriceService(ctx.Request.Context(), uri.StoreId, uri.BarcodeId, req); err != nil {
+		if err == dto.ErrInvalidPrice {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrStoreDoesntExist || err == dto.ErrProductDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_SET_STORE_PRICE)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *storeController) DeleteStorePrice(ctx *gin.Context) {
+	var uri dto.StorePriceURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err := s.storeService.DeleteStorePriceService(ctx.Request.Context(), uri.StoreId, uri.BarcodeId); err != nil {
+		if err == dto.ErrStorePriceNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_DELETE_STORE_PRICE)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SupplierController interface {
+		GetSuppliers(ctx *gin.Context)
+		AddSupplier(ctx *gin.Context)
+		UpdateSupplier(ctx *gin.Context)
+	}
+	supplierController struct {
+		supplierService service.SupplierService
+	}
+)
+
+func NewSupplierController(supplierService service.SupplierService) SupplierController {
+	return &supplierController{supplierService}
+}
+
+func (s *supplierController) GetSuppliers(ctx *gin.Context) {
+	suppliers, err := s.supplierService.GetSuppliersService(ctx.Request.Context())
+	if err != nil {
+		if err == dto.ErrSuppliersNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_ALL_SUPPLIERS, suppliers)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *supplierController) AddSupplier(ctx *gin.Context) {
+	var req dto.AddSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	supplier, err := s.supplierService.CreateSupplierService(ctx.Request.Context(), req)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_ADD_SUPPLIER, supplier)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (s *supplierController) UpdateSupplier(ctx *gin.Context) {
+	var uri dto.SupplierIdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.UpdateSupplierRequest
+	_ = ctx.ShouldBindJSON(&req)
+	if err := s.supplierService.UpdateSupplierService(ctx.Request.Context(), uri.SupplierId, req); err != nil {
+		if err == dto.ErrSupplierDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrNoChangesRequest {
+			res := utils.ReturnResponseError(304, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotModified, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_UPDATE_SUPPLIER)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SyncController interface {
+		SyncProducts(ctx *gin.Context)
+	}
+	syncController struct {
+		syncService service.SyncService
+	}
+)
+
+func NewSyncController(syncService service.SyncService) SyncController {
+	return &syncController{syncService}
+}
+
+func (s *syncController) SyncProducts(ctx *gin.Context) {
+	var req dto.SyncQuery
+	_ = ctx.ShouldBindQuery(&req)
+	changes, err := s.syncService.SyncProductsService(ctx.Request.Context(), req.Since)
+	if err != nil {
+		if err == dto.ErrInvalidSyncToken {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_SYNC_PRODUCTS, changes)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"net/http"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	TransactionController interface {
+		CreateTransaction(ctx *gin.Context)
+		GetTransactionDetail(ctx *gin.Context)
+		UploadOfflineSales(ctx *gin.Context)
+		GetSaleConflicts(ctx *gin.Context)
+		ResolveSaleConflict(ctx *gin.Context)
+	}
+	transactionController struct {
+		transactionService service.TransactionService
+	}
+)
+
+func NewTransactionController(transactionService service.TransactionService) TransactionController {
+	return &transactionController{transactionService}
+}
+
+func (t *transactionController) CreateTransaction(ctx *gin.Context) {
+	var req dto.CreateTransactionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	transaction, err := t.transactionService.CreateTransactionService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrOverrideApproverRequired {
+			res := utils.ReturnResponseError(400, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+		if err == dto.ErrProductDoesntExist || err == dto.ErrStoreDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrInsufficientStock || err == dto.ErrExpiredBatch {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_CREATE_TRANSACTION, transaction)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (t *transactionController) GetTransactionDetail(ctx *gin.Context) {
+	var uri dto.TransactionIdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	transaction, err := t.transactionService.GetTransactionDetailService(ctx.Request.Context(), uri.TransactionId)
+	if err != nil {
+		if err == dto.ErrTransactionNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_TRANSACTION_DETAIL, transaction)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (t *transactionController) UploadOfflineSales(ctx *gin.Context) {
+	var req dto.UploadOfflineSalesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	results, err := t.transactionService.UploadOfflineSalesService(ctx.Request.Context(), req)
+	if err != nil {
+		if err == dto.ErrStoreDoesntExist {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_UPLOAD_OFFLINE_SALES, results)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (t *transactionController) GetSaleConflicts(ctx *gin.Context) {
+	var query dto.SaleConflictQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	conflicts, err := t.transactionService.GetSaleConflictsService(ctx.Request.Context(), query)
+	if err != nil {
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_GET_SALE_CONFLICTS, conflicts)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (t *transactionController) ResolveSaleConflict(ctx *gin.Context) {
+	var uri dto.SaleConflictIdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	var req dto.ResolveSaleConflictRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	conflict, err := t.transactionService.ResolveSaleConflictService(ctx.Request.Context(), uri.ConflictId, req)
+	if err != nil {
+		if err == dto.ErrSaleConflictNotFound {
+			res := utils.ReturnResponseError(404, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+			return
+		}
+		if err == dto.ErrSaleConflictResolved {
+			res := utils.ReturnResponseError(409, err.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		abortInternalError(ctx, err)
+		return
+	}
+	res := utils.ReturnResponseSuccess(200, dto.MESSAGE_SUCCESS_RESOLVE_SALE_CONFLICT, conflict)
+	ctx.JSON(http.StatusOK, res)
+}
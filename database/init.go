@@ -2,38 +2,24 @@ package database
 
 import (
 	"fmt"
-	"os"
+	"tiga-putra-cashier-be/config"
 
-	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func InitDB() *gorm.DB {
+func InitDB(cfg *config.Config) *gorm.DB {
+	db := cfg.Database
+	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=Asia/Jakarta", db.Host, db.User, db.Pass, db.Name, db.Port)
 
-	if os.Getenv("APP_ENV") != "TEST" {
-		err := godotenv.Load(".env")
-		if err != nil {
-			panic("Failed to load ENV for database")
-		}
-	}
-
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	dbHost := os.Getenv("DB_HOST")
-	dbName := os.Getenv("DB_NAME")
-	dbPort := os.Getenv("DB_PORT")
-
-	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=Asia/Jakarta", dbHost, dbUser, dbPass, dbName, dbPort)
-
-	db, err := gorm.Open(postgres.New(postgres.Config{
+	conn, err := gorm.Open(postgres.New(postgres.Config{
 		DSN:                  dsn,
 		PreferSimpleProtocol: true,
 	}), &gorm.Config{})
 	if err != nil {
 		panic(err)
 	}
-	return db
+	return conn
 }
 
 func CloseDB(db *gorm.DB) {
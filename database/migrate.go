@@ -1,26 +1,335 @@
 package database
 
 import (
+	"embed"
+	"fmt"
+	"io/fs"
 	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
+	"time"
 
 	"gorm.io/gorm"
 )
 
-func MigrateUp(db *gorm.DB) error {
-	err := db.AutoMigrate(&entity.Product{})
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// MigrationFiles are the migrations shipped with the app.
+var MigrationFiles, _ = fs.Sub(embeddedMigrations, "migrations")
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamptz NOT NULL)`
+
+// Migration is one numbered change of the schema. Applied versions are kept
+// in schema_migrations.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator reads the migrations in fsys, which need an up and a down file
+// each.
+func NewMigrator(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db, migrations}, nil
+}
+
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", dto.ErrInvalidMigrationFile, entry.Name())
+		}
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("%w: %s", dto.ErrInvalidMigrationFile, entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %s and %s", dto.ErrInvalidMigrationFile, version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%w: %04d_%s has no up file", dto.ErrInvalidMigrationFile, migration.Version, migration.Name)
+		}
+		if migration.Down == "" {
+			return nil, fmt.Errorf("%w: %04d_%s has no down file", dto.ErrInvalidMigrationFile, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return int(a.Version) - int(b.Version) })
+	return migrations, nil
+}
+
+// Status lists every migration with when it was applied, oldest first.
+func (m *Migrator) Status() ([]dto.MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var status []dto.MigrationStatus
+	for _, migration := range m.migrations {
+		row := dto.MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			row.AppliedAt = &record.AppliedAt
+		}
+		status = append(status, row)
+	}
+	for _, record := range applied {
+		if m.find(record.Version) == nil {
+			status = append(status, dto.MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &record.AppliedAt, Missing: true})
+		}
+	}
+	slices.SortFunc(status, func(a, b dto.MigrationStatus) int { return int(a.Version) - int(b.Version) })
+	return status, nil
+}
+
+// Check fails with ErrPendingMigrations unless every migration is applied.
+// The server runs it before serving, so it never works against a schema it
+// doesn't know.
+func (m *Migrator) Check() error {
+	applied, err := m.applied()
 	if err != nil {
-		log.Println("Migration has been processed")
 		return err
 	}
+	var pending []string
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, fmt.Sprintf("%04d_%s", migration.Version, migration.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s pending", dto.ErrPendingMigrations, strings.Join(pending, ", "))
+	}
+	for _, record := range applied {
+		if m.find(record.Version) == nil {
+			log.Printf("migration %04d_%s is applied but unknown to this version of the app", record.Version, record.Name)
+		}
+	}
 	return nil
 }
 
-func MigrateDown(db *gorm.DB) error {
-	err := db.Migrator().DropTable(&entity.Product{})
+// Up applies the next steps pending migrations, or all of them when steps is
+// zero.
+func (m *Migrator) Up(steps int) error {
+	if steps < 0 {
+		return dto.ErrInvalidMigrationSteps
+	}
+	applied, err := m.prepare()
 	if err != nil {
-		log.Println("Migration has been rolled back")
 		return err
 	}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration, true); err != nil {
+			return err
+		}
+		if steps--; steps == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// Down reverts the last steps applied migrations. Unlike Up it has no "all",
+// rolling everything back takes To(0).
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return dto.ErrInvalidMigrationSteps
+	}
+	applied, err := m.prepare()
+	if err != nil {
+		return err
+	}
+	for _, version := range descending(applied) {
+		if steps == 0 {
+			break
+		}
+		if err := m.revert(applied[version]); err != nil {
+			return err
+		}
+		steps--
+	}
 	return nil
 }
+
+// To migrates up or down until exactly the migrations up to version are
+// applied. Version 0 reverts everything.
+func (m *Migrator) To(version uint) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", dto.ErrMigrationNotFound, version)
+	}
+	applied, err := m.prepare()
+	if err != nil {
+		return err
+	}
+	for _, appliedVersion := range descending(applied) {
+		if appliedVersion <= version {
+			break
+		}
+		if err := m.revert(applied[appliedVersion]); err != nil {
+			return err
+		}
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version uint) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) revert(record entity.SchemaMigration) error {
+	migration := m.find(record.Version)
+	if migration == nil {
+		return fmt.Errorf("%w: %04d_%s", dto.ErrMigrationFileMissing, record.Version, record.Name)
+	}
+	return m.apply(*migration, false)
+}
+
+// prepare creates schema_migrations on the first run and reads what is
+// applied.
+func (m *Migrator) prepare() (map[uint]entity.SchemaMigration, error) {
+	if err := m.db.Exec(createSchemaMigrations).Error; err != nil {
+		return nil, err
+	}
+	return m.applied()
+}
+
+func (m *Migrator) applied() (map[uint]entity.SchemaMigration, error) {
+	var exists bool
+	if err := m.db.Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[uint]entity.SchemaMigration)
+	if !exists {
+		return applied, nil
+	}
+	var records []entity.SchemaMigration
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// apply runs one migration and records it in the same transaction. The lock
+// makes instances migrating at the same time take turns; whichever comes
+// second finds the migration done and skips it.
+func (m *Migrator) apply(migration Migration, up bool) error {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", constant.MigrationLockId).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&entity.SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if (count > 0) == up {
+			return nil
+		}
+		statements := migration.Down
+		if up {
+			statements = migration.Up
+		}
+		if hasStatements(statements) {
+			if err := tx.Exec(statements).Error; err != nil {
+				return err
+			}
+		}
+		if !up {
+			return tx.Where("version = ?", migration.Version).Delete(&entity.SchemaMigration{}).Error
+		}
+		return tx.Create(&entity.SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %04d_%s %s: %w", migration.Version, migration.Name, direction, err)
+	}
+	log.Printf("migrated %s %04d_%s", direction, migration.Version, migration.Name)
+	return nil
+}
+
+// hasStatements tells a migration that only explains why there is nothing to
+// do apart from one with SQL to run.
+func hasStatements(sql string) bool {
+	for _, line := range strings.Split(sql, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+func descending(applied map[uint]entity.SchemaMigration) []uint {
+	versions := make([]uint, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	slices.Reverse(versions)
+	return versions
+}
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS "idempotency_keys";
+DROP TABLE IF EXISTS "stock_transfer_items";
+DROP TABLE IF EXISTS "stock_transfers";
+DROP TABLE IF EXISTS "sale_conflicts";
+DROP TABLE IF EXISTS "transaction_items";
+DROP TABLE IF EXISTS "transactions";
+DROP TABLE IF EXISTS "stock_count_items";
+DROP TABLE IF EXISTS "stock_count_sessions";
+DROP TABLE IF EXISTS "stock_batches";
+DROP TABLE IF EXISTS "stock_movements";
+DROP TABLE IF EXISTS "store_prices";
+DROP TABLE IF EXISTS "product_images";
+DROP TABLE IF EXISTS "price_histories";
+DROP TABLE IF EXISTS "products";
+DROP TABLE IF EXISTS "suppliers";
+DROP TABLE IF EXISTS "stores";
@@ -0,0 +1,87 @@
+-- The schema as AutoMigrate created it. IF NOT EXISTS lets databases set up
+-- before versioned migrations adopt it as their first version.
+CREATE TABLE IF NOT EXISTS "stores" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"code" text,"name" text,"type" text,"address" text,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_stores_code" ON "stores" ("code");
+CREATE INDEX IF NOT EXISTS "idx_stores_deleted_at" ON "stores" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "suppliers" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"name" text,"phone" text,"lead_time_days" bigint,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_suppliers_deleted_at" ON "suppliers" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "products" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"barcode_id" text,"image" text,"title" text,"price" text,"description" text,"category" text,"min_stock" bigint,"reorder_quantity" bigint,"supplier_id" bigint,"version" bigint NOT NULL DEFAULT 1,PRIMARY KEY ("id"));
+-- The first AutoMigrate only created the columns up to description, which
+-- CREATE TABLE IF NOT EXISTS leaves as they are.
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "category" text;
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "min_stock" bigint;
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "reorder_quantity" bigint;
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "supplier_id" bigint;
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+CREATE INDEX IF NOT EXISTS "idx_products_supplier_id" ON "products" ("supplier_id");
+CREATE INDEX IF NOT EXISTS "idx_products_category" ON "products" ("category");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_products_barcode_id" ON "products" ("barcode_id");
+CREATE INDEX IF NOT EXISTS "idx_products_deleted_at" ON "products" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "price_histories" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"barcode_id" text,"price" text,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_price_histories_barcode_id" ON "price_histories" ("barcode_id");
+CREATE INDEX IF NOT EXISTS "idx_price_histories_deleted_at" ON "price_histories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_images" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"barcode_id" text NOT NULL,"image" text NOT NULL,"position" bigint NOT NULL DEFAULT 0,"is_primary" boolean NOT NULL DEFAULT false,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_product_images_barcode_id" ON "product_images" ("barcode_id");
+
+CREATE TABLE IF NOT EXISTS "store_prices" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"store_id" bigint,"barcode_id" text,"price" text,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_store_prices_deleted_at" ON "store_prices" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_store_price" ON "store_prices" ("store_id","barcode_id");
+
+CREATE TABLE IF NOT EXISTS "stock_movements" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"barcode_id" text,"quantity" bigint,"type" text,"reference" text,"note" text,"batch_id" bigint,"store_id" bigint,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_stock_movements_batch_id" ON "stock_movements" ("batch_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_movements_type" ON "stock_movements" ("type");
+CREATE INDEX IF NOT EXISTS "idx_stock_movements_barcode_id" ON "stock_movements" ("barcode_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_movements_deleted_at" ON "stock_movements" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_stock_movements_store_id" ON "stock_movements" ("store_id");
+
+CREATE TABLE IF NOT EXISTS "stock_batches" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"barcode_id" text,"batch_number" text,"expiry_date" date,"received_quantity" bigint,"remaining_quantity" bigint,"store_id" bigint,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_stock_batches_store_id" ON "stock_batches" ("store_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_batches_expiry_date" ON "stock_batches" ("expiry_date");
+CREATE INDEX IF NOT EXISTS "idx_stock_batches_barcode_id" ON "stock_batches" ("barcode_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_batches_deleted_at" ON "stock_batches" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "stock_count_sessions" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"status" text,"started_at" timestamptz,"approved_by" text,"approved_at" timestamptz,"note" text,"store_id" bigint,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_stock_count_sessions_store_id" ON "stock_count_sessions" ("store_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_count_sessions_status" ON "stock_count_sessions" ("status");
+CREATE INDEX IF NOT EXISTS "idx_stock_count_sessions_deleted_at" ON "stock_count_sessions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "stock_count_items" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"session_id" bigint,"barcode_id" text,"device_id" text,"counted_by" text,"counted_quantity" bigint,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_stock_count_item" ON "stock_count_items" ("session_id","barcode_id","device_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_count_items_deleted_at" ON "stock_count_items" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "transactions" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"client_id" text,"cashier" text,"payment_method" text,"total" text,"expired_override_by" text,"store_id" bigint,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_transactions_store_id" ON "transactions" ("store_id");
+CREATE INDEX IF NOT EXISTS "idx_transactions_payment_method" ON "transactions" ("payment_method");
+CREATE INDEX IF NOT EXISTS "idx_transactions_cashier" ON "transactions" ("cashier");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_transactions_client_id" ON "transactions" ("client_id");
+CREATE INDEX IF NOT EXISTS "idx_transactions_deleted_at" ON "transactions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "transaction_items" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"transaction_id" bigint,"barcode_id" text,"title" text,"quantity" bigint,"unit_price" text,"subtotal" text,PRIMARY KEY ("id"),CONSTRAINT "fk_transactions_items" FOREIGN KEY ("transaction_id") REFERENCES "transactions"("id"));
+CREATE INDEX IF NOT EXISTS "idx_transaction_items_transaction_id" ON "transaction_items" ("transaction_id");
+CREATE INDEX IF NOT EXISTS "idx_transaction_items_deleted_at" ON "transaction_items" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_transaction_items_barcode_id" ON "transaction_items" ("barcode_id");
+
+CREATE TABLE IF NOT EXISTS "sale_conflicts" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"transaction_id" bigint,"barcode_id" text,"type" text,"quantity" bigint,"detail" text,"resolved_by" text,"resolved_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_sale_conflicts_resolved_at" ON "sale_conflicts" ("resolved_at");
+CREATE INDEX IF NOT EXISTS "idx_sale_conflicts_type" ON "sale_conflicts" ("type");
+CREATE INDEX IF NOT EXISTS "idx_sale_conflicts_barcode_id" ON "sale_conflicts" ("barcode_id");
+CREATE INDEX IF NOT EXISTS "idx_sale_conflicts_transaction_id" ON "sale_conflicts" ("transaction_id");
+CREATE INDEX IF NOT EXISTS "idx_sale_conflicts_deleted_at" ON "sale_conflicts" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "stock_transfers" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"from_store_id" bigint,"to_store_id" bigint,"status" text,"created_by" text,"received_by" text,"received_at" timestamptz,"note" text,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_stock_transfers_status" ON "stock_transfers" ("status");
+CREATE INDEX IF NOT EXISTS "idx_stock_transfers_to_store_id" ON "stock_transfers" ("to_store_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_transfers_from_store_id" ON "stock_transfers" ("from_store_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_transfers_deleted_at" ON "stock_transfers" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "stock_transfer_items" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"transfer_id" bigint,"barcode_id" text,"quantity" bigint,"source_batch_id" bigint,PRIMARY KEY ("id"),CONSTRAINT "fk_stock_transfers_items" FOREIGN KEY ("transfer_id") REFERENCES "stock_transfers"("id"));
+CREATE INDEX IF NOT EXISTS "idx_stock_transfer_items_barcode_id" ON "stock_transfer_items" ("barcode_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_transfer_items_transfer_id" ON "stock_transfer_items" ("transfer_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_transfer_items_deleted_at" ON "stock_transfer_items" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "idempotency_keys" ("key" text,"request_hash" text,"status_code" bigint,"content_type" text,"body" bytea,"created_at" timestamptz,"expires_at" timestamptz,PRIMARY KEY ("key"));
+CREATE INDEX IF NOT EXISTS "idx_idempotency_keys_expires_at" ON "idempotency_keys" ("expires_at");
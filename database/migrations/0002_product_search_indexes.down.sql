@@ -0,0 +1,5 @@
+-- pg_trgm stays, other databases on the server may use it.
+DROP INDEX IF EXISTS idx_products_changed;
+DROP INDEX IF EXISTS idx_products_barcode_prefix;
+DROP INDEX IF EXISTS idx_products_title_trgm;
+DROP INDEX IF EXISTS idx_products_search;
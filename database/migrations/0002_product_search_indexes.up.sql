@@ -0,0 +1,9 @@
+-- The product search gets full-text on title and description, trigrams on
+-- title for typos and a pattern index for barcode prefixes; the catalog sync
+-- walks products by their last change. The indexed expressions have to match
+-- constant.ProductSearchDocument and constant.ProductChangedAt verbatim.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_products_search ON products USING GIN ((to_tsvector('simple', title || ' ' || description)));
+CREATE INDEX IF NOT EXISTS idx_products_title_trgm ON products USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_products_barcode_prefix ON products (barcode_id text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_products_changed ON products ((GREATEST(products.updated_at, COALESCE(products.deleted_at, products.updated_at))), id);
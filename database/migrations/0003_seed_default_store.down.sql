@@ -0,0 +1,2 @@
+-- Stock stays in the default store: which rows had none can't be told apart
+-- anymore, and the store itself is referenced by everything recorded since.
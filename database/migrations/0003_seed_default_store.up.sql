@@ -0,0 +1,20 @@
+-- The store every request without a store falls back to (constant.DefaultStoreId),
+-- and the stock recorded before stores existed moves into it. The store is
+-- found by its code; should it not have the id the app falls back to, the
+-- migration stops instead of attaching the stock to whichever store has it.
+INSERT INTO stores (created_at, updated_at, code, name, type)
+SELECT NOW(), NOW(), 'MAIN', 'Main Store', 'store'
+WHERE NOT EXISTS (SELECT 1 FROM stores WHERE code = 'MAIN');
+
+DO $$
+BEGIN
+	IF (SELECT id FROM stores WHERE code = 'MAIN') <> 1 THEN
+		RAISE EXCEPTION 'store MAIN has id %, the app expects the default store to have id 1', (SELECT id FROM stores WHERE code = 'MAIN');
+	END IF;
+END
+$$;
+
+UPDATE stock_movements SET store_id = (SELECT id FROM stores WHERE code = 'MAIN') WHERE store_id IS NULL OR store_id = 0;
+UPDATE stock_batches SET store_id = (SELECT id FROM stores WHERE code = 'MAIN') WHERE store_id IS NULL OR store_id = 0;
+UPDATE stock_count_sessions SET store_id = (SELECT id FROM stores WHERE code = 'MAIN') WHERE store_id IS NULL OR store_id = 0;
+UPDATE transactions SET store_id = (SELECT id FROM stores WHERE code = 'MAIN') WHERE store_id IS NULL OR store_id = 0;
@@ -0,0 +1,2 @@
+-- The backfilled rows are indistinguishable from images added since, so they
+-- stay.
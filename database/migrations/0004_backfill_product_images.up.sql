@@ -0,0 +1,6 @@
+-- Products created before products had several images get theirs as the
+-- primary one.
+INSERT INTO product_images (created_at, updated_at, barcode_id, image, position, is_primary)
+SELECT NOW(), NOW(), products.barcode_id, products.image, 0, TRUE FROM products
+WHERE products.deleted_at IS NULL AND products.image <> '' AND NOT EXISTS (
+	SELECT 1 FROM product_images WHERE product_images.barcode_id = products.barcode_id);
@@ -0,0 +1,6 @@
+ALTER TABLE products ALTER COLUMN price TYPE text USING price::text;
+ALTER TABLE price_histories ALTER COLUMN price TYPE text USING price::text;
+ALTER TABLE store_prices ALTER COLUMN price TYPE text USING price::text;
+ALTER TABLE transactions ALTER COLUMN total TYPE text USING total::text;
+ALTER TABLE transaction_items ALTER COLUMN unit_price TYPE text USING unit_price::text;
+ALTER TABLE transaction_items ALTER COLUMN subtotal TYPE text USING subtotal::text;
@@ -0,0 +1,8 @@
+-- Money was stored as text, which Postgres can neither sum nor compare by
+-- value. Values are kept to the cent, like the prices the app rounds.
+ALTER TABLE products ALTER COLUMN price TYPE numeric(14,2) USING price::numeric;
+ALTER TABLE price_histories ALTER COLUMN price TYPE numeric(14,2) USING price::numeric;
+ALTER TABLE store_prices ALTER COLUMN price TYPE numeric(14,2) USING price::numeric;
+ALTER TABLE transactions ALTER COLUMN total TYPE numeric(14,2) USING total::numeric;
+ALTER TABLE transaction_items ALTER COLUMN unit_price TYPE numeric(14,2) USING unit_price::numeric;
+ALTER TABLE transaction_items ALTER COLUMN subtotal TYPE numeric(14,2) USING subtotal::numeric;
@@ -0,0 +1,9 @@
+-- Offline sales are checked against the price their store had when they were
+-- made. Overrides set before this migration only count from their last
+-- change, which is all that is known about them.
+CREATE TABLE IF NOT EXISTS "store_price_histories" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"store_id" bigint,"barcode_id" text,"price" numeric(14,2),PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_store_price_histories_deleted_at" ON "store_price_histories" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_store_price_history" ON "store_price_histories" ("store_id","barcode_id");
+
+INSERT INTO store_price_histories (created_at, updated_at, store_id, barcode_id, price)
+SELECT updated_at, updated_at, store_id, barcode_id, price FROM store_prices WHERE deleted_at IS NULL;
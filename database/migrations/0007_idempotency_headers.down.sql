@@ -0,0 +1,3 @@
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS content_type text;
+UPDATE idempotency_keys SET content_type = headers->'Content-Type'->>0 WHERE headers->'Content-Type' IS NOT NULL;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS headers;
@@ -0,0 +1,5 @@
+-- Replays need more of the response than its content type, e.g. the ETag and
+-- Location of what a request created.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS headers jsonb;
+UPDATE idempotency_keys SET headers = jsonb_build_object('Content-Type', jsonb_build_array(content_type)) WHERE content_type <> '';
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS content_type;
@@ -2,8 +2,11 @@ package di
 
 import (
 	"log"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/database"
+	"tiga-putra-cashier-be/job"
+	"tiga-putra-cashier-be/middleware"
 	"tiga-putra-cashier-be/repository"
 	"tiga-putra-cashier-be/service"
 	"tiga-putra-cashier-be/utils"
@@ -14,10 +17,16 @@ import (
 
 func BuildContainer() *dig.Container {
 	container := dig.New()
+	if err := container.Provide(config.Load); err != nil {
+		log.Fatalf("Failed to provide config: %v", err)
+	}
 	if err := container.Provide(database.InitDB); err != nil {
 		log.Fatalf("Failed to provide database: %v", err)
 	}
 
+	if err := container.Provide(utils.NewStorage); err != nil {
+		log.Fatalf("Failed to provide storage: %v", err)
+	}
 	if err := container.Provide(utils.FileInit); err != nil {
 		log.Fatalf("Failed to provide file utils: %v", err)
 	}
@@ -25,13 +34,115 @@ func BuildContainer() *dig.Container {
 	if err := container.Provide(repository.NewProductRepository); err != nil {
 		log.Fatalf("Failed to provide product repository: %v", err)
 	}
+	if err := container.Provide(repository.NewProductImageRepository); err != nil {
+		log.Fatalf("Failed to provide product image repository: %v", err)
+	}
+	if err := container.Provide(repository.NewStockCountRepository); err != nil {
+		log.Fatalf("Failed to provide stock count repository: %v", err)
+	}
+	if err := container.Provide(repository.NewStockRepository); err != nil {
+		log.Fatalf("Failed to provide stock repository: %v", err)
+	}
+	if err := container.Provide(repository.NewSupplierRepository); err != nil {
+		log.Fatalf("Failed to provide supplier repository: %v", err)
+	}
+	if err := container.Provide(repository.NewTransactionRepository); err != nil {
+		log.Fatalf("Failed to provide transaction repository: %v", err)
+	}
+	if err := container.Provide(repository.NewStoreRepository); err != nil {
+		log.Fatalf("Failed to provide store repository: %v", err)
+	}
+	if err := container.Provide(repository.NewStockTransferRepository); err != nil {
+		log.Fatalf("Failed to provide stock transfer repository: %v", err)
+	}
+	if err := container.Provide(repository.NewReportRepository); err != nil {
+		log.Fatalf("Failed to provide report repository: %v", err)
+	}
+	if err := container.Provide(repository.NewSyncRepository); err != nil {
+		log.Fatalf("Failed to provide sync repository: %v", err)
+	}
+	if err := container.Provide(repository.NewIdempotencyRepository); err != nil {
+		log.Fatalf("Failed to provide idempotency repository: %v", err)
+	}
+
 	if err := container.Provide(service.NewProductService); err != nil {
 		log.Fatalf("Failed to provide product service: %v", err)
 	}
+	if err := container.Provide(service.NewProductImageService); err != nil {
+		log.Fatalf("Failed to provide product image service: %v", err)
+	}
+	if err := container.Provide(service.NewStockCountService); err != nil {
+		log.Fatalf("Failed to provide stock count service: %v", err)
+	}
+	if err := container.Provide(service.NewStockService); err != nil {
+		log.Fatalf("Failed to provide stock service: %v", err)
+	}
+	if err := container.Provide(service.NewSupplierService); err != nil {
+		log.Fatalf("Failed to provide supplier service: %v", err)
+	}
+	if err := container.Provide(service.NewTransactionService); err != nil {
+		log.Fatalf("Failed to provide transaction service: %v", err)
+	}
+	if err := container.Provide(service.NewStoreService); err != nil {
+		log.Fatalf("Failed to provide store service: %v", err)
+	}
+	if err := container.Provide(service.NewStockTransferService); err != nil {
+		log.Fatalf("Failed to provide stock transfer service: %v", err)
+	}
+	if err := container.Provide(service.NewReportService); err != nil {
+		log.Fatalf("Failed to provide report service: %v", err)
+	}
+	if err := container.Provide(service.NewSyncService); err != nil {
+		log.Fatalf("Failed to provide sync service: %v", err)
+	}
 
 	if err := container.Provide(controller.NewProductController); err != nil {
 		log.Fatalf("Failed to provide product controller: %v", err)
 	}
+	if err := container.Provide(controller.NewProductImageController); err != nil {
+		log.Fatalf("Failed to provide product image controller: %v", err)
+	}
+	if err := container.Provide(controller.NewStockCountController); err != nil {
+		log.Fatalf("Failed to provide stock count controller: %v", err)
+	}
+	if err := container.Provide(controller.NewStockController); err != nil {
+		log.Fatalf("Failed to provide stock controller: %v", err)
+	}
+	if err := container.Provide(controller.NewSupplierController); err != nil {
+		log.Fatalf("Failed to provide supplier controller: %v", err)
+	}
+	if err := container.Provide(controller.NewTransactionController); err != nil {
+		log.Fatalf("Failed to provide transaction controller: %v", err)
+	}
+	if err := container.Provide(controller.NewStoreController); err != nil {
+		log.Fatalf("Failed to provide store controller: %v", err)
+	}
+	if err := container.Provide(controller.NewStockTransferController); err != nil {
+		log.Fatalf("Failed to provide stock transfer controller: %v", err)
+	}
+	if err := container.Provide(controller.NewReportController); err != nil {
+		log.Fatalf("Failed to provide report controller: %v", err)
+	}
+	if err := container.Provide(controller.NewSyncController); err != nil {
+		log.Fatalf("Failed to provide sync controller: %v", err)
+	}
+	if err := container.Provide(controller.NewImageController); err != nil {
+		log.Fatalf("Failed to provide image controller: %v", err)
+	}
+
+	if err := container.Provide(middleware.NewIdempotencyMiddleware); err != nil {
+		log.Fatalf("Failed to provide idempotency middleware: %v", err)
+	}
+
+	if err := container.Provide(job.NewNotifier); err != nil {
+		log.Fatalf("Failed to provide notifier: %v", err)
+	}
+	if err := container.Provide(job.NewLowStockJob); err != nil {
+		log.Fatalf("Failed to provide low stock job: %v", err)
+	}
+	if err := container.Provide(job.NewImageGCJob); err != nil {
+		log.Fatalf("Failed to provide image gc job: %v", err)
+	}
 
 	if err := container.Provide(gin.Default); err != nil {
 		log.Fatalf("Failed to provide gin default instance: %v", err)
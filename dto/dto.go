@@ -3,6 +3,8 @@ package dto
 import "errors"
 
 var (
-	ErrBadrequest = errors.New("Missing or invalid request")
-	ErrToSaveFile = errors.New("Something wrong when saving file")
+	ErrBadrequest      = errors.New("Missing or invalid request")
+	ErrToSaveFile      = errors.New("Something wrong when saving file")
+	ErrRequestCanceled = errors.New("Request canceled by client")
+	ErrRequestTimeout  = errors.New("Request took too long to process")
 )
@@ -0,0 +1,14 @@
+package dto
+
+import "errors"
+
+var (
+	ErrUnsupportedExportFormat = errors.New("Export format should be csv, xlsx or pdf")
+	ErrToWriteExport           = errors.New("Failed to write export file")
+)
+
+type (
+	ExportQuery struct {
+		Format string `form:"format" binding:"omitempty,oneof=json csv xlsx pdf"`
+	}
+)
@@ -0,0 +1,11 @@
+package dto
+
+import "errors"
+
+var (
+	ErrIdempotencyKeyTooLong    = errors.New("Idempotency key is too long")
+	ErrIdempotencyKeyReused     = errors.New("Idempotency key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("A request with this idempotency key is still being processed")
+	ErrIdempotentBodyTooLarge   = errors.New("Request body is too large to be sent with an idempotency key")
+	ErrISEIdempotency           = errors.New("Failed to process idempotency key")
+)
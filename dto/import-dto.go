@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"errors"
+	"mime/multipart"
+)
+
+var (
+	ErrWrongImportFileExtension = errors.New("Import file should be csv or xlsx")
+	ErrImportFileTooLarge       = errors.New("Import file should be no more than 20MB and images no more than 500MB")
+	ErrInvalidImportFile        = errors.New("Import file can't be read")
+	ErrInvalidImportHeader      = errors.New("Import file should have barcode_id, title, price and description columns")
+	ErrInvalidImageArchive      = errors.New("Images should be uploaded as a zip archive")
+
+	ErrImportMissingField     = errors.New("Barcode, title, price and description are required")
+	ErrImportInvalidPrice     = errors.New("Price should be a number that isn't negative")
+	ErrImportInvalidNumber    = errors.New("Minimum stock, reorder quantity and supplier should be whole numbers")
+	ErrImportDuplicateBarcode = errors.New("Barcode appears more than once in the file")
+	ErrImportImageNotFound    = errors.New("Image is not in the uploaded archive")
+	ErrImportInvalidStatus    = errors.New("Status should be active or deleted")
+	ErrToDownloadImage        = errors.New("Failed to download image")
+	ErrImageURLNotPublic      = errors.New("Image URL should point to a public address")
+
+	MESSAGE_SUCCESS_IMPORT_PRODUCTS   = "Success Import Products"
+	MESSAGE_SUCCESS_VALIDATE_PRODUCTS = "Success Validate Products Import"
+
+	PRODUCT_IMPORT_COLUMNS = []string{"barcode_id", "title", "price", "description", "category", "image", "min_stock", "reorder_quantity", "supplier_id"}
+)
+
+type (
+	ImportProductRequest struct {
+		File   *multipart.FileHeader `form:"file" binding:"required"`
+		Images *multipart.FileHeader `form:"images"`
+		DryRun bool                  `form:"dry_run"`
+	}
+
+	ExportProductQuery struct {
+		Format         string `form:"format" binding:"omitempty,oneof=json csv xlsx pdf"`
+		IncludeDeleted bool   `form:"include_deleted"`
+		WithImages     bool   `form:"with_images"`
+	}
+
+	ImportProductRowResult struct {
+		Row       int      `json:"row"`
+		BarcodeId string   `json:"barcode_id"`
+		Status    string   `json:"status"`
+		Errors    []string `json:"errors,omitempty"`
+	}
+
+	ImportProductResult struct {
+		DryRun    bool                     `json:"dry_run"`
+		Total     int                      `json:"total"`
+		Created   int                      `json:"created"`
+		Updated   int                      `json:"updated"`
+		Revived   int                      `json:"revived"`
+		Invalid   int                      `json:"invalid"`
+		Failed    int                      `json:"failed"`
+		Skipped   int                      `json:"skipped"`
+		Unchanged int                      `json:"unchanged"`
+		Rows      []ImportProductRowResult `json:"rows"`
+	}
+)
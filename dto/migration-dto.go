@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidMigrationFile  = errors.New("Migration files must be named NNNN_name.up.sql and NNNN_name.down.sql")
+	ErrMigrationNotFound     = errors.New("No migration with this version")
+	ErrMigrationFileMissing  = errors.New("Applied migration has no migration file")
+	ErrInvalidMigrationSteps = errors.New("Number of migrations must be a positive number")
+	ErrPendingMigrations     = errors.New("Database schema is not migrated, run migrate up")
+)
+
+// MigrationStatus is a migration known to the app or recorded as applied in
+// the database. Missing marks one that was applied but has no file, e.g. by a
+// newer version of the app.
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time
+	Missing   bool
+}
@@ -1,14 +1,33 @@
 package dto
 
+import "errors"
+
+var (
+	ErrInvalidCursor = errors.New("Cursor is invalid or was made for another sort order")
+)
+
 type (
+	// PaginationRequest pages by number when page is given and by cursor
+	// otherwise.
 	PaginationRequest struct {
-		Page uint16 `form:"page" binding:"required,gte=1"`
+		Page     int `form:"page" binding:"omitempty,gte=1"`
+		PageSize int `form:"page_size" binding:"omitempty,gte=1,lte=100"`
 	}
 
 	PaginationResponse struct {
-		Page      uint16 `json:"page"`
-		PrevPage  uint16 `json:"prev_page"`
-		NextPage  uint16 `json:"next_page"`
-		TotalPage uint16 `json:"total_page"`
+		Page       int   `json:"page"`
+		PrevPage   int   `json:"prev_page"`
+		NextPage   int   `json:"next_page"`
+		TotalPage  int   `json:"total_page"`
+		PageSize   int   `json:"page_size"`
+		TotalItems int64 `json:"total_items"`
+	}
+
+	// CursorResponse hands out the token for the page after this one. It is
+	// empty on the last page.
+	CursorResponse struct {
+		NextCursor string `json:"next_cursor"`
+		HasMore    bool   `json:"has_more"`
+		PageSize   int    `json:"page_size"`
 	}
 )
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNoPriceFilter          = errors.New("Select products by category, supplier, barcode or search")
+	ErrInvalidPriceAdjustment = errors.New("Price adjustment can't be zero and rounding step can't be negative")
+	ErrNegativeAdjustedPrice  = errors.New("Adjusted price can't be negative")
+	ErrToUpdateProductPrices  = errors.New("Failed to update product prices")
+
+	MESSAGE_SUCCESS_PREVIEW_PRICE_ADJUSTMENT = "Success Preview Price Adjustment"
+	MESSAGE_SUCCESS_ADJUST_PRICES            = "Success Adjust Prices"
+)
+
+type (
+	// ProductFilter selects products for a bulk change. Every filter that is
+	// set has to match.
+	ProductFilter struct {
+		Category   string   `json:"category"`
+		SupplierId *uint    `json:"supplier_id"`
+		BarcodeIds []string `json:"barcode_ids"`
+		Search     string   `json:"search"`
+	}
+
+	PriceAdjustmentRequest struct {
+		ProductFilter
+		Type     string          `json:"type" binding:"required,oneof=percentage fixed"`
+		Value    decimal.Decimal `json:"value" binding:"required"`
+		RoundTo  decimal.Decimal `json:"round_to"`
+		Rounding string          `json:"rounding" binding:"omitempty,oneof=nearest up down"`
+		Preview  bool            `json:"preview"`
+	}
+
+	PriceChange struct {
+		BarcodeId string          `json:"barcode_id"`
+		Title     string          `json:"title"`
+		OldPrice  decimal.Decimal `json:"old_price"`
+		NewPrice  decimal.Decimal `json:"new_price"`
+	}
+
+	PriceAdjustmentResult struct {
+		Preview  bool          `json:"preview"`
+		Total    int           `json:"total"`
+		Changed  int           `json:"changed"`
+		Products []PriceChange `json:"products"`
+	}
+)
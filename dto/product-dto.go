@@ -16,10 +16,18 @@ var (
 	ErrProductExist       = errors.New("Product with this barcode already Exist")
 	ErrProductDoesntExist = errors.New("Product with this barcode doesn't exist")
 	ErrNoChangesRequest   = errors.New("There is no updated field in the request")
+	ErrInvalidStockLevel  = errors.New("Minimum stock and reorder quantity can't be negative")
+	ErrVersionMismatch    = errors.New("Product has been changed since it was loaded, reload it and try again")
+	ErrIfMatchRequired    = errors.New("If-Match header with the product ETag is required")
+	ErrImageNotFound      = errors.New("Image not found")
+	ErrInvalidImage       = errors.New("File is not a valid jpeg or png image")
+	ErrImageTooLarge      = errors.New("Image dimensions are too large")
+	ErrISEImage           = errors.New("Failed to get image")
 
 	MESSAGE_SUCCESS_GET_ALL_PRODUCTS   = "Success Get All product"
 	MESSAGE_SUCCESS_GET_PRODUCT_DETAIL = "Success Get Product Detail"
 	MESSAGE_SUCCESS_SEARCH_PRODUCTS    = "Success Get All product"
+	MESSAGE_SUCCESS_AUTOCOMPLETE       = "Success Get Product Suggestions"
 	MESSAGE_SUCCESS_ADD_PRODUCT        = "Success Add Product"
 	MESSAGE_SUCCESS_UPDATE_PRODUCT     = "Success Update Product"
 	MESSAGE_SUCCESS_DELETE_PRODUCT     = "Success Delete Product"
@@ -27,11 +35,24 @@ var (
 
 type (
 	ProductWithoutTimeStamp struct {
-		BarcodeId   string          `json:"barcode_id" binding:"required"`
-		Image       string          `json:"image" binding:"required"`
-		Title       string          `json:"title" binding:"required"`
-		Price       decimal.Decimal `json:"price" binding:"required"`
-		Description string          `json:"description" binding:"required"`
+		BarcodeId       string          `json:"barcode_id" binding:"required"`
+		Image           string          `json:"image" binding:"required"`
+		Title           string          `json:"title" binding:"required"`
+		Price           decimal.Decimal `json:"price" binding:"required"`
+		Description     string          `json:"description" binding:"required"`
+		Category        string          `json:"category"`
+		MinStock        int64           `json:"min_stock"`
+		ReorderQuantity int64           `json:"reorder_quantity"`
+		SupplierId      *uint           `json:"supplier_id"`
+		Version         uint            `json:"version"`
+		Images          ProductImages   `json:"images" gorm:"-"`
+	}
+
+	// ProductImages holds the URL of every size generated for a product image.
+	ProductImages struct {
+		Original  string `json:"original"`
+		Medium    string `json:"medium"`
+		Thumbnail string `json:"thumbnail"`
 	}
 
 	AllProductsWithPagination struct {
@@ -40,11 +61,15 @@ type (
 	}
 
 	AddProductRequest struct {
-		BarcodeId   string                `form:"barcode_id" binding:"required"`
-		Image       *multipart.FileHeader `form:"image" binding:"required"`
-		Title       string                `form:"title" binding:"required"`
-		Price       decimal.Decimal       `form:"price" binding:"required"`
-		Description string                `form:"description" binding:"required"`
+		BarcodeId       string                `form:"barcode_id" binding:"required"`
+		Image           *multipart.FileHeader `form:"image" binding:"required"`
+		Title           string                `form:"title" binding:"required"`
+		Price           decimal.Decimal       `form:"price" binding:"required"`
+		Description     string                `form:"description" binding:"required"`
+		Category        string                `form:"category"`
+		MinStock        int64                 `form:"min_stock" binding:"gte=0"`
+		ReorderQuantity int64                 `form:"reorder_quantity" binding:"gte=0"`
+		SupplierId      *uint                 `form:"supplier_id"`
 	}
 
 	ProductBarcodeIdURI struct {
@@ -52,14 +77,54 @@ type (
 	}
 
 	UpdateProductRequest struct {
-		Image       *multipart.FileHeader `form:"image"`
-		Title       *string               `form:"title"`
-		Price       *decimal.Decimal      `form:"price"`
-		Description *string               `form:"description"`
+		Image           *multipart.FileHeader `form:"image"`
+		Title           *string               `form:"title"`
+		Price           *decimal.Decimal      `form:"price"`
+		Description     *string               `form:"description"`
+		Category        *string               `form:"category"`
+		MinStock        *int64                `form:"min_stock"`
+		ReorderQuantity *int64                `form:"reorder_quantity"`
+		SupplierId      *uint                 `form:"supplier_id"`
 	}
 
+	// SearchProductQuery matches q against title, description and barcode
+	// prefix. title is the older name for q and barcode_id only matches
+	// barcodes.
 	SearchProductQuery struct {
+		Query     string  `form:"q"`
 		Title     *string `form:"title"`
 		BarcodeId *string `form:"barcode_id"`
+		StoreId   uint    `form:"store_id"`
+		Page      int     `form:"page"`
+		PageSize  int     `form:"page_size"`
+	}
+
+	// ProductListQuery pages through the catalog. Price filters use the catalog
+	// price and stock sorts by the stock of store_id, or of every store when it
+	// isn't given. Without a page number the listing pages by cursor.
+	ProductListQuery struct {
+		PaginationRequest
+		Sort     string           `form:"sort" binding:"omitempty,oneof=title price created_at updated_at stock"`
+		Order    string           `form:"order" binding:"omitempty,oneof=asc desc"`
+		MinPrice *decimal.Decimal `form:"min_price"`
+		MaxPrice *decimal.Decimal `form:"max_price"`
+		Category string           `form:"category"`
+		HasImage *bool            `form:"has_image"`
+		StoreId  uint             `form:"store_id"`
+		Cursor   string           `form:"cursor"`
+	}
+
+	ProductsWithCursor struct {
+		Products []ProductWithoutTimeStamp `json:"products"`
+		Cursor   CursorResponse            `json:"cursor"`
+	}
+
+	AutocompleteQuery struct {
+		Query string `form:"q" binding:"required"`
+	}
+
+	ProductSuggestion struct {
+		BarcodeId string `json:"barcode_id"`
+		Title     string `json:"title"`
 	}
 )
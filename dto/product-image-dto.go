@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"errors"
+	"mime/multipart"
+)
+
+var (
+	ErrProductImageNotFound = errors.New("Product image not found")
+	ErrTooManyProductImages = errors.New("Product already has the maximum number of images")
+	ErrInvalidImageOrder    = errors.New("Image order should list every image of the product exactly once")
+	ErrISEProductImages     = errors.New("Failed to update product images")
+
+	MESSAGE_SUCCESS_GET_PRODUCT_IMAGES     = "Success Get Product Images"
+	MESSAGE_SUCCESS_ADD_PRODUCT_IMAGE      = "Success Add Product Image"
+	MESSAGE_SUCCESS_REORDER_PRODUCT_IMAGES = "Success Reorder Product Images"
+	MESSAGE_SUCCESS_SET_PRIMARY_IMAGE      = "Success Set Primary Product Image"
+	MESSAGE_SUCCESS_DELETE_PRODUCT_IMAGE   = "Success Delete Product Image"
+)
+
+type (
+	ProductImageURI struct {
+		BarcodeId string `uri:"barcode_id" binding:"required"`
+		ImageId   uint   `uri:"image_id" binding:"required"`
+	}
+
+	AddProductImageRequest struct {
+		Image *multipart.FileHeader `form:"image" binding:"required"`
+	}
+
+	ReorderProductImagesRequest struct {
+		ImageIds []uint `json:"image_ids" binding:"required,min=1"`
+	}
+
+	ProductImageResponse struct {
+		Id       uint          `json:"id"`
+		Image    string        `json:"image"`
+		Position int           `json:"position"`
+		Primary  bool          `json:"primary"`
+		Urls     ProductImages `json:"urls"`
+	}
+
+	// ImageGCReport sums up one run of the orphaned image collection. Orphans
+	// younger than the grace period are reported as Pending and kept.
+	ImageGCReport struct {
+		DryRun  bool     `json:"dry_run"`
+		Scanned int      `json:"scanned"`
+		Orphans []string `json:"orphans"`
+		Pending []string `json:"pending"`
+		Deleted int      `json:"deleted"`
+		Failed  int      `json:"failed"`
+	}
+)
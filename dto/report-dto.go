@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrISEReport          = errors.New("Failed to generate report")
+	ErrInvalidReportRange = errors.New("Report range should use YYYY-MM-DD dates with from not after to")
+
+	MESSAGE_SUCCESS_GET_SALES_SUMMARY        = "Success Get Sales Summary"
+	MESSAGE_SUCCESS_GET_HOURLY_SALES         = "Success Get Hourly Sales"
+	MESSAGE_SUCCESS_GET_CASHIER_SALES        = "Success Get Sales By Cashier"
+	MESSAGE_SUCCESS_GET_PAYMENT_METHOD_SALES = "Success Get Sales By Payment Method"
+	MESSAGE_SUCCESS_GET_CATEGORY_SALES       = "Success Get Sales By Category"
+	MESSAGE_SUCCESS_GET_TOP_PRODUCTS         = "Success Get Top Products"
+	MESSAGE_SUCCESS_GET_STOCK_VALUATION      = "Success Get Stock Valuation"
+
+	DEFAULT_TOP_PRODUCTS_LIMIT = uint16(10)
+)
+
+type (
+	ReportQuery struct {
+		From    string `form:"from" binding:"required"`
+		To      string `form:"to" binding:"required"`
+		StoreId uint   `form:"store_id"`
+	}
+
+	SalesSummaryQuery struct {
+		ReportQuery
+		Period string `form:"period" binding:"omitempty,oneof=day week month"`
+	}
+
+	TopProductsQuery struct {
+		ReportQuery
+		By    string `form:"by" binding:"omitempty,oneof=quantity revenue"`
+		Limit uint16 `form:"limit" binding:"omitempty,lte=100"`
+	}
+
+	SalesPeriodTotal struct {
+		Period        string          `json:"period"`
+		Transactions  int64           `json:"transactions"`
+		Revenue       decimal.Decimal `json:"revenue"`
+		AverageTicket decimal.Decimal `json:"average_ticket"`
+	}
+
+	HourlySales struct {
+		Hour         int             `json:"hour"`
+		Transactions int64           `json:"transactions"`
+		Revenue      decimal.Decimal `json:"revenue"`
+	}
+
+	CashierSales struct {
+		Cashier      string          `json:"cashier"`
+		Transactions int64           `json:"transactions"`
+		Revenue      decimal.Decimal `json:"revenue"`
+	}
+
+	PaymentMethodSales struct {
+		PaymentMethod string          `json:"payment_method"`
+		Transactions  int64           `json:"transactions"`
+		Revenue       decimal.Decimal `json:"revenue"`
+	}
+
+	CategorySales struct {
+		Category string          `json:"category"`
+		Quantity int64           `json:"quantity"`
+		Revenue  decimal.Decimal `json:"revenue"`
+	}
+
+	ProductSales struct {
+		BarcodeId string          `json:"barcode_id"`
+		Title     string          `json:"title"`
+		Quantity  int64           `json:"quantity"`
+		Revenue   decimal.Decimal `json:"revenue"`
+	}
+
+	StockValuation struct {
+		StoreId   uint            `json:"store_id"`
+		StoreName string          `json:"store_name"`
+		BarcodeId string          `json:"barcode_id"`
+		Title     string          `json:"title"`
+		Quantity  int64           `json:"quantity"`
+		UnitPrice decimal.Decimal `json:"unit_price"`
+		Value     decimal.Decimal `json:"value"`
+	}
+)
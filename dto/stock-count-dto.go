@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrStockCountNotFound    = errors.New("Stock count session not found")
+	ErrStockCountNotOpen     = errors.New("Stock count session is no longer open")
+	ErrStockCountAlreadyOpen = errors.New("Another stock count session is still open in this store")
+	ErrUncountedProducts     = errors.New("Some products have not been counted in this session")
+	ErrUnknownBarcode        = errors.New("Counted barcode doesn't belong to any product")
+	ErrISEStockCount         = errors.New("Failed to process stock count")
+
+	MESSAGE_SUCCESS_START_STOCK_COUNT      = "Success Start Stock Count"
+	MESSAGE_SUCCESS_SUBMIT_STOCK_COUNT     = "Success Submit Stock Count"
+	MESSAGE_SUCCESS_GET_STOCK_COUNT_REPORT = "Success Get Stock Count Report"
+	MESSAGE_SUCCESS_GET_UNCOUNTED_PRODUCTS = "Success Get Uncounted Products"
+	MESSAGE_SUCCESS_APPROVE_STOCK_COUNT    = "Success Approve Stock Count"
+)
+
+type (
+	StartStockCountRequest struct {
+		StoreId uint   `json:"store_id"`
+		Note    string `json:"note"`
+	}
+
+	StockCountSessionURI struct {
+		SessionId uint `uri:"session_id" binding:"required"`
+	}
+
+	StockCountItemRequest struct {
+		BarcodeId string `json:"barcode_id" binding:"required"`
+		Quantity  int64  `json:"quantity" binding:"gte=0"`
+	}
+
+	SubmitStockCountRequest struct {
+		DeviceId  string                  `json:"device_id" binding:"required"`
+		CountedBy string                  `json:"counted_by" binding:"required"`
+		Items     []StockCountItemRequest `json:"items" binding:"required,min=1,dive"`
+	}
+
+	ApproveStockCountRequest struct {
+		ApprovedBy       string `json:"approved_by" binding:"required"`
+		IncludeUncounted bool   `json:"include_uncounted"`
+	}
+
+	StockCountSessionResponse struct {
+		Id         uint       `json:"id"`
+		StoreId    uint       `json:"store_id"`
+		Status     string     `json:"status"`
+		StartedAt  time.Time  `json:"started_at"`
+		ApprovedBy string     `json:"approved_by"`
+		ApprovedAt *time.Time `json:"approved_at"`
+		Note       string     `json:"note"`
+	}
+
+	StockVariance struct {
+		BarcodeId       string `json:"barcode_id"`
+		Title           string `json:"title"`
+		SystemQuantity  int64  `json:"system_quantity"`
+		CountedQuantity int64  `json:"counted_quantity"`
+		Variance        int64  `json:"variance"`
+	}
+
+	UncountedProduct struct {
+		BarcodeId      string `json:"barcode_id"`
+		Title          string `json:"title"`
+		SystemQuantity int64  `json:"system_quantity"`
+	}
+
+	StockCountReport struct {
+		Session   StockCountSessionResponse `json:"session"`
+		Variances []StockVariance           `json:"variances"`
+		Uncounted []UncountedProduct        `json:"uncounted"`
+	}
+)
@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrISEStock          = errors.New("Failed to process stock")
+	ErrInsufficientStock = errors.New("Stock is not enough for this product")
+	ErrExpiredBatch      = errors.New("Remaining stock of this product is expired, override is required to sell it")
+	ErrInvalidExpiryDate = errors.New("Expiry date should use YYYY-MM-DD format")
+	ErrBatchNotFound     = errors.New("Stock batch not found")
+	ErrBatchEmpty        = errors.New("Stock batch has no remaining quantity")
+
+	MESSAGE_SUCCESS_GET_LOW_STOCK_PRODUCTS = "Success Get Low Stock Products"
+	MESSAGE_SUCCESS_GET_REORDER_SUGGESTION = "Success Get Reorder Suggestion"
+	MESSAGE_SUCCESS_RECEIVE_STOCK          = "Success Receive Stock"
+	MESSAGE_SUCCESS_GET_EXPIRING_BATCHES   = "Success Get Expiring Batches"
+	MESSAGE_SUCCESS_WRITE_OFF_BATCH        = "Success Write Off Batch"
+	MESSAGE_SUCCESS_GET_STOCK_LEVELS       = "Success Get Stock Levels"
+
+	DEFAULT_REORDER_SUGGESTION_WINDOW_DAYS = uint16(30)
+	DEFAULT_EXPIRING_WITHIN_DAYS           = uint16(7)
+)
+
+type (
+	LowStockProduct struct {
+		StoreId         uint   `json:"store_id"`
+		StoreName       string `json:"store_name"`
+		BarcodeId       string `json:"barcode_id"`
+		Title           string `json:"title"`
+		CurrentStock    int64  `json:"current_stock"`
+		MinStock        int64  `json:"min_stock"`
+		ReorderQuantity int64  `json:"reorder_quantity"`
+		SupplierId      *uint  `json:"supplier_id"`
+	}
+
+	ReorderCandidate struct {
+		LowStockProduct
+		SupplierName string
+		LeadTimeDays uint
+		SoldQuantity int64
+	}
+
+	ReorderSuggestionQuery struct {
+		WindowDays uint16 `form:"window_days" binding:"omitempty,gte=1,lte=365"`
+		StoreId    uint   `form:"store_id"`
+	}
+
+	ReorderLine struct {
+		BarcodeId         string          `json:"barcode_id"`
+		Title             string          `json:"title"`
+		CurrentStock      int64           `json:"current_stock"`
+		MinStock          int64           `json:"min_stock"`
+		AverageDailySales decimal.Decimal `json:"average_daily_sales"`
+		SuggestedQuantity int64           `json:"suggested_quantity"`
+	}
+
+	PurchaseOrderDraft struct {
+		SupplierId   *uint         `json:"supplier_id"`
+		SupplierName string        `json:"supplier_name"`
+		LeadTimeDays uint          `json:"lead_time_days"`
+		Lines        []ReorderLine `json:"lines"`
+	}
+
+	ReorderSuggestion struct {
+		StoreId        uint                 `json:"store_id"`
+		WindowDays     uint16               `json:"window_days"`
+		GeneratedAt    time.Time            `json:"generated_at"`
+		PurchaseOrders []PurchaseOrderDraft `json:"purchase_orders"`
+	}
+
+	LowStockAlert struct {
+		StoreId      uint      `json:"store_id"`
+		StoreName    string    `json:"store_name"`
+		BarcodeId    string    `json:"barcode_id"`
+		Title        string    `json:"title"`
+		CurrentStock int64     `json:"current_stock"`
+		MinStock     int64     `json:"min_stock"`
+		DetectedAt   time.Time `json:"detected_at"`
+	}
+
+	ReceiveStockRequest struct {
+		BarcodeId   string `json:"barcode_id" binding:"required"`
+		BatchNumber string `json:"batch_number"`
+		ExpiryDate  string `json:"expiry_date"`
+		Quantity    int64  `json:"quantity" binding:"required,gt=0"`
+		Reference   string `json:"reference"`
+		StoreId     uint   `json:"store_id"`
+	}
+
+	StockBatch struct {
+		Id                uint       `json:"id"`
+		BarcodeId         string     `json:"barcode_id"`
+		BatchNumber       string     `json:"batch_number"`
+		ExpiryDate        *time.Time `json:"expiry_date"`
+		ReceivedQuantity  int64      `json:"received_quantity"`
+		RemainingQuantity int64      `json:"remaining_quantity"`
+		StoreId           uint       `json:"store_id"`
+	}
+
+	ExpiringBatchQuery struct {
+		Days    uint16 `form:"days" binding:"omitempty,lte=365"`
+		StoreId uint   `form:"store_id"`
+	}
+
+	ExpiringBatch struct {
+		BatchId           uint      `json:"batch_id"`
+		StoreId           uint      `json:"store_id"`
+		BarcodeId         string    `json:"barcode_id"`
+		Title             string    `json:"title"`
+		BatchNumber       string    `json:"batch_number"`
+		ExpiryDate        time.Time `json:"expiry_date"`
+		RemainingQuantity int64     `json:"remaining_quantity"`
+		Expired           bool      `json:"expired"`
+	}
+
+	StockBatchURI struct {
+		BatchId uint `uri:"batch_id" binding:"required"`
+	}
+
+	WriteOffBatchRequest struct {
+		Note string `json:"note"`
+	}
+
+	StockLevelURI struct {
+		BarcodeId string `uri:"barcode_id" binding:"required"`
+	}
+
+	StoreStockLevel struct {
+		StoreId      uint   `json:"store_id"`
+		StoreName    string `json:"store_name"`
+		CurrentStock int64  `json:"current_stock"`
+		InTransit    int64  `json:"in_transit"`
+	}
+)
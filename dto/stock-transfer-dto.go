@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTransferNotFound     = errors.New("Stock transfer not found")
+	ErrTransferNotInTransit = errors.New("Stock transfer is no longer in transit")
+	ErrSameStoreTransfer    = errors.New("Stock can't be transferred to the same store")
+	ErrISEStockTransfer     = errors.New("Failed to process stock transfer")
+
+	MESSAGE_SUCCESS_CREATE_STOCK_TRANSFER     = "Success Create Stock Transfer"
+	MESSAGE_SUCCESS_GET_STOCK_TRANSFERS       = "Success Get Stock Transfers"
+	MESSAGE_SUCCESS_GET_STOCK_TRANSFER_DETAIL = "Success Get Stock Transfer Detail"
+	MESSAGE_SUCCESS_RECEIVE_STOCK_TRANSFER    = "Success Receive Stock Transfer"
+)
+
+type (
+	StockTransferItemRequest struct {
+		BarcodeId string `json:"barcode_id" binding:"required"`
+		Quantity  int64  `json:"quantity" binding:"required,gt=0"`
+	}
+
+	CreateStockTransferRequest struct {
+		FromStoreId uint                       `json:"from_store_id" binding:"required"`
+		ToStoreId   uint                       `json:"to_store_id" binding:"required"`
+		CreatedBy   string                     `json:"created_by" binding:"required"`
+		Note        string                     `json:"note"`
+		Items       []StockTransferItemRequest `json:"items" binding:"required,min=1,dive"`
+	}
+
+	StockTransferURI struct {
+		TransferId uint `uri:"transfer_id" binding:"required"`
+	}
+
+	StockTransferQuery struct {
+		Status  string `form:"status" binding:"omitempty,oneof=in_transit received"`
+		StoreId uint   `form:"store_id"`
+	}
+
+	ReceiveStockTransferRequest struct {
+		ReceivedBy string `json:"received_by" binding:"required"`
+	}
+
+	StockTransferItem struct {
+		BarcodeId     string `json:"barcode_id"`
+		Quantity      int64  `json:"quantity"`
+		SourceBatchId *uint  `json:"source_batch_id"`
+	}
+
+	StockTransferResponse struct {
+		Id          uint                `json:"id"`
+		FromStoreId uint                `json:"from_store_id"`
+		ToStoreId   uint                `json:"to_store_id"`
+		Status      string              `json:"status"`
+		CreatedBy   string              `json:"created_by"`
+		ReceivedBy  string              `json:"received_by"`
+		ReceivedAt  *time.Time          `json:"received_at"`
+		Note        string              `json:"note"`
+		CreatedAt   time.Time           `json:"created_at"`
+		Items       []StockTransferItem `json:"items"`
+	}
+)
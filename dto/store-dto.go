@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrStoresNotFound     = errors.New("Stores Not Found")
+	ErrStoreDoesntExist   = errors.New("Store doesn't exist")
+	ErrStoreExist         = errors.New("Store with this code already Exist")
+	ErrInvalidStoreType   = errors.New("Store type should be store or warehouse")
+	ErrInvalidPrice       = errors.New("Price can't be negative")
+	ErrStorePriceNotFound = errors.New("This product has no price override in the store")
+	ErrISEStore           = errors.New("Failed to process store")
+
+	MESSAGE_SUCCESS_GET_ALL_STORES     = "Success Get All Store"
+	MESSAGE_SUCCESS_ADD_STORE          = "Success Add Store"
+	MESSAGE_SUCCESS_UPDATE_STORE       = "Success Update Store"
+	MESSAGE_SUCCESS_GET_STORE_PRICES   = "Success Get Store Prices"
+	MESSAGE_SUCCESS_SET_STORE_PRICE    = "Success Set Store Price"
+	MESSAGE_SUCCESS_DELETE_STORE_PRICE = "Success Delete Store Price"
+)
+
+type (
+	Store struct {
+		Id      uint   `json:"id"`
+		Code    string `json:"code"`
+		Name    string `json:"name"`
+		Type    string `json:"type"`
+		Address string `json:"address"`
+	}
+
+	AddStoreRequest struct {
+		Code    string `json:"code" binding:"required"`
+		Name    string `json:"name" binding:"required"`
+		Type    string `json:"type"`
+		Address string `json:"address"`
+	}
+
+	StoreIdURI struct {
+		StoreId uint `uri:"store_id" binding:"required"`
+	}
+
+	StorePriceURI struct {
+		StoreId   uint   `uri:"store_id" binding:"required"`
+		BarcodeId string `uri:"barcode_id" binding:"required"`
+	}
+
+	StoreQuery struct {
+		StoreId uint `form:"store_id"`
+	}
+
+	UpdateStoreRequest struct {
+		Name    *string `json:"name"`
+		Type    *string `json:"type"`
+		Address *string `json:"address"`
+	}
+
+	SetStorePriceRequest struct {
+		Price decimal.Decimal `json:"price" binding:"required"`
+	}
+
+	StorePrice struct {
+		StoreId   uint            `json:"store_id"`
+		BarcodeId string          `json:"barcode_id"`
+		Title     string          `json:"title"`
+		BasePrice decimal.Decimal `json:"base_price"`
+		Price     decimal.Decimal `json:"price"`
+	}
+)
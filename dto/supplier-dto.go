@@ -0,0 +1,38 @@
+package dto
+
+import "errors"
+
+var (
+	ErrSuppliersNotFound   = errors.New("Suppliers Not Found")
+	ErrSupplierDoesntExist = errors.New("Supplier doesn't exist")
+	ErrISESupplier         = errors.New("Failed to process supplier")
+
+	MESSAGE_SUCCESS_GET_ALL_SUPPLIERS = "Success Get All Supplier"
+	MESSAGE_SUCCESS_ADD_SUPPLIER      = "Success Add Supplier"
+	MESSAGE_SUCCESS_UPDATE_SUPPLIER   = "Success Update Supplier"
+)
+
+type (
+	Supplier struct {
+		Id           uint   `json:"id"`
+		Name         string `json:"name"`
+		Phone        string `json:"phone"`
+		LeadTimeDays uint   `json:"lead_time_days"`
+	}
+
+	AddSupplierRequest struct {
+		Name         string `json:"name" binding:"required"`
+		Phone        string `json:"phone"`
+		LeadTimeDays uint   `json:"lead_time_days"`
+	}
+
+	SupplierIdURI struct {
+		SupplierId uint `uri:"supplier_id" binding:"required"`
+	}
+
+	UpdateSupplierRequest struct {
+		Name         *string `json:"name"`
+		Phone        *string `json:"phone"`
+		LeadTimeDays *uint   `json:"lead_time_days"`
+	}
+)
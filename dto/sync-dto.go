@@ -0,0 +1,26 @@
+package dto
+
+import "errors"
+
+var (
+	ErrInvalidSyncToken = errors.New("Sync token is invalid, sync again without since")
+	ErrISESync          = errors.New("Failed to get changes")
+
+	MESSAGE_SUCCESS_SYNC_PRODUCTS = "Success Sync Products"
+)
+
+type (
+	SyncQuery struct {
+		Since string `form:"since"`
+	}
+
+	// ProductSyncResponse holds the products changed since the token a client
+	// sent. Deleted lists barcodes to drop from the local cache. When HasMore
+	// is set the client should call again right away with NextToken.
+	ProductSyncResponse struct {
+		Products  []ProductWithoutTimeStamp `json:"products"`
+		Deleted   []string                  `json:"deleted"`
+		NextToken string                    `json:"next_token"`
+		HasMore   bool                      `json:"has_more"`
+	}
+)
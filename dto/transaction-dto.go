@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrToCreateTransaction      = errors.New("Failed to create transaction")
+	ErrTransactionNotFound      = errors.New("Transaction not found")
+	ErrOverrideApproverRequired = errors.New("Selling expired stock requires the name of the approver")
+	ErrTransactionUploaded      = errors.New("Transaction has already been uploaded")
+	ErrSaleConflictNotFound     = errors.New("Sale conflict not found")
+	ErrSaleConflictResolved     = errors.New("Sale conflict has already been resolved")
+	ErrISESaleConflicts         = errors.New("Failed to process sale conflicts")
+
+	MESSAGE_SUCCESS_CREATE_TRANSACTION     = "Success Create Transaction"
+	MESSAGE_SUCCESS_GET_TRANSACTION_DETAIL = "Success Get Transaction Detail"
+	MESSAGE_SUCCESS_UPLOAD_OFFLINE_SALES   = "Success Upload Offline Sales"
+	MESSAGE_SUCCESS_GET_SALE_CONFLICTS     = "Success Get Sale Conflicts"
+	MESSAGE_SUCCESS_RESOLVE_SALE_CONFLICT  = "Success Resolve Sale Conflict"
+)
+
+type (
+	TransactionItemRequest struct {
+		BarcodeId string `json:"barcode_id" binding:"required"`
+		Quantity  int64  `json:"quantity" binding:"required,gt=0"`
+	}
+
+	CreateTransactionRequest struct {
+		Cashier       string                   `json:"cashier" binding:"required"`
+		PaymentMethod string                   `json:"payment_method" binding:"required"`
+		Items         []TransactionItemRequest `json:"items" binding:"required,min=1,dive"`
+		StoreId       uint                     `json:"store_id"`
+		AllowExpired  bool                     `json:"allow_expired"`
+		OverrideBy    string                   `json:"override_by"`
+	}
+
+	OfflineSaleItemRequest struct {
+		BarcodeId string           `json:"barcode_id" binding:"required"`
+		Title     string           `json:"title"`
+		Quantity  int64            `json:"quantity" binding:"required,gt=0"`
+		UnitPrice *decimal.Decimal `json:"unit_price" binding:"required"`
+	}
+
+	OfflineSaleRequest struct {
+		ClientId      string                   `json:"client_id" binding:"required,uuid"`
+		Cashier       string                   `json:"cashier" binding:"required"`
+		PaymentMethod string                   `json:"payment_method" binding:"required"`
+		SoldAt        *time.Time               `json:"sold_at" binding:"required"`
+		Items         []OfflineSaleItemRequest `json:"items" binding:"required,min=1,dive"`
+	}
+
+	UploadOfflineSalesRequest struct {
+		StoreId uint                 `json:"store_id"`
+		Sales   []OfflineSaleRequest `json:"sales" binding:"required,min=1,max=100,dive"`
+	}
+
+	SaleConflictResponse struct {
+		Id            uint       `json:"id"`
+		TransactionId uint       `json:"transaction_id"`
+		BarcodeId     string     `json:"barcode_id"`
+		Type          string     `json:"type"`
+		Quantity      int64      `json:"quantity"`
+		Detail        string     `json:"detail"`
+		CreatedAt     time.Time  `json:"created_at"`
+		ResolvedBy    string     `json:"resolved_by,omitempty"`
+		ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+	}
+
+	OfflineSaleResult struct {
+		ClientId      string                 `json:"client_id"`
+		Status        string                 `json:"status"`
+		TransactionId uint                   `json:"transaction_id,omitempty"`
+		Conflicts     []SaleConflictResponse `json:"conflicts,omitempty"`
+		Error         string                 `json:"error,omitempty"`
+	}
+
+	SaleConflictQuery struct {
+		StoreId  uint `form:"store_id"`
+		Resolved bool `form:"resolved"`
+	}
+
+	SaleConflictIdURI struct {
+		ConflictId uint `uri:"conflict_id" binding:"required"`
+	}
+
+	ResolveSaleConflictRequest struct {
+		ResolvedBy string `json:"resolved_by" binding:"required"`
+	}
+
+	ProductPriceAt struct {
+		BarcodeId string
+		Title     string
+		Price     decimal.Decimal
+		Deleted   bool
+	}
+
+	TransactionIdURI struct {
+		TransactionId uint `uri:"transaction_id" binding:"required"`
+	}
+
+	TransactionItemResponse struct {
+		BarcodeId string          `json:"barcode_id"`
+		Title     string          `json:"title"`
+		Quantity  int64           `json:"quantity"`
+		UnitPrice decimal.Decimal `json:"unit_price"`
+		Subtotal  decimal.Decimal `json:"subtotal"`
+	}
+
+	TransactionResponse struct {
+		Id                uint                      `json:"id"`
+		StoreId           uint                      `json:"store_id"`
+		Cashier           string                    `json:"cashier"`
+		PaymentMethod     string                    `json:"payment_method"`
+		Total             decimal.Decimal           `json:"total"`
+		ExpiredOverrideBy string                    `json:"expired_override_by,omitempty"`
+		CreatedAt         time.Time                 `json:"created_at"`
+		Items             []TransactionItemResponse `json:"items"`
+	}
+)
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"net/http"
+	"time"
+)
+
+type IdempotencyKey struct {
+	Key         string `gorm:"primaryKey"`
+	RequestHash string
+	StatusCode  int
+	Headers     http.Header `gorm:"serializer:json"`
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"index"`
+}
@@ -1,15 +1,41 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
 type Product struct {
 	gorm.Model
-	BarcodeId   string `gorm:"uniqueIndex"`
-	Image       string
-	Title       string
-	Price       decimal.Decimal
-	Description string
+	BarcodeId       string `gorm:"uniqueIndex"`
+	Image           string
+	Title           string
+	Price           decimal.Decimal `gorm:"type:numeric(14,2)"`
+	Description     string
+	Category        string `gorm:"index"`
+	MinStock        int64
+	ReorderQuantity int64
+	SupplierId      *uint `gorm:"index"`
+	Version         uint  `gorm:"not null;default:1"`
+}
+
+type PriceHistory struct {
+	gorm.Model
+	BarcodeId string          `gorm:"index"`
+	Price     decimal.Decimal `gorm:"type:numeric(14,2)"`
+}
+
+// ProductImage is one image of a product. Product.Image mirrors the primary
+// one, so everything that only knows a single image keeps working. Rows are
+// deleted for good together with their files.
+type ProductImage struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	BarcodeId string `gorm:"index;not null"`
+	Image     string `gorm:"not null"`
+	Position  int    `gorm:"not null;default:0"`
+	IsPrimary bool   `gorm:"not null;default:false"`
 }
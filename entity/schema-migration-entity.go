@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type SchemaMigration struct {
+	Version   uint `gorm:"primaryKey"`
+	Name      string
+	AppliedAt time.Time
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockCountSession struct {
+	gorm.Model
+	Status     string `gorm:"index"`
+	StartedAt  time.Time
+	ApprovedBy string
+	ApprovedAt *time.Time
+	Note       string
+	StoreId    uint `gorm:"index"`
+}
+
+type StockCountItem struct {
+	gorm.Model
+	SessionId       uint   `gorm:"uniqueIndex:idx_stock_count_item"`
+	BarcodeId       string `gorm:"uniqueIndex:idx_stock_count_item"`
+	DeviceId        string `gorm:"uniqueIndex:idx_stock_count_item"`
+	CountedBy       string
+	CountedQuantity int64
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockMovement struct {
+	gorm.Model
+	BarcodeId string `gorm:"index"`
+	Quantity  int64
+	Type      string `gorm:"index"`
+	Reference string
+	Note      string
+	BatchId   *uint `gorm:"index"`
+	StoreId   uint  `gorm:"index"`
+}
+
+type StockBatch struct {
+	gorm.Model
+	BarcodeId         string `gorm:"index"`
+	BatchNumber       string
+	ExpiryDate        *time.Time `gorm:"type:date;index"`
+	ReceivedQuantity  int64
+	RemainingQuantity int64
+	StoreId           uint `gorm:"index"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockTransfer struct {
+	gorm.Model
+	FromStoreId uint   `gorm:"index"`
+	ToStoreId   uint   `gorm:"index"`
+	Status      string `gorm:"index"`
+	CreatedBy   string
+	ReceivedBy  string
+	ReceivedAt  *time.Time
+	Note        string
+	Items       []StockTransferItem `gorm:"foreignKey:TransferId"`
+}
+
+type StockTransferItem struct {
+	gorm.Model
+	TransferId    uint   `gorm:"index"`
+	BarcodeId     string `gorm:"index"`
+	Quantity      int64
+	SourceBatchId *uint
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type Store struct {
+	gorm.Model
+	Code    string `gorm:"uniqueIndex"`
+	Name    string
+	Type    string
+	Address string
+}
+
+type StorePrice struct {
+	gorm.Model
+	StoreId   uint            `gorm:"uniqueIndex:idx_store_price"`
+	BarcodeId string          `gorm:"uniqueIndex:idx_store_price"`
+	Price     decimal.Decimal `gorm:"type:numeric(14,2)"`
+}
+
+// StorePriceHistory records every change of a store's price override, so a
+// sale can be checked against the price the store had when it was made. A
+// null price marks the override being removed.
+type StorePriceHistory struct {
+	gorm.Model
+	StoreId   uint                `gorm:"index:idx_store_price_history"`
+	BarcodeId string              `gorm:"index:idx_store_price_history"`
+	Price     decimal.NullDecimal `gorm:"type:numeric(14,2)"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Supplier struct {
+	gorm.Model
+	Name         string
+	Phone        string
+	LeadTimeDays uint
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type Transaction struct {
+	gorm.Model
+	ClientId          *string         `gorm:"uniqueIndex"`
+	Cashier           string          `gorm:"index"`
+	PaymentMethod     string          `gorm:"index"`
+	Total             decimal.Decimal `gorm:"type:numeric(14,2)"`
+	ExpiredOverrideBy string
+	StoreId           uint              `gorm:"index"`
+	Items             []TransactionItem `gorm:"foreignKey:TransactionId"`
+}
+
+type TransactionItem struct {
+	gorm.Model
+	TransactionId uint   `gorm:"index"`
+	BarcodeId     string `gorm:"index"`
+	Title         string
+	Quantity      int64
+	UnitPrice     decimal.Decimal `gorm:"type:numeric(14,2)"`
+	Subtotal      decimal.Decimal `gorm:"type:numeric(14,2)"`
+}
+
+type SaleConflict struct {
+	gorm.Model
+	TransactionId uint   `gorm:"index"`
+	BarcodeId     string `gorm:"index"`
+	Type          string `gorm:"index"`
+	Quantity      int64
+	Detail        string
+	ResolvedBy    string
+	ResolvedAt    *time.Time `gorm:"index"`
+}
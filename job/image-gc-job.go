@@ -0,0 +1,116 @@
+package job
+
+import (
+	"context"
+	"log"
+	"strings"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	"tiga-putra-cashier-be/utils"
+	"time"
+)
+
+type ImageGCJob struct {
+	productImageRepository repository.ProductImageRepository
+	storage                utils.Storage
+	imageDir               string
+	interval               time.Duration
+	gracePeriod            time.Duration
+	dryRun                 bool
+}
+
+func NewImageGCJob(productImageRepository repository.ProductImageRepository, storage utils.Storage, cfg *config.Config) *ImageGCJob {
+	return &ImageGCJob{
+		productImageRepository: productImageRepository,
+		storage:                storage,
+		imageDir:               cfg.Storage.ImageDir,
+		interval:               cfg.Jobs.ImageGC.Interval,
+		gracePeriod:            cfg.Jobs.ImageGC.GracePeriod,
+		dryRun:                 cfg.Jobs.ImageGC.DryRun,
+	}
+}
+
+// Interval is how often the scheduled run collects. It is disabled when this
+// is zero.
+func (j *ImageGCJob) Interval() time.Duration {
+	return j.interval
+}
+
+// GracePeriod is how long an unreferenced file is kept.
+func (j *ImageGCJob) GracePeriod() time.Duration {
+	return j.gracePeriod
+}
+
+// Run collects on every tick. In dry run mode it only reports the orphans.
+func (j *ImageGCJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		report, err := j.Collect(ctx, j.gracePeriod, j.dryRun)
+		if err != nil {
+			log.Printf("image gc failed: %v", err)
+		} else {
+			LogImageGCReport(report)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect deletes the files in the image store no product refers to once they
+// are older than gracePeriod. Files are listed before the references are read,
+// so an upload committed in between is still referenced or inside the grace
+// period.
+func (j *ImageGCJob) Collect(ctx context.Context, gracePeriod time.Duration, dryRun bool) (dto.ImageGCReport, error) {
+	report := dto.ImageGCReport{DryRun: dryRun, Orphans: []string{}, Pending: []string{}}
+	prefix := j.imageDir + "/"
+	files, err := j.storage.List(ctx, prefix)
+	if err != nil {
+		return report, err
+	}
+	images, err := j.productImageRepository.RetrieveReferencedImagesRepository(ctx)
+	if err != nil {
+		return report, err
+	}
+	referenced := make(map[string]bool)
+	for _, image := range images {
+		for _, size := range utils.ImageSizes {
+			referenced[utils.ImageVariantName(image, size)] = true
+		}
+	}
+
+	now := time.Now()
+	report.Scanned = len(files)
+	for _, file := range files {
+		if referenced[strings.TrimPrefix(file.Key, prefix)] {
+			continue
+		}
+		if now.Sub(file.ModTime) < gracePeriod {
+			report.Pending = append(report.Pending, file.Key)
+			continue
+		}
+		report.Orphans = append(report.Orphans, file.Key)
+		if dryRun || ctx.Err() != nil {
+			continue
+		}
+		if err := j.storage.Delete(ctx, file.Key); err != nil {
+			log.Printf("image gc failed to delete %s: %v", file.Key, err)
+			report.Failed++
+			continue
+		}
+		report.Deleted++
+	}
+	return report, nil
+}
+
+func LogImageGCReport(report dto.ImageGCReport) {
+	for _, key := range report.Orphans {
+		log.Printf("image gc orphan: %s", key)
+	}
+	log.Printf("image gc scanned %d files: %d orphaned, %d within grace period, %d deleted, %d failed (dry run: %t)",
+		report.Scanned, len(report.Orphans), len(report.Pending), report.Deleted, report.Failed, report.DryRun)
+}
@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	"time"
+)
+
+type LowStockJob struct {
+	stockRepository repository.StockRepository
+	notifier        Notifier
+	interval        time.Duration
+	alerted         map[string]bool
+}
+
+func NewLowStockJob(stockRepository repository.StockRepository, notifier Notifier, cfg *config.Config) *LowStockJob {
+	return &LowStockJob{
+		stockRepository: stockRepository,
+		notifier:        notifier,
+		interval:        cfg.Jobs.LowStock.CheckInterval,
+		alerted:         make(map[string]bool),
+	}
+}
+
+// Interval is how often the job runs. It is disabled when this is zero.
+func (j *LowStockJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *LowStockJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := j.Check(ctx); err != nil {
+			log.Printf("low stock check failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check alerts only for products that crossed the threshold in a store since the
+// previous check. Products that recovered are forgotten so they alert again next
+// time.
+func (j *LowStockJob) Check(ctx context.Context) error {
+	products, err := j.stockRepository.RetrieveLowStockProductsRepository(ctx, 0)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	stillLow := make(map[string]bool)
+	var alerts []dto.LowStockAlert
+	for _, product := range products {
+		key := fmt.Sprintf("%d/%s", product.StoreId, product.BarcodeId)
+		stillLow[key] = true
+		if j.alerted[key] {
+			continue
+		}
+		alerts = append(alerts, dto.LowStockAlert{
+			StoreId:      product.StoreId,
+			StoreName:    product.StoreName,
+			BarcodeId:    product.BarcodeId,
+			Title:        product.Title,
+			CurrentStock: product.CurrentStock,
+			MinStock:     product.MinStock,
+			DetectedAt:   now,
+		})
+	}
+	if len(alerts) > 0 {
+		if err := j.notifier.Notify(ctx, alerts); err != nil {
+			return err
+		}
+	}
+	j.alerted = stillLow
+	return nil
+}
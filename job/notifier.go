@@ -0,0 +1,72 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"time"
+)
+
+type (
+	Notifier interface {
+		Notify(ctx context.Context, alerts []dto.LowStockAlert) error
+	}
+	logNotifier     struct{}
+	webhookNotifier struct {
+		url    string
+		client *http.Client
+	}
+)
+
+// NewNotifier posts alerts to the low stock webhook when one is configured and
+// falls back to the application log otherwise.
+func NewNotifier(cfg *config.Config) Notifier {
+	if url := cfg.Jobs.LowStock.WebhookURL; url != "" {
+		return NewWebhookNotifier(url)
+	}
+	return NewLogNotifier()
+}
+
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func NewWebhookNotifier(url string) Notifier {
+	return &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (l *logNotifier) Notify(ctx context.Context, alerts []dto.LowStockAlert) error {
+	for _, alert := range alerts {
+		log.Printf("Low stock at %s: %s (%s) has %d left, minimum is %d", alert.StoreName, alert.Title, alert.BarcodeId, alert.CurrentStock, alert.MinStock)
+	}
+	return nil
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, alerts []dto.LowStockAlert) error {
+	payload, err := json.Marshal(map[string]interface{}{"alerts": alerts})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("low stock webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
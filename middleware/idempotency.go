@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var idempotentMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// replayedHeaders are the response headers a retry gets back with the body.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+type IdempotencyMiddleware struct {
+	idempotencyRepository repository.IdempotencyRepository
+	ttl                   time.Duration
+	maxBodySize           int64
+}
+
+// NewIdempotencyMiddleware keeps responses for the configured key TTL.
+func NewIdempotencyMiddleware(idempotencyRepository repository.IdempotencyRepository, cfg *config.Config) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{idempotencyRepository, cfg.Idempotency.KeyTTL, cfg.Idempotency.MaxBodySize}
+}
+
+// Handle runs a mutating request carrying an Idempotency-Key once and replays
+// its response to retries with the same key. Server errors and requests the
+// client cancelled free the key again so the retry gets another go.
+func (m *IdempotencyMiddleware) Handle(ctx *gin.Context) {
+	key := ctx.GetHeader(constant.IdempotencyKeyHeader)
+	if key == "" || !idempotentMethods[ctx.Request.Method] {
+		ctx.Next()
+		return
+	}
+	if len(key) > constant.MaxIdempotencyKeyLength {
+		res := utils.ReturnResponseError(400, dto.ErrIdempotencyKeyTooLong.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, m.maxBodySize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		res := utils.ReturnResponseError(413, dto.ErrIdempotentBodyTooLarge.Error())
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, res)
+		return
+	}
+	if err != nil {
+		res := utils.ReturnResponseError(400, dto.ErrBadrequest.Error())
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := requestHash(ctx.Request, body)
+	record, reserved, err := m.idempotencyRepository.ReserveIdempotencyKeyRepository(ctx.Request.Context(), key, hash, m.ttl)
+	if err != nil {
+		res := utils.ReturnResponseError(500, err.Error())
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+	if !reserved {
+		if record.RequestHash != hash {
+			res := utils.ReturnResponseError(422, dto.ErrIdempotencyKeyReused.Error())
+			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, res)
+			return
+		}
+		if record.StatusCode == 0 {
+			res := utils.ReturnResponseError(409, dto.ErrIdempotencyKeyInProgress.Error())
+			ctx.AbortWithStatusJSON(http.StatusConflict, res)
+			return
+		}
+		for name, values := range record.Headers {
+			for _, value := range values {
+				ctx.Writer.Header().Add(name, value)
+			}
+		}
+		ctx.Header(constant.IdempotentReplayedHeader, "true")
+		ctx.Data(record.StatusCode, record.Headers.Get("Content-Type"), record.Body)
+		ctx.Abort()
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+	ctx.Writer = recorder
+	ctx.Next()
+
+	// The request context is done once the client went away, which must not
+	// stop the key from being released.
+	saveCtx := context.WithoutCancel(ctx.Request.Context())
+	status := recorder.Status()
+	if status >= http.StatusInternalServerError || status == constant.StatusClientClosedRequest {
+		err = m.idempotencyRepository.ReleaseIdempotencyKeyRepository(saveCtx, key)
+	} else {
+		headers := http.Header{}
+		for _, name := range replayedHeaders {
+			for _, value := range recorder.Header().Values(name) {
+				headers.Add(name, value)
+			}
+		}
+		err = m.idempotencyRepository.SaveIdempotentResponseRepository(saveCtx, key, status, headers, recorder.body.Bytes())
+	}
+	if err != nil {
+		log.Printf("idempotency key %q: %v", key, err)
+	}
+}
+
+// requestHash fingerprints what a retry has to repeat: method, path, query and
+// body. Multipart bodies are hashed by their parts, as a client rebuilding the
+// form for a retry picks a new boundary.
+func requestHash(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, request.Method+" "+request.URL.RequestURI()+"\n")
+	mediaType, params, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		if parts, err := multipartParts(body, params["boundary"]); err == nil {
+			for _, part := range parts {
+				hash.Write(part)
+			}
+			return hex.EncodeToString(hash.Sum(nil))
+		}
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func multipartParts(body []byte, boundary string) ([][]byte, error) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	var parts [][]byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		digest := sha256.Sum256(content)
+		parts = append(parts, []byte(part.FormName()+"\x00"+part.FileName()+"\x00"+hex.EncodeToString(digest[:])+"\n"))
+	}
+	sort.Slice(parts, func(i, j int) bool { return bytes.Compare(parts[i], parts[j]) < 0 })
+	return parts, nil
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+	return r.ResponseWriter.WriteString(data)
+}
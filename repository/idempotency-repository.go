@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IdempotencyRepository interface {
+		ReserveIdempotencyKeyRepository(ctx context.Context, key, requestHash string, ttl time.Duration) (entity.IdempotencyKey, bool, error)
+		SaveIdempotentResponseRepository(ctx context.Context, key string, statusCode int, headers http.Header, body []byte) error
+		ReleaseIdempotencyKeyRepository(ctx context.Context, key string) error
+	}
+	idempotencyRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewIdempotencyRepository(db *gorm.DB, cfg *config.Config) IdempotencyRepository {
+	return &idempotencyRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+// ReserveIdempotencyKeyRepository claims a key for a request. It returns true
+// when the key is new, or expired, or left behind by a request that never
+// finished; otherwise it returns the record of the request that holds it.
+// Expired keys are purged on the way.
+func (i *idempotencyRepository) ReserveIdempotencyKeyRepository(ctx context.Context, key, requestHash string, ttl time.Duration) (entity.IdempotencyKey, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.timeouts.Query)
+	defer cancel()
+
+	now := time.Now()
+	record := entity.IdempotencyKey{Key: key, RequestHash: requestHash, CreatedAt: now, ExpiresAt: now.Add(ttl)}
+	reserved := false
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("expires_at < ? OR (key = ? AND status_code = 0 AND created_at < ?)", now, key, now.Add(-constant.IdempotencyLockTimeout)).
+			Delete(&entity.IdempotencyKey{}).Error
+		if err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			reserved = true
+			return nil
+		}
+		return tx.Where("key = ?", key).First(&record).Error
+	})
+	if err != nil {
+		return entity.IdempotencyKey{}, false, dto.ErrISEIdempotency
+	}
+	return record, reserved, nil
+}
+
+func (i *idempotencyRepository) SaveIdempotentResponseRepository(ctx context.Context, key string, statusCode int, headers http.Header, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, i.timeouts.Query)
+	defer cancel()
+
+	err := i.db.WithContext(ctx).Model(&entity.IdempotencyKey{}).Where("key = ?", key).Select("status_code", "headers", "body").
+		Updates(&entity.IdempotencyKey{StatusCode: statusCode, Headers: headers, Body: body}).Error
+	if err != nil {
+		return dto.ErrISEIdempotency
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKeyRepository frees a key so the request can be retried.
+func (i *idempotencyRepository) ReleaseIdempotencyKeyRepository(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, i.timeouts.Query)
+	defer cancel()
+
+	if err := i.db.WithContext(ctx).Where("key = ?", key).Delete(&entity.IdempotencyKey{}).Error; err != nil {
+		return dto.ErrISEIdempotency
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+
+	"gorm.io/gorm"
+)
+
+type (
+	ProductImageRepository interface {
+		RetrieveProductImagesRepository(ctx context.Context, barcodeId string) ([]entity.ProductImage, error)
+		CreateProductImageRepository(ctx context.Context, image *entity.ProductImage) error
+		ReorderProductImagesRepository(ctx context.Context, barcodeId string, imageIds []uint) ([]entity.ProductImage, error)
+		SetPrimaryProductImageRepository(ctx context.Context, barcodeId string, imageId uint) error
+		DeleteProductImageRepository(ctx context.Context, barcodeId string, imageId uint) (entity.ProductImage, error)
+		RetrieveReferencedImagesRepository(ctx context.Context) ([]string, error)
+	}
+	productImageRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewProductImageRepository(db *gorm.DB, cfg *config.Config) ProductImageRepository {
+	return &productImageRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+func retrieveProductImages(tx *gorm.DB, barcodeId string) ([]entity.ProductImage, error) {
+	var images []entity.ProductImage
+	if err := tx.Where("barcode_id = ?", barcodeId).Order("position, id").Find(&images).Error; err != nil {
+		return nil, dto.ErrISEProductImages
+	}
+	return images, nil
+}
+
+// setPrimaryImage mirrors the primary image on the product, which bumps its
+// version like any other change to the product.
+func setPrimaryImage(tx *gorm.DB, barcodeId, image string) error {
+	err := tx.Model(&entity.Product{}).Where("barcode_id = ?", barcodeId).
+		Updates(map[string]interface{}{"image": image, "version": gorm.Expr("version + 1")}).Error
+	if err != nil {
+		return dto.ErrISEProductImages
+	}
+	return nil
+}
+
+func (p *productImageRepository) RetrieveProductImagesRepository(ctx context.Context, barcodeId string) ([]entity.ProductImage, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	var total int64
+	if err := p.db.WithContext(ctx).Model(&entity.Product{}).Where("barcode_id = ?", barcodeId).Count(&total).Error; err != nil {
+		return nil, dto.ErrISEProductImages
+	}
+	if total == 0 {
+		return nil, dto.ErrProductDoesntExist
+	}
+	return retrieveProductImages(p.db.WithContext(ctx), barcodeId)
+}
+
+// CreateProductImageRepository appends the image after the existing ones. The
+// first image of a product becomes its primary image.
+func (p *productImageRepository) CreateProductImageRepository(ctx context.Context, image *entity.ProductImage) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, image.BarcodeId); err != nil {
+			return err
+		}
+		images, err := retrieveProductImages(tx, image.BarcodeId)
+		if err != nil {
+			return err
+		}
+		if len(images) >= constant.MaxProductImages {
+			return dto.ErrTooManyProductImages
+		}
+		image.Position, image.IsPrimary = 0, true
+		for _, existing := range images {
+			image.Position = max(image.Position, existing.Position+1)
+			if existing.IsPrimary {
+				image.IsPrimary = false
+			}
+		}
+		if err := tx.Create(image).Error; err != nil {
+			return dto.ErrISEProductImages
+		}
+		if !image.IsPrimary {
+			return nil
+		}
+		return setPrimaryImage(tx, image.BarcodeId, image.Image)
+	})
+}
+
+// ReorderProductImagesRepository positions the images in the given order,
+// which has to name every image of the product once.
+func (p *productImageRepository) ReorderProductImagesRepository(ctx context.Context, barcodeId string, imageIds []uint) ([]entity.ProductImage, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	var reordered []entity.ProductImage
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, barcodeId); err != nil {
+			return err
+		}
+		images, err := retrieveProductImages(tx, barcodeId)
+		if err != nil {
+			return err
+		}
+		if len(images) != len(imageIds) {
+			return dto.ErrInvalidImageOrder
+		}
+		byId := make(map[uint]entity.ProductImage, len(images))
+		for _, image := range images {
+			byId[image.ID] = image
+		}
+		for position, id := range imageIds {
+			image, ok := byId[id]
+			if !ok {
+				return dto.ErrInvalidImageOrder
+			}
+			delete(byId, id)
+			if image.Position != position {
+				if err := tx.Model(&image).Update("position", position).Error; err != nil {
+					return dto.ErrISEProductImages
+				}
+				image.Position = position
+			}
+			reordered = append(reordered, image)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reordered, nil
+}
+
+func (p *productImageRepository) SetPrimaryProductImageRepository(ctx context.Context, barcodeId string, imageId uint) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, barcodeId); err != nil {
+			return err
+		}
+		var image entity.ProductImage
+		err := tx.Where("id = ? AND barcode_id = ?", imageId, barcodeId).First(&image).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrProductImageNotFound
+		} else if err != nil {
+			return dto.ErrISEProductImages
+		}
+		if image.IsPrimary {
+			return nil
+		}
+		err = tx.Model(&entity.ProductImage{}).Where("barcode_id = ?", barcodeId).Update("is_primary", gorm.Expr("id = ?", imageId)).Error
+		if err != nil {
+			return dto.ErrISEProductImages
+		}
+		return setPrimaryImage(tx, barcodeId, image.Image)
+	})
+}
+
+// DeleteProductImageRepository removes the image and returns it so the caller
+// can remove its files. Deleting the primary image promotes the first of the
+// remaining ones.
+func (p *productImageRepository) DeleteProductImageRepository(ctx context.Context, barcodeId string, imageId uint) (entity.ProductImage, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	var image entity.ProductImage
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, barcodeId); err != nil {
+			return err
+		}
+		err := tx.Where("id = ? AND barcode_id = ?", imageId, barcodeId).First(&image).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrProductImageNotFound
+		} else if err != nil {
+			return dto.ErrISEProductImages
+		}
+		if err := tx.Delete(&image).Error; err != nil {
+			return dto.ErrISEProductImages
+		}
+		if !image.IsPrimary {
+			return nil
+		}
+		var next entity.ProductImage
+		err = tx.Where("barcode_id = ?", barcodeId).Order("position, id").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return setPrimaryImage(tx, barcodeId, "")
+		} else if err != nil {
+			return dto.ErrISEProductImages
+		}
+		if err := tx.Model(&next).Update("is_primary", true).Error; err != nil {
+			return dto.ErrISEProductImages
+		}
+		return setPrimaryImage(tx, barcodeId, next.Image)
+	})
+	if err != nil {
+		return entity.ProductImage{}, err
+	}
+	return image, nil
+}
+
+// RetrieveReferencedImagesRepository lists every image still in use. Images of
+// soft-deleted products no longer count, restoring one uploads a new image.
+func (p *productImageRepository) RetrieveReferencedImagesRepository(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Bulk)
+	defer cancel()
+
+	var images []string
+	err := p.db.WithContext(ctx).Raw(`
+		SELECT image FROM products WHERE deleted_at IS NULL AND image <> ''
+		UNION
+		SELECT product_images.image FROM product_images
+		JOIN products ON products.barcode_id = product_images.barcode_id
+		WHERE products.deleted_at IS NULL`).Scan(&images).Error
+	if err != nil {
+		return nil, dto.ErrISEProductImages
+	}
+	return images, nil
+}
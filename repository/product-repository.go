@@ -2,140 +2,692 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
 	"tiga-putra-cashier-be/utils"
 	"time"
+	"unicode"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	ProductRepository interface {
-		CountProductsRepository() (uint16, error)
-		RetrieveProductsRepository(limit, offset uint16) ([]entity.Product, error)
-		RetrieveProductByBarcodeId(barcodeId *string) (dto.ProductWithoutTimeStamp, bool)
-		RetrieveProductForSearch(req *dto.SearchProductQuery) ([]entity.Product, error)
-		RetrieveDeletedProductByBarcodeId(barcodeId *string) (dto.ProductWithoutTimeStamp, bool)
-		CreateProductRepository(product *entity.Product) error
-		UpdateProductRepository(barcodeId *string, product *map[string]interface{}) error
-		UpdateDeletedProductRepository(barcodeId *string) error
-		DeleteProductRepository(barcodeId *string) error
+		CountProductsRepository(ctx context.Context, query *dto.ProductListQuery) (int64, error)
+		RetrieveProductsRepository(ctx context.Context, query *dto.ProductListQuery, limit, offset int) ([]entity.Product, error)
+		RetrieveProductsAfterRepository(ctx context.Context, query *dto.ProductListQuery, after *utils.Cursor, limit int) ([]entity.Product, string, error)
+		RetrieveProductByBarcodeId(ctx context.Context, barcodeId *string) (dto.ProductWithoutTimeStamp, bool)
+		CountProductsForSearchRepository(ctx context.Context, req *dto.SearchProductQuery) (int64, error)
+		RetrieveProductForSearch(ctx context.Context, req *dto.SearchProductQuery, limit, offset int) ([]entity.Product, error)
+		RetrieveProductSuggestionsRepository(ctx context.Context, term string, limit int) ([]dto.ProductSuggestion, error)
+		RetrieveDeletedProductByBarcodeId(ctx context.Context, barcodeId *string) (dto.ProductWithoutTimeStamp, bool)
+		CreateProductRepository(ctx context.Context, product *entity.Product) error
+		UpdateProductRepository(ctx context.Context, barcodeId *string, product *map[string]interface{}, version *uint) error
+		UpdateDeletedProductRepository(ctx context.Context, barcodeId *string, image string) error
+		DeleteProductRepository(ctx context.Context, barcodeId *string, version *uint) ([]string, error)
+		RetrieveStorePriceOverridesRepository(ctx context.Context, storeId uint, barcodeIds []string) (map[string]decimal.Decimal, error)
+		StreamProductsRepository(ctx context.Context, includeDeleted bool, fn func(entity.Product) error) error
+		RetrieveProductsByBarcodeIdsRepository(ctx context.Context, barcodeIds []string) (map[string]entity.Product, error)
+		UpsertProductsRepository(ctx context.Context, products []entity.Product, columns []string) error
+		RetrieveProductsByFilterRepository(ctx context.Context, filter dto.ProductFilter) ([]entity.Product, error)
+		UpdateProductPricesRepository(ctx context.Context, filter dto.ProductFilter, adjust func(entity.Product) (decimal.Decimal, error)) ([]dto.PriceChange, error)
+		RetrievePricesAtRepository(ctx context.Context, storeId uint, barcodeIds []string, at time.Time) (map[string]dto.ProductPriceAt, error)
+		// WithTx runs fn against a repository bound to one transaction, which
+		// commits when fn returns nil and rolls back otherwise.
+		WithTx(ctx context.Context, fn func(repo ProductRepository) error) error
 	}
 	productRepository struct {
-		db *gorm.DB
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+		inTx     bool
 	}
 )
 
-func NewProductRepository(db *gorm.DB) ProductRepository {
-	return &productRepository{db}
+func NewProductRepository(db *gorm.DB, cfg *config.Config) ProductRepository {
+	return &productRepository{db: db, timeouts: cfg.Database.Timeouts}
 }
 
-func (p *productRepository) CountProductsRepository() (uint16, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (p *productRepository) WithTx(ctx context.Context, fn func(repo ProductRepository) error) error {
+	if p.inTx {
+		return fn(p)
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Tx)
+	defer cancel()
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&productRepository{db: tx, timeouts: p.timeouts, inTx: true})
+	})
+}
+
+// lockInTx makes a read inside WithTx lock the rows it returns, so what the
+// caller checked can't change before its transaction ends.
+func (p *productRepository) lockInTx(db *gorm.DB) *gorm.DB {
+	if !p.inTx {
+		return db
+	}
+	return db.Clauses(clause.Locking{Strength: "UPDATE"})
+}
+
+// productListColumns maps the sorts a listing accepts to what they order by.
+var productListColumns = map[string]string{
+	"title":      "products.title",
+	"price":      "products.price",
+	"created_at": "products.created_at",
+	"updated_at": "products.updated_at",
+	"stock":      "COALESCE(s.current_stock, 0)",
+}
+
+func filterProductList(query *dto.ProductListQuery) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query.MinPrice != nil {
+			db = db.Where("products.price >= ?", *query.MinPrice)
+		}
+		if query.MaxPrice != nil {
+			db = db.Where("products.price <= ?", *query.MaxPrice)
+		}
+		if query.Category != "" {
+			db = db.Where("products.category = ?", query.Category)
+		}
+		if query.HasImage != nil {
+			if *query.HasImage {
+				db = db.Where("products.image <> ''")
+			} else {
+				db = db.Where("products.image = ''")
+			}
+		}
+		return db
+	}
+}
+
+func joinProductStock(query *dto.ProductListQuery) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query.Sort != "stock" {
+			return db
+		}
+		return db.Joins("LEFT JOIN (SELECT barcode_id, SUM(quantity) AS current_stock FROM stock_movements WHERE deleted_at IS NULL AND (? = 0 OR store_id = ?) GROUP BY barcode_id) s ON s.barcode_id = products.barcode_id",
+			query.StoreId, query.StoreId)
+	}
+}
+
+// sortProductList orders by the requested column, falling back to insertion
+// order, with the barcode breaking ties so pages don't overlap.
+func sortProductList(query *dto.ProductListQuery) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		column, ok := productListColumns[query.Sort]
+		if !ok {
+			return db.Order("products.id")
+		}
+		db = db.Scopes(joinProductStock(query))
+		direction := "ASC"
+		if query.Order == "desc" {
+			direction = "DESC"
+		}
+		return db.Order(column + " " + direction + ", products.barcode_id")
+	}
+}
+
+func (p *productRepository) CountProductsRepository(ctx context.Context, query *dto.ProductListQuery) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
 	var totalProduct int64
-	err := p.db.WithContext(ctx).Model(&entity.Product{}).Count(&totalProduct).Error
+	err := p.db.WithContext(ctx).Model(&entity.Product{}).Scopes(filterProductList(query)).Count(&totalProduct).Error
 	if err != nil {
 		return 0, dto.ErrISEProducts
 	}
-	return uint16(totalProduct), nil
+	return totalProduct, nil
 }
 
-func (p *productRepository) RetrieveProductsRepository(limit, offset uint16) ([]entity.Product, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (p *productRepository) RetrieveProductsRepository(ctx context.Context, query *dto.ProductListQuery, limit, offset int) ([]entity.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
 	var allProducts []entity.Product
-	err := p.db.WithContext(ctx).Scopes(utils.Paginate(limit, offset)).Find(&allProducts).Error
+	err := p.db.WithContext(ctx).Select("products.*").
+		Scopes(filterProductList(query), sortProductList(query), utils.Paginate(limit, offset)).Find(&allProducts).Error
 	if err != nil {
 		return []entity.Product{}, dto.ErrISEProducts
 	}
 	return allProducts, nil
 }
 
-func (p *productRepository) RetrieveProductByBarcodeId(barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+type productRow struct {
+	entity.Product
+	CurrentStock int64
+}
+
+func (r productRow) cursor(query *dto.ProductListQuery) utils.Cursor {
+	cursor := utils.Cursor{Sort: query.Sort, Order: query.Order, Id: r.ID}
+	switch query.Sort {
+	case "title":
+		cursor.Value = r.Title
+	case "price":
+		cursor.Value = r.Price.String()
+	case "created_at":
+		cursor.Value = r.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		cursor.Value = r.UpdatedAt.Format(time.RFC3339Nano)
+	case "stock":
+		cursor.Value = strconv.FormatInt(r.CurrentStock, 10)
+	}
+	return cursor
+}
+
+// RetrieveProductsAfterRepository returns up to limit products following after,
+// or the first ones when after is nil, and the cursor of the next page when
+// there is one.
+func (p *productRepository) RetrieveProductsAfterRepository(ctx context.Context, query *dto.ProductListQuery, after *utils.Cursor, limit int) ([]entity.Product, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	columns := "products.*"
+	if query.Sort == "stock" {
+		columns += ", COALESCE(s.current_stock, 0) AS current_stock"
+	}
+	var rows []productRow
+	err := p.db.WithContext(ctx).Model(&entity.Product{}).Select(columns).
+		Scopes(filterProductList(query), joinProductStock(query), utils.Keyset(productListColumns[query.Sort], "products.id", query.Order == "desc", after, limit+1)).
+		Find(&rows).Error
+	if err != nil {
+		return []entity.Product{}, "", dto.ErrISEProducts
+	}
+
+	var next string
+	if len(rows) > limit {
+		rows = rows[:limit]
+		next = utils.EncodeCursor(rows[limit-1].cursor(query))
+	}
+	products := make([]entity.Product, len(rows))
+	for i, row := range rows {
+		products[i] = row.Product
+	}
+	return products, next, nil
+}
+
+func (p *productRepository) RetrieveProductByBarcodeId(ctx context.Context, barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
 
 	var product dto.ProductWithoutTimeStamp
-	err := p.db.WithContext(ctx).Model(&entity.Product{}).Where("barcode_id = ?", *barcodeId).First(&product).Error
+	err := p.db.WithContext(ctx).Scopes(p.lockInTx).Model(&entity.Product{}).Where("barcode_id = ?", *barcodeId).First(&product).Error
 	if err != nil {
 		return dto.ProductWithoutTimeStamp{}, false
 	}
 	return product, true
 }
 
-func (p *productRepository) RetrieveProductForSearch(req *dto.SearchProductQuery) ([]entity.Product, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+// searchTerm is what q (or the older title) matched against, and searchQuery
+// turns it into a prefix tsquery. Only letters and digits make it into the
+// tsquery, so user input can't break its syntax.
+func searchTerm(req *dto.SearchProductQuery) string {
+	if req.Query == "" && req.Title != nil {
+		return strings.TrimSpace(*req.Title)
+	}
+	return strings.TrimSpace(req.Query)
+}
 
-	var products []entity.Product
-	query := p.db.WithContext(ctx).Model(&entity.Product{})
+func searchQuery(term string) string {
+	words := strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
 
-	if req.Title != nil && *req.Title != "" {
-		query = query.Where("title ILIKE ?", "%"+*req.Title+"%")
+// matchProducts finds products whose title or description contain words
+// starting with the term, whose title is close to it despite typos, or whose
+// barcode starts with it.
+func matchProducts(term, barcodeId string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if term != "" {
+			db = db.Where(constant.ProductSearchDocument+" @@ to_tsquery('simple', ?) OR ? <% title OR barcode_id LIKE ?",
+				searchQuery(term), term, escapeLike(term)+"%")
+		}
+		if barcodeId != "" {
+			db = db.Where("barcode_id LIKE ?", escapeLike(barcodeId)+"%")
+		}
+		return db
 	}
+}
 
-	if req.BarcodeId != nil && *req.BarcodeId != "" {
-		query = query.Where("barcode_id = ?", *req.BarcodeId)
+// rankProducts puts barcode prefix matches first, then orders by full-text
+// rank plus title similarity.
+func rankProducts(term string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if term == "" {
+			return db.Order("barcode_id")
+		}
+		return db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "(barcode_id LIKE ?) DESC, ts_rank(" + constant.ProductSearchDocument + ", to_tsquery('simple', ?)) + word_similarity(?, title) DESC, barcode_id",
+			Vars: []interface{}{escapeLike(term) + "%", searchQuery(term), term},
+		}})
 	}
+}
+
+func (p *productRepository) CountProductsForSearchRepository(ctx context.Context, req *dto.SearchProductQuery) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
 
-	err := query.Find(&products).Error
+	var barcodeId string
+	if req.BarcodeId != nil {
+		barcodeId = strings.TrimSpace(*req.BarcodeId)
+	}
+	var total int64
+	err := p.db.WithContext(ctx).Model(&entity.Product{}).Scopes(matchProducts(searchTerm(req), barcodeId)).Count(&total).Error
+	if err != nil {
+		return 0, dto.ErrISEProducts
+	}
+	return total, nil
+}
+
+func (p *productRepository) RetrieveProductForSearch(ctx context.Context, req *dto.SearchProductQuery, limit, offset int) ([]entity.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	var barcodeId string
+	if req.BarcodeId != nil {
+		barcodeId = strings.TrimSpace(*req.BarcodeId)
+	}
+	term := searchTerm(req)
+	var products []entity.Product
+	err := p.db.WithContext(ctx).Scopes(matchProducts(term, barcodeId), rankProducts(term), utils.Paginate(limit, offset)).Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
-func (p *productRepository) RetrieveDeletedProductByBarcodeId(barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (p *productRepository) RetrieveProductSuggestionsRepository(ctx context.Context, term string, limit int) ([]dto.ProductSuggestion, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	var suggestions []dto.ProductSuggestion
+	err := p.db.WithContext(ctx).Model(&entity.Product{}).Select("barcode_id", "title").
+		Scopes(matchProducts(term, ""), rankProducts(term)).Limit(limit).Find(&suggestions).Error
+	if err != nil {
+		return nil, dto.ErrISEProducts
+	}
+	return suggestions, nil
+}
+
+func (p *productRepository) RetrieveDeletedProductByBarcodeId(ctx context.Context, barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
 
 	var product dto.ProductWithoutTimeStamp
-	err := p.db.WithContext(ctx).Unscoped().Model(&entity.Product{}).Where("barcode_id = ? AND deleted_at IS NOT NULL", *barcodeId).First(&product).Error
+	err := p.db.WithContext(ctx).Scopes(p.lockInTx).Unscoped().Model(&entity.Product{}).Where("barcode_id = ? AND deleted_at IS NOT NULL", *barcodeId).First(&product).Error
 	if err != nil {
 		return dto.ProductWithoutTimeStamp{}, false
 	}
 	return product, true
 }
 
-func (p *productRepository) CreateProductRepository(product *entity.Product) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (p *productRepository) CreateProductRepository(ctx context.Context, product *entity.Product) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
 
-	err := p.db.WithContext(ctx).Create(product).Error
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		if err := syncPrimaryImages(tx, []string{product.BarcodeId}); err != nil {
+			return err
+		}
+		return recordPrices(tx, []string{product.BarcodeId})
+	})
 	if err != nil {
 		return dto.ErrToAddProduct
 	}
 	return nil
 }
 
-func (p *productRepository) UpdateProductRepository(barcodeId *string, product *map[string]interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+// matchVersion limits a write to the version the client last saw. Without a
+// version the write goes through unconditionally.
+func matchVersion(version *uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if version == nil {
+			return db
+		}
+		return db.Where("version = ?", *version)
+	}
+}
+
+// UpdateProductRepository applies the updates and bumps the version in one
+// statement, so a stale version fails with ErrVersionMismatch instead of
+// overwriting a change made in between.
+func (p *productRepository) UpdateProductRepository(ctx context.Context, barcodeId *string, product *map[string]interface{}, version *uint) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
-	err := p.db.WithContext(ctx).Model(&entity.Product{}).Where("barcode_id = ?", *barcodeId).Updates(&product).Error
+	updates := map[string]interface{}{"version": gorm.Expr("version + 1")}
+	for column, value := range *product {
+		updates[column] = value
+	}
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Product{}).Where("barcode_id = ?", *barcodeId).Scopes(matchVersion(version)).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if version != nil && result.RowsAffected == 0 {
+			return dto.ErrVersionMismatch
+		}
+		if _, ok := (*product)["image"]; ok {
+			if err := syncPrimaryImages(tx, []string{*barcodeId}); err != nil {
+				return err
+			}
+		}
+		if _, ok := (*product)["price"]; !ok {
+			return nil
+		}
+		return recordPrices(tx, []string{*barcodeId})
+	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (p *productRepository) UpdateDeletedProductRepository(barcodeId *string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+// UpdateDeletedProductRepository revives a deleted product with a new primary
+// image, its images having been removed when it was deleted.
+func (p *productRepository) UpdateDeletedProductRepository(ctx context.Context, barcodeId *string, image string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
-	err := p.db.WithContext(ctx).Unscoped().Model(&entity.Product{}).Where("barcode_id = ?", *barcodeId).Update("deleted_at", nil).Error
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&entity.Product{}).Where("barcode_id = ?", *barcodeId).
+			Updates(map[string]interface{}{"deleted_at": nil, "image": image, "version": gorm.Expr("version + 1")}).Error
+		if err != nil {
+			return err
+		}
+		return syncPrimaryImages(tx, []string{*barcodeId})
+	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (p *productRepository) DeleteProductRepository(barcodeId *string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+// DeleteProductRepository soft deletes the product and drops its images for
+// good, returning their file names so the caller can remove the files.
+func (p *productRepository) DeleteProductRepository(ctx context.Context, barcodeId *string, version *uint) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
 	defer cancel()
-	err := p.db.WithContext(ctx).Where("barcode_id = ?", barcodeId).Delete(&entity.Product{}).Error
+	var images []string
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("barcode_id = ?", barcodeId).Scopes(matchVersion(version)).Delete(&entity.Product{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if version != nil && result.RowsAffected == 0 {
+			return dto.ErrVersionMismatch
+		}
+		if err := tx.Model(&entity.ProductImage{}).Where("barcode_id = ?", barcodeId).Pluck("image", &images).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("barcode_id = ?", barcodeId).Delete(&entity.ProductImage{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&entity.Product{}).Where("barcode_id = ?", barcodeId).Update("image", "").Error
+	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return images, nil
+}
+
+// RetrieveStorePriceOverridesRepository returns the prices a store sells the given
+// products at when they differ from the catalog price. The catalog itself is
+// shared by every store, so the other queries stay global.
+func (p *productRepository) RetrieveStorePriceOverridesRepository(ctx context.Context, storeId uint, barcodeIds []string) (map[string]decimal.Decimal, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	db := p.db.WithContext(ctx)
+	if err := checkStore(db, storeId); err != nil {
+		return nil, err
+	}
+	var overrides []entity.StorePrice
+	err := db.Where("store_id = ? AND barcode_id IN ?", storeId, barcodeIds).Find(&overrides).Error
+	if err != nil {
+		return nil, dto.ErrISEProducts
+	}
+	prices := make(map[string]decimal.Decimal)
+	for _, override := range overrides {
+		prices[override.BarcodeId] = override.Price
+	}
+	return prices, nil
+}
+
+// StreamProductsRepository walks the whole catalog in barcode order without
+// loading it into memory.
+func (p *productRepository) StreamProductsRepository(ctx context.Context, includeDeleted bool, fn func(entity.Product) error) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Bulk)
+	defer cancel()
+
+	db := p.db.WithContext(ctx)
+	query := db.Model(&entity.Product{})
+	if includeDeleted {
+		query = query.Unscoped()
+	}
+	rows, err := query.Order("barcode_id").Rows()
+	if err != nil {
+		return dto.ErrISEProducts
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var product entity.Product
+		if err := db.ScanRows(rows, &product); err != nil {
+			return dto.ErrISEProducts
+		}
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return dto.ErrISEProducts
+	}
+	return nil
+}
+
+// RetrieveProductsByBarcodeIdsRepository also returns deleted products, keyed by
+// barcode, so an import can tell which rows it will create, update or revive.
+func (p *productRepository) RetrieveProductsByBarcodeIdsRepository(ctx context.Context, barcodeIds []string) (map[string]entity.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	var products []entity.Product
+	err := p.db.WithContext(ctx).Unscoped().Where("barcode_id IN ?", barcodeIds).Find(&products).Error
+	if err != nil {
+		return nil, dto.ErrISEProducts
+	}
+	existing := make(map[string]entity.Product, len(products))
+	for _, product := range products {
+		existing[product.BarcodeId] = product
+	}
+	return existing, nil
+}
+
+// UpsertProductsRepository writes a batch of products in one transaction.
+// Existing barcodes are overwritten and revived when they were deleted, but
+// keep their image when the batch doesn't bring a new one. Besides title,
+// price and description only the given columns are overwritten, and a product
+// that would come out the same is left alone so its version stays.
+func (p *productRepository) UpsertProductsRepository(ctx context.Context, products []entity.Product, columns []string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Bulk)
+	defer cancel()
+
+	compared := append([]string{"title", "price", "description"}, columns...)
+	updates := clause.AssignmentColumns(append([]string{"updated_at"}, compared...))
+	updates = append(updates,
+		clause.Assignment{Column: clause.Column{Name: "image"}, Value: gorm.Expr(`CASE WHEN excluded.image = '' THEN "products".image ELSE excluded.image END`)},
+		clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: nil},
+		clause.Assignment{Column: clause.Column{Name: "version"}, Value: gorm.Expr(`"products".version + 1`)},
+	)
+	current, excluded := make([]string, len(compared)), make([]string, len(compared))
+	for i, column := range compared {
+		current[i], excluded[i] = `"products".`+column, "excluded."+column
+	}
+	changed := gorm.Expr(fmt.Sprintf(`(%s) IS DISTINCT FROM (%s) OR (excluded.image <> '' AND excluded.image <> "products".image) OR "products".deleted_at IS NOT NULL`,
+		strings.Join(current, ", "), strings.Join(excluded, ", ")))
+	barcodeIds := make([]string, len(products))
+	for i, product := range products {
+		barcodeIds[i] = product.BarcodeId
+	}
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "barcode_id"}},
+			DoUpdates: updates,
+			Where:     clause.Where{Exprs: []clause.Expression{changed}},
+		}).Create(&products).Error
+		if err != nil {
+			return err
+		}
+		if err := syncPrimaryImages(tx, barcodeIds); err != nil {
+			return err
+		}
+		return recordPrices(tx, barcodeIds)
+	})
+	if err != nil {
+		return dto.ErrToAddProduct
 	}
 	return nil
 }
+
+func filterProducts(filter dto.ProductFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Category != "" {
+			db = db.Where("category = ?", filter.Category)
+		}
+		if filter.SupplierId != nil {
+			db = db.Where("supplier_id = ?", *filter.SupplierId)
+		}
+		if len(filter.BarcodeIds) > 0 {
+			db = db.Where("barcode_id IN ?", filter.BarcodeIds)
+		}
+		if filter.Search != "" {
+			db = db.Where(`title ILIKE ? ESCAPE '\'`, "%"+escapeLike(filter.Search)+"%")
+		}
+		return db.Order("barcode_id")
+	}
+}
+
+func (p *productRepository) RetrieveProductsByFilterRepository(ctx context.Context, filter dto.ProductFilter) ([]entity.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Bulk)
+	defer cancel()
+
+	var products []entity.Product
+	err := p.db.WithContext(ctx).Scopes(filterProducts(filter)).Find(&products).Error
+	if err != nil {
+		return nil, dto.ErrISEProducts
+	}
+	return products, nil
+}
+
+// UpdateProductPricesRepository locks the selected products and writes the price
+// adjust returns for each of them in one transaction, so a failing product
+// leaves every price as it was.
+func (p *productRepository) UpdateProductPricesRepository(ctx context.Context, filter dto.ProductFilter, adjust func(entity.Product) (decimal.Decimal, error)) ([]dto.PriceChange, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Bulk)
+	defer cancel()
+
+	var changes []dto.PriceChange
+	var changed []string
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var products []entity.Product
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(filterProducts(filter)).Find(&products).Error
+		if err != nil {
+			return dto.ErrToUpdateProductPrices
+		}
+		if len(products) == 0 {
+			return dto.ErrProductsNotFound
+		}
+		for _, product := range products {
+			price, err := adjust(product)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, dto.PriceChange{BarcodeId: product.BarcodeId, Title: product.Title, OldPrice: product.Price, NewPrice: price})
+			if price.Equal(product.Price) {
+				continue
+			}
+			err = tx.Model(&entity.Product{}).Where("barcode_id = ?", product.BarcodeId).
+				Updates(map[string]interface{}{"price": price, "version": gorm.Expr("version + 1")}).Error
+			if err != nil {
+				return dto.ErrToUpdateProductPrices
+			}
+			changed = append(changed, product.BarcodeId)
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		if err := recordPrices(tx, changed); err != nil {
+			return dto.ErrToUpdateProductPrices
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// recordPrices appends the current catalog price of the given products to the
+// price history when it differs from the last one recorded.
+func recordPrices(tx *gorm.DB, barcodeIds []string) error {
+	return tx.Exec(`INSERT INTO price_histories (created_at, updated_at, barcode_id, price)
+		SELECT NOW(), NOW(), products.barcode_id, products.price FROM products
+		WHERE products.barcode_id IN ? AND products.price IS DISTINCT FROM (
+			SELECT price_histories.price FROM price_histories WHERE price_histories.barcode_id = products.barcode_id
+			ORDER BY price_histories.created_at DESC, price_histories.id DESC LIMIT 1)`, barcodeIds).Error
+}
+
+// syncPrimaryImages points the primary image of the given products at their
+// current image, adding one for products that have an image but no primary
+// image yet.
+func syncPrimaryImages(tx *gorm.DB, barcodeIds []string) error {
+	return tx.Exec(`WITH updated AS (
+			UPDATE product_images SET image = products.image, updated_at = NOW() FROM products
+			WHERE product_images.barcode_id = products.barcode_id AND product_images.is_primary
+				AND products.barcode_id IN ? AND products.image <> '' AND product_images.image <> products.image)
+		INSERT INTO product_images (created_at, updated_at, barcode_id, image, position, is_primary)
+		SELECT NOW(), NOW(), products.barcode_id, products.image, 0, TRUE FROM products
+		WHERE products.barcode_id IN ? AND products.image <> '' AND NOT EXISTS (
+			SELECT 1 FROM product_images WHERE product_images.barcode_id = products.barcode_id AND product_images.is_primary)`,
+		barcodeIds, barcodeIds).Error
+}
+
+// RetrievePricesAtRepository returns the price each product sold at in the
+// store at the given time, deleted products included: the store's own price
+// when it had one then, else the catalog price. Products without catalog
+// history from then fall back to their current price.
+func (p *productRepository) RetrievePricesAtRepository(ctx context.Context, storeId uint, barcodeIds []string, at time.Time) (map[string]dto.ProductPriceAt, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Query)
+	defer cancel()
+
+	var rows []dto.ProductPriceAt
+	err := p.db.WithContext(ctx).Unscoped().Model(&entity.Product{}).
+		Select(`products.barcode_id, products.title, products.deleted_at IS NOT NULL AS deleted, COALESCE((
+			SELECT store_price_histories.price FROM store_price_histories
+			WHERE store_price_histories.store_id = ? AND store_price_histories.barcode_id = products.barcode_id AND store_price_histories.created_at <= ? AND store_price_histories.deleted_at IS NULL
+			ORDER BY store_price_histories.created_at DESC, store_price_histories.id DESC LIMIT 1), (
+			SELECT price_histories.price FROM price_histories WHERE price_histories.barcode_id = products.barcode_id AND price_histories.created_at <= ?
+			ORDER BY price_histories.created_at DESC, price_histories.id DESC LIMIT 1), products.price) AS price`, storeId, at, at).
+		Where("products.barcode_id IN ?", barcodeIds).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, dto.ErrISEProducts
+	}
+	prices := make(map[string]dto.ProductPriceAt, len(rows))
+	for _, row := range rows {
+		prices[row.BarcodeId] = row
+	}
+	return prices, nil
+}
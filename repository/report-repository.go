@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+
+	"gorm.io/gorm"
+)
+
+// reportFilter limits a report to the transactions of whole local days between
+// from and to, optionally in one store. The day boundaries are computed by
+// Postgres in the report time zone, so they don't depend on the server's zone.
+const reportFilter = `t.deleted_at IS NULL
+	AND t.created_at >= (?::date)::timestamp AT TIME ZONE ?
+	AND t.created_at < (?::date + 1)::timestamp AT TIME ZONE ?
+	AND (? = 0 OR t.store_id = ?)`
+
+type (
+	ReportRepository interface {
+		RetrieveSalesByPeriodRepository(ctx context.Context, filter dto.ReportQuery, period string) ([]dto.SalesPeriodTotal, error)
+		RetrieveSalesByHourRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.HourlySales, error)
+		RetrieveSalesByCashierRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.CashierSales, error)
+		RetrieveSalesByPaymentMethodRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.PaymentMethodSales, error)
+		RetrieveSalesByCategoryRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.CategorySales, error)
+		RetrieveTopProductsRepository(ctx context.Context, filter dto.ReportQuery, rankBy string, limit uint16) ([]dto.ProductSales, error)
+		StreamStockValuationRepository(ctx context.Context, storeId uint, fn func(dto.StockValuation) error) error
+	}
+	reportRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewReportRepository(db *gorm.DB, cfg *config.Config) ReportRepository {
+	return &reportRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+func (r *reportRepository) RetrieveSalesByPeriodRepository(ctx context.Context, filter dto.ReportQuery, period string) ([]dto.SalesPeriodTotal, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	args := append([]interface{}{period, constant.ReportTimeZone}, reportFilterArgs(filter)...)
+	var totals []dto.SalesPeriodTotal
+	err := r.db.WithContext(ctx).Raw(`SELECT to_char(date_trunc(?, t.created_at AT TIME ZONE ?), 'YYYY-MM-DD') AS period, COUNT(*) AS transactions, SUM(t.total) AS revenue, ROUND(AVG(t.total), 2) AS average_ticket
+		FROM transactions t
+		WHERE `+reportFilter+`
+		GROUP BY 1
+		ORDER BY 1`, args...).Scan(&totals).Error
+	if err != nil {
+		return nil, dto.ErrISEReport
+	}
+	return totals, nil
+}
+
+func (r *reportRepository) RetrieveSalesByHourRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.HourlySales, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	args := append([]interface{}{constant.ReportTimeZone}, reportFilterArgs(filter)...)
+	var sales []dto.HourlySales
+	err := r.db.WithContext(ctx).Raw(`SELECT EXTRACT(HOUR FROM t.created_at AT TIME ZONE ?)::int AS hour, COUNT(*) AS transactions, SUM(t.total) AS revenue
+		FROM transactions t
+		WHERE `+reportFilter+`
+		GROUP BY 1
+		ORDER BY 1`, args...).Scan(&sales).Error
+	if err != nil {
+		return nil, dto.ErrISEReport
+	}
+	return sales, nil
+}
+
+func (r *reportRepository) RetrieveSalesByCashierRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.CashierSales, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	var sales []dto.CashierSales
+	err := r.db.WithContext(ctx).Raw(`SELECT t.cashier, COUNT(*) AS transactions, SUM(t.total) AS revenue
+		FROM transactions t
+		WHERE `+reportFilter+`
+		GROUP BY t.cashier
+		ORDER BY revenue DESC, t.cashier`, reportFilterArgs(filter)...).Scan(&sales).Error
+	if err != nil {
+		return nil, dto.ErrISEReport
+	}
+	return sales, nil
+}
+
+func (r *reportRepository) RetrieveSalesByPaymentMethodRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.PaymentMethodSales, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	var sales []dto.PaymentMethodSales
+	err := r.db.WithContext(ctx).Raw(`SELECT t.payment_method, COUNT(*) AS transactions, SUM(t.total) AS revenue
+		FROM transactions t
+		WHERE `+reportFilter+`
+		GROUP BY t.payment_method
+		ORDER BY revenue DESC, t.payment_method`, reportFilterArgs(filter)...).Scan(&sales).Error
+	if err != nil {
+		return nil, dto.ErrISEReport
+	}
+	return sales, nil
+}
+
+// RetrieveSalesByCategoryRepository groups sold items by the current category of
+// their product, including products that were deleted since.
+func (r *reportRepository) RetrieveSalesByCategoryRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.CategorySales, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	args := append([]interface{}{constant.UncategorizedCategory}, reportFilterArgs(filter)...)
+	var sales []dto.CategorySales
+	err := r.db.WithContext(ctx).Raw(`SELECT COALESCE(NULLIF(p.category, ''), ?) AS category, SUM(ti.quantity) AS quantity, SUM(ti.subtotal) AS revenue
+		FROM transaction_items ti
+		JOIN transactions t ON t.id = ti.transaction_id
+		LEFT JOIN products p ON p.barcode_id = ti.barcode_id
+		WHERE ti.deleted_at IS NULL AND `+reportFilter+`
+		GROUP BY 1
+		ORDER BY revenue DESC, 1`, args...).Scan(&sales).Error
+	if err != nil {
+		return nil, dto.ErrISEReport
+	}
+	return sales, nil
+}
+
+func (r *reportRepository) RetrieveTopProductsRepository(ctx context.Context, filter dto.ReportQuery, rankBy string, limit uint16) ([]dto.ProductSales, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	order := "revenue DESC, quantity DESC"
+	if rankBy == constant.ReportRankByQuantity {
+		order = "quantity DESC, revenue DESC"
+	}
+	args := append(reportFilterArgs(filter), limit)
+	var products []dto.ProductSales
+	err := r.db.WithContext(ctx).Raw(`SELECT ti.barcode_id, MAX(ti.title) AS title, SUM(ti.quantity) AS quantity, SUM(ti.subtotal) AS revenue
+		FROM transaction_items ti
+		JOIN transactions t ON t.id = ti.transaction_id
+		WHERE ti.deleted_at IS NULL AND `+reportFilter+`
+		GROUP BY ti.barcode_id
+		ORDER BY `+order+`, ti.barcode_id
+		LIMIT ?`, args...).Scan(&products).Error
+	if err != nil {
+		return nil, dto.ErrISEReport
+	}
+	return products, nil
+}
+
+// StreamStockValuationRepository values the stock on hand of every store, or
+// only storeId when it is set, at the price the store sells it for. Rows are
+// handed to fn one by one as they are read.
+func (r *reportRepository) StreamStockValuationRepository(ctx context.Context, storeId uint, fn func(dto.StockValuation) error) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Bulk)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+	rows, err := db.Raw(`SELECT st.id AS store_id, st.name AS store_name, p.barcode_id, p.title, s.current_stock AS quantity, COALESCE(sp.price, p.price) AS unit_price, s.current_stock * COALESCE(sp.price, p.price) AS value
+		FROM (`+currentStockQuery+`) s
+		JOIN stores st ON st.id = s.store_id AND st.deleted_at IS NULL
+		JOIN products p ON p.barcode_id = s.barcode_id AND p.deleted_at IS NULL
+		LEFT JOIN store_prices sp ON sp.store_id = s.store_id AND sp.barcode_id = s.barcode_id AND sp.deleted_at IS NULL
+		WHERE s.current_stock <> 0 AND (? = 0 OR s.store_id = ?)
+		ORDER BY st.id, p.barcode_id`, storeId, storeId).Rows()
+	if err != nil {
+		return dto.ErrISEReport
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var valuation dto.StockValuation
+		if err := db.ScanRows(rows, &valuation); err != nil {
+			return dto.ErrISEReport
+		}
+		if err := fn(valuation); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return dto.ErrISEReport
+	}
+	return nil
+}
+
+func reportFilterArgs(filter dto.ReportQuery) []interface{} {
+	return []interface{}{
+		filter.From, constant.ReportTimeZone,
+		filter.To, constant.ReportTimeZone,
+		filter.StoreId, filter.StoreId,
+	}
+}
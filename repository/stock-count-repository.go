@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const stockCountNote = "Stock opname adjustment"
+
+const systemStockAsOfQuery = `SELECT barcode_id, SUM(quantity) AS system_quantity FROM stock_movements WHERE store_id = ? AND created_at <= ? AND deleted_at IS NULL GROUP BY barcode_id`
+
+type (
+	StockCountRepository interface {
+		RetrieveOpenSessionRepository(ctx context.Context, storeId uint) (entity.StockCountSession, bool)
+		RetrieveSessionByIdRepository(ctx context.Context, sessionId uint) (entity.StockCountSession, bool)
+		CreateSessionRepository(ctx context.Context, session *entity.StockCountSession) error
+		RetrieveExistingBarcodesRepository(ctx context.Context, barcodeIds []string) ([]string, error)
+		UpsertCountItemsRepository(ctx context.Context, sessionId uint, items []entity.StockCountItem) error
+		RetrieveVariancesRepository(ctx context.Context, session entity.StockCountSession) ([]dto.StockVariance, error)
+		RetrieveUncountedProductsRepository(ctx context.Context, session entity.StockCountSession) ([]dto.UncountedProduct, error)
+		ApproveSessionRepository(ctx context.Context, sessionId uint, approvedBy string, includeUncounted bool) ([]dto.StockVariance, error)
+	}
+	stockCountRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewStockCountRepository(db *gorm.DB, cfg *config.Config) StockCountRepository {
+	return &stockCountRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+func (s *stockCountRepository) RetrieveOpenSessionRepository(ctx context.Context, storeId uint) (entity.StockCountSession, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var session entity.StockCountSession
+	err := s.db.WithContext(ctx).Where("store_id = ? AND status = ?", storeId, constant.StockCountOpen).First(&session).Error
+	if err != nil {
+		return entity.StockCountSession{}, false
+	}
+	return session, true
+}
+
+func (s *stockCountRepository) RetrieveSessionByIdRepository(ctx context.Context, sessionId uint) (entity.StockCountSession, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var session entity.StockCountSession
+	err := s.db.WithContext(ctx).Where("id = ?", sessionId).First(&session).Error
+	if err != nil {
+		return entity.StockCountSession{}, false
+	}
+	return session, true
+}
+
+func (s *stockCountRepository) CreateSessionRepository(ctx context.Context, session *entity.StockCountSession) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkStore(tx, session.StoreId); err != nil {
+			return err
+		}
+		if err := tx.Create(session).Error; err != nil {
+			return dto.ErrISEStockCount
+		}
+		return nil
+	})
+}
+
+func (s *stockCountRepository) RetrieveExistingBarcodesRepository(ctx context.Context, barcodeIds []string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var existing []string
+	err := s.db.WithContext(ctx).Model(&entity.Product{}).Where("barcode_id IN ?", barcodeIds).Pluck("barcode_id", &existing).Error
+	if err != nil {
+		return nil, dto.ErrISEStockCount
+	}
+	return existing, nil
+}
+
+// UpsertCountItemsRepository stores the counts of one device. Every device keeps
+// its own row per barcode, so concurrent submissions never overwrite each other
+// while a resubmission from the same device replaces its previous count.
+func (s *stockCountRepository) UpsertCountItemsRepository(ctx context.Context, sessionId uint, items []entity.StockCountItem) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var session entity.StockCountSession
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ?", sessionId).First(&session).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrStockCountNotFound
+		} else if err != nil {
+			return dto.ErrISEStockCount
+		}
+		if session.Status != constant.StockCountOpen {
+			return dto.ErrStockCountNotOpen
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}, {Name: "barcode_id"}, {Name: "device_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"counted_quantity", "counted_by", "updated_at"}),
+		}).Create(&items).Error
+		if err != nil {
+			return dto.ErrISEStockCount
+		}
+		return nil
+	})
+}
+
+func (s *stockCountRepository) RetrieveVariancesRepository(ctx context.Context, session entity.StockCountSession) ([]dto.StockVariance, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	variances, err := retrieveVariances(s.db.WithContext(ctx), session)
+	if err != nil {
+		return nil, dto.ErrISEStockCount
+	}
+	return variances, nil
+}
+
+func (s *stockCountRepository) RetrieveUncountedProductsRepository(ctx context.Context, session entity.StockCountSession) ([]dto.UncountedProduct, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	uncounted, err := retrieveUncountedProducts(s.db.WithContext(ctx), session)
+	if err != nil {
+		return nil, dto.ErrISEStockCount
+	}
+	return uncounted, nil
+}
+
+// ApproveSessionRepository locks the session, recomputes the variances and posts
+// them as adjustment movements in one transaction, so counts submitted while the
+// approval is running can't be lost.
+func (s *stockCountRepository) ApproveSessionRepository(ctx context.Context, sessionId uint, approvedBy string, includeUncounted bool) ([]dto.StockVariance, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var posted []dto.StockVariance
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var session entity.StockCountSession
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", sessionId).First(&session).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrStockCountNotFound
+		} else if err != nil {
+			return dto.ErrISEStockCount
+		}
+		if session.Status != constant.StockCountOpen {
+			return dto.ErrStockCountNotOpen
+		}
+
+		variances, err := retrieveVariances(tx, session)
+		if err != nil {
+			return dto.ErrISEStockCount
+		}
+		uncounted, err := retrieveUncountedProducts(tx, session)
+		if err != nil {
+			return dto.ErrISEStockCount
+		}
+		if len(uncounted) > 0 && !includeUncounted {
+			return dto.ErrUncountedProducts
+		}
+		for _, product := range uncounted {
+			variances = append(variances, dto.StockVariance{
+				BarcodeId:      product.BarcodeId,
+				Title:          product.Title,
+				SystemQuantity: product.SystemQuantity,
+				Variance:       -product.SystemQuantity,
+			})
+		}
+
+		for _, variance := range variances {
+			if variance.Variance == 0 {
+				continue
+			}
+			if err := postAdjustment(tx, session.StoreId, variance.BarcodeId, variance.Variance, fmt.Sprintf("stock-count-%d", sessionId)); err != nil {
+				return dto.ErrISEStockCount
+			}
+		}
+
+		err = tx.Model(&entity.StockCountSession{}).Where("id = ?", sessionId).Updates(map[string]interface{}{
+			"status":      constant.StockCountApproved,
+			"approved_by": approvedBy,
+			"approved_at": time.Now(),
+		}).Error
+		if err != nil {
+			return dto.ErrISEStockCount
+		}
+		posted = variances
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return posted, nil
+}
+
+// postAdjustment books the variance of one product. A shortage is taken out
+// of the batches, expired ones first, so FEFO and write-offs no longer count
+// on the missing units. Only what exceeds the stock left after the sales since
+// the count started is booked without a batch.
+func postAdjustment(tx *gorm.DB, storeId uint, barcodeId string, variance int64, reference string) error {
+	untracked := variance
+	if variance < 0 {
+		err := lockProduct(tx, barcodeId)
+		if err != nil && err != dto.ErrProductDoesntExist {
+			return err
+		}
+		if err == nil {
+			currentStock, err := productStock(tx, storeId, barcodeId)
+			if err != nil {
+				return err
+			}
+			if take := min(-variance, currentStock); take > 0 {
+				if _, err := depleteStock(tx, storeId, barcodeId, take, true, constant.MovementAdjustment, reference, stockCountNote); err != nil {
+					return err
+				}
+				untracked += take
+			}
+		}
+	}
+	if untracked == 0 {
+		return nil
+	}
+	return tx.Create(&entity.StockMovement{
+		BarcodeId: barcodeId,
+		Quantity:  untracked,
+		Type:      constant.MovementAdjustment,
+		Reference: reference,
+		Note:      stockCountNote,
+		StoreId:   storeId,
+	}).Error
+}
+
+func retrieveVariances(db *gorm.DB, session entity.StockCountSession) ([]dto.StockVariance, error) {
+	var variances []dto.StockVariance
+	err := db.Raw(`SELECT c.barcode_id, COALESCE(p.title, '') AS title, COALESCE(s.system_quantity, 0) AS system_quantity, c.counted_quantity, c.counted_quantity - COALESCE(s.system_quantity, 0) AS variance
+		FROM (SELECT barcode_id, SUM(counted_quantity) AS counted_quantity FROM stock_count_items WHERE session_id = ? AND deleted_at IS NULL GROUP BY barcode_id) c
+		LEFT JOIN products p ON p.barcode_id = c.barcode_id
+		LEFT JOIN (`+systemStockAsOfQuery+`) s ON s.barcode_id = c.barcode_id
+		ORDER BY c.barcode_id`, session.ID, session.StoreId, session.StartedAt).Scan(&variances).Error
+	return variances, err
+}
+
+func retrieveUncountedProducts(db *gorm.DB, session entity.StockCountSession) ([]dto.UncountedProduct, error) {
+	var uncounted []dto.UncountedProduct
+	err := db.Raw(`SELECT p.barcode_id, p.title, COALESCE(s.system_quantity, 0) AS system_quantity
+		FROM products p
+		LEFT JOIN (`+systemStockAsOfQuery+`) s ON s.barcode_id = p.barcode_id
+		WHERE p.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM stock_count_items i WHERE i.session_id = ? AND i.barcode_id = p.barcode_id AND i.deleted_at IS NULL)
+		ORDER BY p.barcode_id`, session.StoreId, session.StartedAt, session.ID).Scan(&uncounted).Error
+	return uncounted, err
+}
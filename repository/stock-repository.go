@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const currentStockQuery = `SELECT store_id, barcode_id, SUM(quantity) AS current_stock FROM stock_movements WHERE deleted_at IS NULL GROUP BY store_id, barcode_id`
+
+type (
+	StockRepository interface {
+		RetrieveLowStockProductsRepository(ctx context.Context, storeId uint) ([]dto.LowStockProduct, error)
+		RetrieveReorderCandidatesRepository(ctx context.Context, storeId uint, salesSince time.Time) ([]dto.ReorderCandidate, error)
+		ReceiveStockRepository(ctx context.Context, batch *entity.StockBatch, reference string) error
+		RetrieveExpiringBatchesRepository(ctx context.Context, storeId uint, withinDays uint16) ([]dto.ExpiringBatch, error)
+		WriteOffBatchRepository(ctx context.Context, batchId uint, note string) (entity.StockBatch, error)
+		RetrieveStockLevelsRepository(ctx context.Context, barcodeId string) ([]dto.StoreStockLevel, error)
+	}
+	stockRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+
+	batchAllocation struct {
+		ID                uint
+		RemainingQuantity int64
+		Expired           bool
+	}
+)
+
+func NewStockRepository(db *gorm.DB, cfg *config.Config) StockRepository {
+	return &stockRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+// RetrieveLowStockProductsRepository checks the minimum stock of every product
+// in every store, or only in storeId when it is set.
+func (s *stockRepository) RetrieveLowStockProductsRepository(ctx context.Context, storeId uint) ([]dto.LowStockProduct, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var products []dto.LowStockProduct
+	err := s.db.WithContext(ctx).Raw(`SELECT st.id AS store_id, st.name AS store_name, p.barcode_id, p.title, COALESCE(s.current_stock, 0) AS current_stock, p.min_stock, p.reorder_quantity, p.supplier_id
+		FROM products p
+		CROSS JOIN stores st
+		LEFT JOIN (`+currentStockQuery+`) s ON s.barcode_id = p.barcode_id AND s.store_id = st.id
+		WHERE p.deleted_at IS NULL AND st.deleted_at IS NULL AND (? = 0 OR st.id = ?) AND p.min_stock > 0 AND COALESCE(s.current_stock, 0) <= p.min_stock
+		ORDER BY st.id, p.barcode_id`, storeId, storeId).Scan(&products).Error
+	if err != nil {
+		return nil, dto.ErrISEStock
+	}
+	return products, nil
+}
+
+func (s *stockRepository) RetrieveReorderCandidatesRepository(ctx context.Context, storeId uint, salesSince time.Time) ([]dto.ReorderCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var candidates []dto.ReorderCandidate
+	err := s.db.WithContext(ctx).Raw(`SELECT st.id AS store_id, st.name AS store_name, p.barcode_id, p.title, COALESCE(s.current_stock, 0) AS current_stock, p.min_stock, p.reorder_quantity, p.supplier_id,
+			COALESCE(sp.name, '') AS supplier_name, COALESCE(sp.lead_time_days, 0) AS lead_time_days, COALESCE(sl.sold_quantity, 0) AS sold_quantity
+		FROM products p
+		JOIN stores st ON st.id = ? AND st.deleted_at IS NULL
+		LEFT JOIN (`+currentStockQuery+`) s ON s.barcode_id = p.barcode_id AND s.store_id = st.id
+		LEFT JOIN (SELECT barcode_id, -SUM(quantity) AS sold_quantity FROM stock_movements WHERE store_id = ? AND type = ? AND created_at >= ? AND deleted_at IS NULL GROUP BY barcode_id) sl ON sl.barcode_id = p.barcode_id
+		LEFT JOIN suppliers sp ON sp.id = p.supplier_id AND sp.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL AND p.min_stock > 0 AND COALESCE(s.current_stock, 0) <= p.min_stock
+		ORDER BY p.supplier_id, p.barcode_id`, storeId, storeId, constant.MovementSale, salesSince).Scan(&candidates).Error
+	if err != nil {
+		return nil, dto.ErrISEStock
+	}
+	return candidates, nil
+}
+
+func (s *stockRepository) ReceiveStockRepository(ctx context.Context, batch *entity.StockBatch, reference string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkStore(tx, batch.StoreId); err != nil {
+			return err
+		}
+		if err := lockProduct(tx, batch.BarcodeId); err != nil {
+			return err
+		}
+		if err := tx.Create(batch).Error; err != nil {
+			return dto.ErrISEStock
+		}
+		movement := entity.StockMovement{
+			BarcodeId: batch.BarcodeId,
+			Quantity:  batch.ReceivedQuantity,
+			Type:      constant.MovementReceipt,
+			Reference: reference,
+			BatchId:   &batch.ID,
+			StoreId:   batch.StoreId,
+		}
+		if err := tx.Create(&movement).Error; err != nil {
+			return dto.ErrISEStock
+		}
+		return nil
+	})
+}
+
+// RetrieveExpiringBatchesRepository lists batches of every store, or only of
+// storeId when it is set.
+func (s *stockRepository) RetrieveExpiringBatchesRepository(ctx context.Context, storeId uint, withinDays uint16) ([]dto.ExpiringBatch, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var batches []dto.ExpiringBatch
+	err := s.db.WithContext(ctx).Raw(`SELECT b.id AS batch_id, b.store_id, b.barcode_id, COALESCE(p.title, '') AS title, b.batch_number, b.expiry_date, b.remaining_quantity, b.expiry_date < CURRENT_DATE AS expired
+		FROM stock_batches b
+		LEFT JOIN products p ON p.barcode_id = b.barcode_id AND p.deleted_at IS NULL
+		WHERE b.deleted_at IS NULL AND (? = 0 OR b.store_id = ?) AND b.remaining_quantity > 0 AND b.expiry_date IS NOT NULL AND b.expiry_date <= CURRENT_DATE + ?::int
+		ORDER BY b.expiry_date, b.id`, storeId, storeId, withinDays).Scan(&batches).Error
+	if err != nil {
+		return nil, dto.ErrISEStock
+	}
+	return batches, nil
+}
+
+func (s *stockRepository) WriteOffBatchRepository(ctx context.Context, batchId uint, note string) (entity.StockBatch, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var batch entity.StockBatch
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", batchId).First(&batch).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrBatchNotFound
+		} else if err != nil {
+			return dto.ErrISEStock
+		}
+		// batches are only ever changed while holding the product lock, so the
+		// batch has to be read again once the lock is taken
+		if err := lockProduct(tx, batch.BarcodeId); err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", batchId).First(&batch).Error; err != nil {
+			return dto.ErrISEStock
+		}
+		if batch.RemainingQuantity <= 0 {
+			return dto.ErrBatchEmpty
+		}
+		// A batch can hold more than the store has on hand, e.g. after sales
+		// of stock never received through a batch, so only what is left of
+		// the product is written off.
+		currentStock, err := productStock(tx, batch.StoreId, batch.BarcodeId)
+		if err != nil {
+			return err
+		}
+		if quantity := min(batch.RemainingQuantity, currentStock); quantity > 0 {
+			movement := entity.StockMovement{
+				BarcodeId: batch.BarcodeId,
+				Quantity:  -quantity,
+				Type:      constant.MovementWriteOff,
+				Reference: fmt.Sprintf("batch-%d", batch.ID),
+				Note:      note,
+				BatchId:   &batch.ID,
+				StoreId:   batch.StoreId,
+			}
+			if err := tx.Create(&movement).Error; err != nil {
+				return dto.ErrISEStock
+			}
+		}
+		if err := tx.Model(&batch).Update("remaining_quantity", 0).Error; err != nil {
+			return dto.ErrISEStock
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.StockBatch{}, err
+	}
+	return batch, nil
+}
+
+func (s *stockRepository) RetrieveStockLevelsRepository(ctx context.Context, barcodeId string) ([]dto.StoreStockLevel, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var levels []dto.StoreStockLevel
+	err := s.db.WithContext(ctx).Raw(`SELECT st.id AS store_id, st.name AS store_name, COALESCE(s.current_stock, 0) AS current_stock, COALESCE(tr.in_transit, 0) AS in_transit
+		FROM stores st
+		LEFT JOIN (SELECT store_id, SUM(quantity) AS current_stock FROM stock_movements WHERE barcode_id = ? AND deleted_at IS NULL GROUP BY store_id) s ON s.store_id = st.id
+		LEFT JOIN (SELECT t.to_store_id, SUM(i.quantity) AS in_transit
+			FROM stock_transfers t JOIN stock_transfer_items i ON i.transfer_id = t.id AND i.deleted_at IS NULL
+			WHERE t.status = ? AND i.barcode_id = ? AND t.deleted_at IS NULL GROUP BY t.to_store_id) tr ON tr.to_store_id = st.id
+		WHERE st.deleted_at IS NULL
+		ORDER BY st.id`, barcodeId, constant.TransferInTransit, barcodeId).Scan(&levels).Error
+	if err != nil {
+		return nil, dto.ErrISEStock
+	}
+	return levels, nil
+}
+
+// lockProduct serializes every stock and image change of a product behind its
+// row lock.
+func lockProduct(tx *gorm.DB, barcodeId string) error {
+	var product entity.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("barcode_id = ?", barcodeId).First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.ErrProductDoesntExist
+	} else if err != nil {
+		return dto.ErrISEStock
+	}
+	return nil
+}
+
+// productStock is the stock of a product in one store.
+func productStock(tx *gorm.DB, storeId uint, barcodeId string) (int64, error) {
+	var currentStock int64
+	err := tx.Model(&entity.StockMovement{}).Where("store_id = ? AND barcode_id = ?", storeId, barcodeId).Select("COALESCE(SUM(quantity), 0)").Scan(&currentStock).Error
+	if err != nil {
+		return 0, dto.ErrISEStock
+	}
+	return currentStock, nil
+}
+
+// depleteStock takes quantity out of the stock of a product in one store
+// first-expiry-first-out and returns the movements it booked. Expired batches are
+// skipped unless allowExpired is set, in which case they go first. Stock that was
+// never received through a batch is used after batches.
+func depleteStock(tx *gorm.DB, storeId uint, barcodeId string, quantity int64, allowExpired bool, movementType, reference, note string) ([]entity.StockMovement, error) {
+	if err := lockProduct(tx, barcodeId); err != nil {
+		return nil, err
+	}
+
+	currentStock, err := productStock(tx, storeId, barcodeId)
+	if err != nil {
+		return nil, err
+	}
+	if currentStock < quantity {
+		return nil, dto.ErrInsufficientStock
+	}
+
+	var batches []batchAllocation
+	err = tx.Model(&entity.StockBatch{}).
+		Select("id, remaining_quantity, COALESCE(expiry_date < CURRENT_DATE, false) AS expired").
+		Where("store_id = ? AND barcode_id = ? AND remaining_quantity > 0", storeId, barcodeId).
+		Order("expiry_date ASC NULLS LAST, id").
+		Scan(&batches).Error
+	if err != nil {
+		return nil, dto.ErrISEStock
+	}
+
+	var expired, fresh []batchAllocation
+	untracked := currentStock
+	for _, batch := range batches {
+		untracked -= batch.RemainingQuantity
+		if batch.Expired {
+			expired = append(expired, batch)
+		} else {
+			fresh = append(fresh, batch)
+		}
+	}
+	allocatable := fresh
+	if allowExpired {
+		allocatable = append(expired, fresh...)
+	}
+
+	remaining := quantity
+	var movements []entity.StockMovement
+	for _, batch := range allocatable {
+		if remaining == 0 {
+			break
+		}
+		take := min(batch.RemainingQuantity, remaining)
+		err := tx.Model(&entity.StockBatch{}).Where("id = ?", batch.ID).
+			Update("remaining_quantity", gorm.Expr("remaining_quantity - ?", take)).Error
+		if err != nil {
+			return nil, dto.ErrISEStock
+		}
+		batchId := batch.ID
+		movements = append(movements, entity.StockMovement{
+			BarcodeId: barcodeId,
+			Quantity:  -take,
+			Type:      movementType,
+			Reference: reference,
+			Note:      note,
+			BatchId:   &batchId,
+			StoreId:   storeId,
+		})
+		remaining -= take
+	}
+	if remaining > 0 && untracked > 0 {
+		take := min(untracked, remaining)
+		movements = append(movements, entity.StockMovement{
+			BarcodeId: barcodeId,
+			Quantity:  -take,
+			Type:      movementType,
+			Reference: reference,
+			Note:      note,
+			StoreId:   storeId,
+		})
+		remaining -= take
+	}
+	if remaining > 0 {
+		if len(expired) > 0 && !allowExpired {
+			return nil, dto.ErrExpiredBatch
+		}
+		return nil, dto.ErrInsufficientStock
+	}
+
+	if err := tx.Create(&movements).Error; err != nil {
+		return nil, dto.ErrISEStock
+	}
+	return movements, nil
+}
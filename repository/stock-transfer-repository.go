@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	StockTransferRepository interface {
+		CreateTransferRepository(ctx context.Context, transfer *entity.StockTransfer, quantities map[string]int64) error
+		RetrieveTransfersRepository(ctx context.Context, status string, storeId uint) ([]entity.StockTransfer, error)
+		RetrieveTransferByIdRepository(ctx context.Context, transferId uint) (entity.StockTransfer, bool)
+		ReceiveTransferRepository(ctx context.Context, transferId uint, receivedBy string) (entity.StockTransfer, error)
+	}
+	stockTransferRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewStockTransferRepository(db *gorm.DB, cfg *config.Config) StockTransferRepository {
+	return &stockTransferRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+// CreateTransferRepository takes the goods out of the source store right away, so
+// they are neither sellable there nor in the destination while in transit. Every
+// batch the goods were taken from becomes its own item, which lets the
+// destination keep the expiry dates on receipt.
+func (s *stockTransferRepository) CreateTransferRepository(ctx context.Context, transfer *entity.StockTransfer, quantities map[string]int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkStore(tx, transfer.FromStoreId); err != nil {
+			return err
+		}
+		if err := checkStore(tx, transfer.ToStoreId); err != nil {
+			return err
+		}
+		if err := tx.Omit("Items").Create(transfer).Error; err != nil {
+			return dto.ErrISEStockTransfer
+		}
+
+		reference := fmt.Sprintf("transfer-%d", transfer.ID)
+		var items []entity.StockTransferItem
+		for _, barcodeId := range sortedBarcodes(quantities) {
+			movements, err := depleteStock(tx, transfer.FromStoreId, barcodeId, quantities[barcodeId], false, constant.MovementTransferOut, reference, "")
+			if err != nil {
+				return err
+			}
+			for _, movement := range movements {
+				items = append(items, entity.StockTransferItem{
+					TransferId:    transfer.ID,
+					BarcodeId:     barcodeId,
+					Quantity:      -movement.Quantity,
+					SourceBatchId: movement.BatchId,
+				})
+			}
+		}
+		if err := tx.Create(&items).Error; err != nil {
+			return dto.ErrISEStockTransfer
+		}
+		transfer.Items = items
+		return nil
+	})
+}
+
+func (s *stockTransferRepository) RetrieveTransfersRepository(ctx context.Context, status string, storeId uint) ([]entity.StockTransfer, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	query := s.db.WithContext(ctx).Preload("Items")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if storeId != 0 {
+		query = query.Where("from_store_id = ? OR to_store_id = ?", storeId, storeId)
+	}
+	var transfers []entity.StockTransfer
+	if err := query.Order("id DESC").Find(&transfers).Error; err != nil {
+		return nil, dto.ErrISEStockTransfer
+	}
+	return transfers, nil
+}
+
+func (s *stockTransferRepository) RetrieveTransferByIdRepository(ctx context.Context, transferId uint) (entity.StockTransfer, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var transfer entity.StockTransfer
+	err := s.db.WithContext(ctx).Preload("Items").Where("id = ?", transferId).First(&transfer).Error
+	if err != nil {
+		return entity.StockTransfer{}, false
+	}
+	return transfer, true
+}
+
+func (s *stockTransferRepository) ReceiveTransferRepository(ctx context.Context, transferId uint, receivedBy string) (entity.StockTransfer, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var transfer entity.StockTransfer
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", transferId).First(&transfer).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrTransferNotFound
+		} else if err != nil {
+			return dto.ErrISEStockTransfer
+		}
+		if transfer.Status != constant.TransferInTransit {
+			return dto.ErrTransferNotInTransit
+		}
+		if err := tx.Where("transfer_id = ?", transferId).Order("barcode_id, id").Find(&transfer.Items).Error; err != nil {
+			return dto.ErrISEStockTransfer
+		}
+
+		reference := fmt.Sprintf("transfer-%d", transfer.ID)
+		locked := make(map[string]bool)
+		for _, item := range transfer.Items {
+			if !locked[item.BarcodeId] {
+				if err := lockProduct(tx, item.BarcodeId); err != nil {
+					return err
+				}
+				locked[item.BarcodeId] = true
+			}
+			movement := entity.StockMovement{
+				BarcodeId: item.BarcodeId,
+				Quantity:  item.Quantity,
+				Type:      constant.MovementTransferIn,
+				Reference: reference,
+				StoreId:   transfer.ToStoreId,
+			}
+			if item.SourceBatchId != nil {
+				var source entity.StockBatch
+				if err := tx.Unscoped().Where("id = ?", *item.SourceBatchId).First(&source).Error; err != nil {
+					return dto.ErrISEStockTransfer
+				}
+				batch := entity.StockBatch{
+					BarcodeId:         item.BarcodeId,
+					BatchNumber:       source.BatchNumber,
+					ExpiryDate:        source.ExpiryDate,
+					ReceivedQuantity:  item.Quantity,
+					RemainingQuantity: item.Quantity,
+					StoreId:           transfer.ToStoreId,
+				}
+				if err := tx.Create(&batch).Error; err != nil {
+					return dto.ErrISEStockTransfer
+				}
+				movement.BatchId = &batch.ID
+			}
+			if err := tx.Create(&movement).Error; err != nil {
+				return dto.ErrISEStockTransfer
+			}
+		}
+
+		now := time.Now()
+		err = tx.Model(&entity.StockTransfer{}).Where("id = ?", transferId).Updates(map[string]interface{}{
+			"status":      constant.TransferReceived,
+			"received_by": receivedBy,
+			"received_at": now,
+		}).Error
+		if err != nil {
+			return dto.ErrISEStockTransfer
+		}
+		transfer.Status = constant.TransferReceived
+		transfer.ReceivedBy = receivedBy
+		transfer.ReceivedAt = &now
+		return nil
+	})
+	if err != nil {
+		return entity.StockTransfer{}, err
+	}
+	return transfer, nil
+}
+
+// sortedBarcodes keeps the order product rows get locked in stable, so two
+// transfers of the same products can't deadlock each other.
+func sortedBarcodes(quantities map[string]int64) []string {
+	barcodeIds := make([]string, 0, len(quantities))
+	for barcodeId := range quantities {
+		barcodeIds = append(barcodeIds, barcodeId)
+	}
+	sort.Strings(barcodeIds)
+	return barcodeIds
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	StoreRepository interface {
+		RetrieveStoresRepository(ctx context.Context) ([]entity.Store, error)
+		RetrieveStoreByIdRepository(ctx context.Context, storeId uint) (entity.Store, bool)
+		RetrieveStoreByCodeRepository(ctx context.Context, code string) (entity.Store, bool)
+		CreateStoreRepository(ctx context.Context, store *entity.Store) error
+		UpdateStoreRepository(ctx context.Context, storeId uint, store *map[string]interface{}) error
+		RetrieveStorePricesRepository(ctx context.Context, storeId uint) ([]dto.StorePrice, error)
+		UpsertStorePriceRepository(ctx context.Context, price *entity.StorePrice) error
+		DeleteStorePriceRepository(ctx context.Context, storeId uint, barcodeId string) error
+	}
+	storeRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewStoreRepository(db *gorm.DB, cfg *config.Config) StoreRepository {
+	return &storeRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+func (s *storeRepository) RetrieveStoresRepository(ctx context.Context) ([]entity.Store, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var stores []entity.Store
+	if err := s.db.WithContext(ctx).Order("id").Find(&stores).Error; err != nil {
+		return []entity.Store{}, dto.ErrISEStore
+	}
+	return stores, nil
+}
+
+func (s *storeRepository) RetrieveStoreByIdRepository(ctx context.Context, storeId uint) (entity.Store, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var store entity.Store
+	if err := s.db.WithContext(ctx).Where("id = ?", storeId).First(&store).Error; err != nil {
+		return entity.Store{}, false
+	}
+	return store, true
+}
+
+func (s *storeRepository) RetrieveStoreByCodeRepository(ctx context.Context, code string) (entity.Store, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var store entity.Store
+	if err := s.db.WithContext(ctx).Unscoped().Where("code = ?", code).First(&store).Error; err != nil {
+		return entity.Store{}, false
+	}
+	return store, true
+}
+
+func (s *storeRepository) CreateStoreRepository(ctx context.Context, store *entity.Store) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	if err := s.db.WithContext(ctx).Create(store).Error; err != nil {
+		return dto.ErrISEStore
+	}
+	return nil
+}
+
+func (s *storeRepository) UpdateStoreRepository(ctx context.Context, storeId uint, store *map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	err := s.db.WithContext(ctx).Model(&entity.Store{}).Where("id = ?", storeId).Updates(*store).Error
+	if err != nil {
+		return dto.ErrISEStore
+	}
+	return nil
+}
+
+func (s *storeRepository) RetrieveStorePricesRepository(ctx context.Context, storeId uint) ([]dto.StorePrice, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var prices []dto.StorePrice
+	err := s.db.WithContext(ctx).Raw(`SELECT sp.store_id, sp.barcode_id, p.title, p.price AS base_price, sp.price
+		FROM store_prices sp
+		JOIN products p ON p.barcode_id = sp.barcode_id AND p.deleted_at IS NULL
+		WHERE sp.store_id = ? AND sp.deleted_at IS NULL
+		ORDER BY p.title`, storeId).Scan(&prices).Error
+	if err != nil {
+		return nil, dto.ErrISEStore
+	}
+	return prices, nil
+}
+
+func (s *storeRepository) UpsertStorePriceRepository(ctx context.Context, price *entity.StorePrice) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "store_id"}, {Name: "barcode_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"price", "updated_at"}),
+		}).Create(price).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&entity.StorePriceHistory{StoreId: price.StoreId, BarcodeId: price.BarcodeId, Price: decimal.NewNullDecimal(price.Price)}).Error
+	})
+	if err != nil {
+		return dto.ErrISEStore
+	}
+	return nil
+}
+
+func (s *storeRepository) DeleteStorePriceRepository(ctx context.Context, storeId uint, barcodeId string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// overrides are removed for good so the unique index stays free for a new one
+		result := tx.Unscoped().Where("store_id = ? AND barcode_id = ?", storeId, barcodeId).Delete(&entity.StorePrice{})
+		if result.Error != nil {
+			return dto.ErrISEStore
+		}
+		if result.RowsAffected == 0 {
+			return dto.ErrStorePriceNotFound
+		}
+		if err := tx.Create(&entity.StorePriceHistory{StoreId: storeId, BarcodeId: barcodeId}).Error; err != nil {
+			return dto.ErrISEStore
+		}
+		return nil
+	})
+}
+
+// checkStore makes sure stock is only ever booked against an existing store.
+func checkStore(tx *gorm.DB, storeId uint) error {
+	var store entity.Store
+	err := tx.Select("id").Where("id = ?", storeId).First(&store).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.ErrStoreDoesntExist
+	} else if err != nil {
+		return dto.ErrISEStore
+	}
+	return nil
+}
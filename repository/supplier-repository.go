@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+
+	"gorm.io/gorm"
+)
+
+type (
+	SupplierRepository interface {
+		RetrieveSuppliersRepository(ctx context.Context) ([]entity.Supplier, error)
+		RetrieveSupplierByIdRepository(ctx context.Context, supplierId uint) (entity.Supplier, bool)
+		CreateSupplierRepository(ctx context.Context, supplier *entity.Supplier) error
+		UpdateSupplierRepository(ctx context.Context, supplierId uint, supplier *map[string]interface{}) error
+	}
+	supplierRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewSupplierRepository(db *gorm.DB, cfg *config.Config) SupplierRepository {
+	return &supplierRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+func (s *supplierRepository) RetrieveSuppliersRepository(ctx context.Context) ([]entity.Supplier, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var suppliers []entity.Supplier
+	if err := s.db.WithContext(ctx).Order("name").Find(&suppliers).Error; err != nil {
+		return []entity.Supplier{}, dto.ErrISESupplier
+	}
+	return suppliers, nil
+}
+
+func (s *supplierRepository) RetrieveSupplierByIdRepository(ctx context.Context, supplierId uint) (entity.Supplier, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var supplier entity.Supplier
+	if err := s.db.WithContext(ctx).Where("id = ?", supplierId).First(&supplier).Error; err != nil {
+		return entity.Supplier{}, false
+	}
+	return supplier, true
+}
+
+func (s *supplierRepository) CreateSupplierRepository(ctx context.Context, supplier *entity.Supplier) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	if err := s.db.WithContext(ctx).Create(supplier).Error; err != nil {
+		return dto.ErrISESupplier
+	}
+	return nil
+}
+
+func (s *supplierRepository) UpdateSupplierRepository(ctx context.Context, supplierId uint, supplier *map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	err := s.db.WithContext(ctx).Model(&entity.Supplier{}).Where("id = ?", supplierId).Updates(*supplier).Error
+	if err != nil {
+		return dto.ErrISESupplier
+	}
+	return nil
+}
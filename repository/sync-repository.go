@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type (
+	SyncRepository interface {
+		RetrieveProductChangesRepository(ctx context.Context, after *utils.Cursor, upTo time.Time, limit int) ([]entity.Product, *utils.Cursor, error)
+	}
+	syncRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewSyncRepository(db *gorm.DB, cfg *config.Config) SyncRepository {
+	return &syncRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+type productChange struct {
+	entity.Product
+	ChangedAt time.Time
+}
+
+// RetrieveProductChangesRepository returns products created, updated or
+// deleted after the cursor and no later than upTo, oldest change first, with
+// the position of the last one.
+func (s *syncRepository) RetrieveProductChangesRepository(ctx context.Context, after *utils.Cursor, upTo time.Time, limit int) ([]entity.Product, *utils.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var changes []productChange
+	err := s.db.WithContext(ctx).Unscoped().Model(&entity.Product{}).
+		Select("products.*, "+constant.ProductChangedAt+" AS changed_at").
+		Where(constant.ProductChangedAt+" <= ?", upTo).
+		Scopes(utils.Keyset(constant.ProductChangedAt, "products.id", false, after, limit)).
+		Find(&changes).Error
+	if err != nil {
+		return nil, nil, dto.ErrISESync
+	}
+	if len(changes) == 0 {
+		return []entity.Product{}, nil, nil
+	}
+	products := make([]entity.Product, len(changes))
+	for i, change := range changes {
+		products[i] = change.Product
+	}
+	last := changes[len(changes)-1]
+	return products, &utils.Cursor{Value: last.ChangedAt.UTC().Format(time.RFC3339Nano), Id: last.ID}, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	TransactionRepository interface {
+		CreateTransactionRepository(ctx context.Context, transaction *entity.Transaction, allowExpired bool) error
+		RetrieveTransactionByIdRepository(ctx context.Context, transactionId uint) (entity.Transaction, bool)
+		CreateOfflineTransactionRepository(ctx context.Context, transaction *entity.Transaction, conflicts []entity.SaleConflict) ([]entity.SaleConflict, error)
+		RetrieveSaleConflictsRepository(ctx context.Context, query dto.SaleConflictQuery) ([]entity.SaleConflict, error)
+		ResolveSaleConflictRepository(ctx context.Context, conflictId uint, resolvedBy string) (entity.SaleConflict, error)
+	}
+	transactionRepository struct {
+		db       *gorm.DB
+		timeouts config.TimeoutsConfig
+	}
+)
+
+func NewTransactionRepository(db *gorm.DB, cfg *config.Config) TransactionRepository {
+	return &transactionRepository{db: db, timeouts: cfg.Database.Timeouts}
+}
+
+func (t *transactionRepository) CreateTransactionRepository(ctx context.Context, transaction *entity.Transaction, allowExpired bool) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkStore(tx, transaction.StoreId); err != nil {
+			return err
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			return dto.ErrToCreateTransaction
+		}
+		reference := fmt.Sprintf("transaction-%d", transaction.ID)
+		for _, item := range transaction.Items {
+			if _, err := depleteStock(tx, transaction.StoreId, item.BarcodeId, item.Quantity, allowExpired, constant.MovementSale, reference, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (t *transactionRepository) RetrieveTransactionByIdRepository(ctx context.Context, transactionId uint) (entity.Transaction, bool) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	var transaction entity.Transaction
+	err := t.db.WithContext(ctx).Preload("Items").Where("id = ?", transactionId).First(&transaction).Error
+	if err != nil {
+		return entity.Transaction{}, false
+	}
+	return transaction, true
+}
+
+// CreateOfflineTransactionRepository stores a sale a till recorded while it was
+// offline. The sale already happened, so stock that can't be booked doesn't
+// fail it: the item is recorded next to the conflicts passed in for a
+// supervisor to review. A client id that was uploaded before returns
+// ErrTransactionUploaded with the stored transaction id.
+func (t *transactionRepository) CreateOfflineTransactionRepository(ctx context.Context, transaction *entity.Transaction, conflicts []entity.SaleConflict) ([]entity.SaleConflict, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkStore(tx, transaction.StoreId); err != nil {
+			return err
+		}
+		var existing entity.Transaction
+		if err := tx.Select("id").Where("client_id = ?", transaction.ClientId).Limit(1).Find(&existing).Error; err != nil {
+			return dto.ErrToCreateTransaction
+		}
+		if existing.ID != 0 {
+			transaction.ID = existing.ID
+			return dto.ErrTransactionUploaded
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			if isUniqueViolation(err, constant.TransactionClientIdIndex) {
+				return dto.ErrTransactionUploaded
+			}
+			return dto.ErrToCreateTransaction
+		}
+
+		flagged := make(map[string]bool)
+		for _, conflict := range conflicts {
+			if conflict.Type != constant.ConflictPriceMismatch {
+				flagged[conflict.BarcodeId] = true
+			}
+		}
+		reference := fmt.Sprintf("transaction-%d", transaction.ID)
+		for _, item := range transaction.Items {
+			if flagged[item.BarcodeId] {
+				continue
+			}
+			err := tx.Transaction(func(sp *gorm.DB) error {
+				_, err := depleteStock(sp, transaction.StoreId, item.BarcodeId, item.Quantity, false, constant.MovementSale, reference, "")
+				return err
+			})
+			switch err {
+			case nil:
+			case dto.ErrInsufficientStock:
+				conflicts = append(conflicts, entity.SaleConflict{BarcodeId: item.BarcodeId, Type: constant.ConflictInsufficientStock, Quantity: item.Quantity, Detail: err.Error()})
+			case dto.ErrExpiredBatch:
+				conflicts = append(conflicts, entity.SaleConflict{BarcodeId: item.BarcodeId, Type: constant.ConflictExpiredStock, Quantity: item.Quantity, Detail: err.Error()})
+			default:
+				return err
+			}
+		}
+		if len(conflicts) == 0 {
+			return nil
+		}
+		for i := range conflicts {
+			conflicts[i].TransactionId = transaction.ID
+		}
+		if err := tx.Create(&conflicts).Error; err != nil {
+			return dto.ErrISESaleConflicts
+		}
+		return nil
+	})
+	if err == dto.ErrTransactionUploaded && transaction.ID == 0 {
+		// The same sale was uploaded concurrently and committed first.
+		var existing entity.Transaction
+		if err := t.db.WithContext(ctx).Select("id").Where("client_id = ?", transaction.ClientId).First(&existing).Error; err != nil {
+			return nil, dto.ErrToCreateTransaction
+		}
+		transaction.ID = existing.ID
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == constant.PgUniqueViolation && pgErr.ConstraintName == index
+}
+
+func (t *transactionRepository) RetrieveSaleConflictsRepository(ctx context.Context, query dto.SaleConflictQuery) ([]entity.SaleConflict, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	db := t.db.WithContext(ctx).Model(&entity.SaleConflict{})
+	if query.StoreId != 0 {
+		db = db.Joins("JOIN transactions ON transactions.id = sale_conflicts.transaction_id").Where("transactions.store_id = ?", query.StoreId)
+	}
+	if query.Resolved {
+		db = db.Where("sale_conflicts.resolved_at IS NOT NULL")
+	} else {
+		db = db.Where("sale_conflicts.resolved_at IS NULL")
+	}
+	var conflicts []entity.SaleConflict
+	if err := db.Order("sale_conflicts.id").Find(&conflicts).Error; err != nil {
+		return nil, dto.ErrISESaleConflicts
+	}
+	return conflicts, nil
+}
+
+func (t *transactionRepository) ResolveSaleConflictRepository(ctx context.Context, conflictId uint, resolvedBy string) (entity.SaleConflict, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	var conflict entity.SaleConflict
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", conflictId).First(&conflict).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrSaleConflictNotFound
+		} else if err != nil {
+			return dto.ErrISESaleConflicts
+		}
+		if conflict.ResolvedAt != nil {
+			return dto.ErrSaleConflictResolved
+		}
+		now := time.Now()
+		conflict.ResolvedBy = resolvedBy
+		conflict.ResolvedAt = &now
+		err = tx.Model(&conflict).Updates(map[string]interface{}{"resolved_by": resolvedBy, "resolved_at": now}).Error
+		if err != nil {
+			return dto.ErrISESaleConflicts
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.SaleConflict{}, err
+	}
+	return conflict, nil
+}
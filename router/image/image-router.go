@@ -0,0 +1,16 @@
+package image
+
+import (
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ImageRouter(router *gin.RouterGroup, ic controller.ImageController) {
+	imageRoutes := router.Group(constant.ImageURLPath)
+	{
+		imageRoutes.GET("/:filename", ic.GetImage)
+		imageRoutes.HEAD("/:filename", ic.GetImage)
+	}
+}
@@ -0,0 +1,18 @@
+package product
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ProductImageRouter(router *gin.RouterGroup, pic controller.ProductImageController) {
+	imageRoutes := router.Group("/product/:barcode_id/images")
+	{
+		imageRoutes.GET("", pic.GetProductImages)
+		imageRoutes.POST("", pic.AddProductImage)
+		imageRoutes.PUT("/order", pic.ReorderProductImages)
+		imageRoutes.POST("/:image_id/primary", pic.SetPrimaryProductImage)
+		imageRoutes.DELETE("/:image_id", pic.DeleteProductImage)
+	}
+}
@@ -12,7 +12,11 @@ func ProductRouter(router *gin.RouterGroup, pc controller.ProductController) {
 		productRoutes.GET("", pc.GetProduct)
 		productRoutes.GET("/:barcode_id", pc.GetProductDetail) //get product detail
 		productRoutes.GET("/search", pc.SearchProduct)
+		productRoutes.GET("/autocomplete", pc.AutocompleteProduct)
+		productRoutes.GET("/export", pc.ExportProduct)
 		productRoutes.POST("", pc.AddProduct)
+		productRoutes.POST("/import", pc.ImportProduct)
+		productRoutes.POST("/price-adjustment", pc.AdjustPrices)
 		productRoutes.PATCH("/:barcode_id", pc.UpdateProduct)
 		productRoutes.DELETE("/:barcode_id", pc.DeleteProduct)
 	}
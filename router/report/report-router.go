@@ -0,0 +1,20 @@
+package report
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ReportRouter(router *gin.RouterGroup, rc controller.ReportController) {
+	reportRoutes := router.Group("/report")
+	{
+		reportRoutes.GET("/sales", rc.GetSalesSummary)
+		reportRoutes.GET("/sales/hourly", rc.GetHourlySales)
+		reportRoutes.GET("/sales/cashier", rc.GetCashierSales)
+		reportRoutes.GET("/sales/payment-method", rc.GetPaymentMethodSales)
+		reportRoutes.GET("/sales/category", rc.GetCategorySales)
+		reportRoutes.GET("/product/top", rc.GetTopProducts)
+		reportRoutes.GET("/stock/valuation", rc.GetStockValuation)
+	}
+}
@@ -1,33 +1,67 @@
 package router
 
 import (
-	"os"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
 	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/middleware"
+	"tiga-putra-cashier-be/router/image"
 	"tiga-putra-cashier-be/router/product"
+	"tiga-putra-cashier-be/router/report"
+	"tiga-putra-cashier-be/router/stock"
+	"tiga-putra-cashier-be/router/store"
+	"tiga-putra-cashier-be/router/supplier"
+	"tiga-putra-cashier-be/router/sync"
+	"tiga-putra-cashier-be/router/transaction"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func AppRouter(r *gin.Engine, pc controller.ProductController) *gin.Engine {
-	if os.Getenv("APP_ENV") == "production" {
+func AppRouter(
+	r *gin.Engine,
+	cfg *config.Config,
+	pc controller.ProductController,
+	pic controller.ProductImageController,
+	scc controller.StockCountController,
+	sc controller.StockController,
+	spc controller.SupplierController,
+	tc controller.TransactionController,
+	stc controller.StoreController,
+	sttc controller.StockTransferController,
+	rc controller.ReportController,
+	syc controller.SyncController,
+	ic controller.ImageController,
+	im *middleware.IdempotencyMiddleware,
+) *gin.Engine {
+	if cfg.App.Env == constant.AppEnvProduction {
 		gin.SetMode(gin.ReleaseMode)
-	} else if os.Getenv("APP_ENV") == "development" {
+	} else if cfg.App.Env == constant.AppEnvDevelopment {
 		gin.SetMode(gin.DebugMode)
-	} else if os.Getenv("APP_ENV") == "TEST" {
+	} else if cfg.App.Env == constant.AppEnvTest {
 		gin.SetMode(gin.TestMode)
 	}
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "DELETE", "PATCH", "OPTIONS"},
+		AllowOrigins:     cfg.Server.CORSAllowOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
 		AllowHeaders:     []string{"*"},
 		ExposeHeaders:    []string{"*"},
 		AllowCredentials: true,
 	}))
-	r.Static("/assets/image", "./assets/image")
+	image.ImageRouter(&r.RouterGroup, ic)
 	v1 := r.Group("/v1")
+	v1.Use(im.Handle)
 	{
 		product.ProductRouter(v1, pc)
+		product.ProductImageRouter(v1, pic)
+		stock.StockCountRouter(v1, scc)
+		stock.StockRouter(v1, sc)
+		supplier.SupplierRouter(v1, spc)
+		transaction.TransactionRouter(v1, tc)
+		store.StoreRouter(v1, stc)
+		stock.StockTransferRouter(v1, sttc)
+		report.ReportRouter(v1, rc)
+		sync.SyncRouter(v1, syc)
 	}
 	return r
 }
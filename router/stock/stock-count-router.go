@@ -0,0 +1,18 @@
+package stock
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func StockCountRouter(router *gin.RouterGroup, scc controller.StockCountController) {
+	stockCountRoutes := router.Group("/stock-count")
+	{
+		stockCountRoutes.POST("", scc.StartStockCount)
+		stockCountRoutes.GET("/:session_id", scc.GetStockCountReport)
+		stockCountRoutes.GET("/:session_id/uncounted", scc.GetUncountedProducts)
+		stockCountRoutes.POST("/:session_id/count", scc.SubmitStockCount)
+		stockCountRoutes.POST("/:session_id/approve", scc.ApproveStockCount)
+	}
+}
@@ -0,0 +1,19 @@
+package stock
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func StockRouter(router *gin.RouterGroup, sc controller.StockController) {
+	stockRoutes := router.Group("/stock")
+	{
+		stockRoutes.GET("/low", sc.GetLowStockProducts)
+		stockRoutes.GET("/reorder-suggestion", sc.GetReorderSuggestion)
+		stockRoutes.POST("/receipt", sc.ReceiveStock)
+		stockRoutes.GET("/batch/expiring", sc.GetExpiringBatches)
+		stockRoutes.POST("/batch/:batch_id/write-off", sc.WriteOffBatch)
+		stockRoutes.GET("/level/:barcode_id", sc.GetStockLevels)
+	}
+}
@@ -0,0 +1,17 @@
+package stock
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func StockTransferRouter(router *gin.RouterGroup, stc controller.StockTransferController) {
+	stockTransferRoutes := router.Group("/stock-transfer")
+	{
+		stockTransferRoutes.GET("", stc.GetStockTransfers)
+		stockTransferRoutes.POST("", stc.CreateStockTransfer)
+		stockTransferRoutes.GET("/:transfer_id", stc.GetStockTransferDetail)
+		stockTransferRoutes.POST("/:transfer_id/receive", stc.ReceiveStockTransfer)
+	}
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func StoreRouter(router *gin.RouterGroup, stc controller.StoreController) {
+	storeRoutes := router.Group("/store")
+	{
+		storeRoutes.GET("", stc.GetStores)
+		storeRoutes.POST("", stc.AddStore)
+		storeRoutes.PATCH("/:store_id", stc.UpdateStore)
+		storeRoutes.GET("/:store_id/price", stc.GetStorePrices)
+		storeRoutes.PUT("/:store_id/price/:barcode_id", stc.SetStorePrice)
+		storeRoutes.DELETE("/:store_id/price/:barcode_id", stc.DeleteStorePrice)
+	}
+}
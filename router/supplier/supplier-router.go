@@ -0,0 +1,16 @@
+package supplier
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SupplierRouter(router *gin.RouterGroup, sc controller.SupplierController) {
+	supplierRoutes := router.Group("/supplier")
+	{
+		supplierRoutes.GET("", sc.GetSuppliers)
+		supplierRoutes.POST("", sc.AddSupplier)
+		supplierRoutes.PATCH("/:supplier_id", sc.UpdateSupplier)
+	}
+}
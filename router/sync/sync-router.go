@@ -0,0 +1,14 @@
+package sync
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SyncRouter(router *gin.RouterGroup, syc controller.SyncController) {
+	syncRoutes := router.Group("/sync")
+	{
+		syncRoutes.GET("/products", syc.SyncProducts)
+	}
+}
@@ -0,0 +1,18 @@
+package transaction
+
+import (
+	"tiga-putra-cashier-be/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TransactionRouter(router *gin.RouterGroup, tc controller.TransactionController) {
+	transactionRoutes := router.Group("/transaction")
+	{
+		transactionRoutes.POST("", tc.CreateTransaction)
+		transactionRoutes.POST("/offline", tc.UploadOfflineSales)
+		transactionRoutes.GET("/conflicts", tc.GetSaleConflicts)
+		transactionRoutes.POST("/conflicts/:conflict_id/resolve", tc.ResolveSaleConflict)
+		transactionRoutes.GET("/:transaction_id", tc.GetTransactionDetail)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"mime/multipart"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"tiga-putra-cashier-be/utils"
+)
+
+type (
+	ProductImageService interface {
+		GetProductImagesService(ctx context.Context, barcodeId string) ([]dto.ProductImageResponse, error)
+		AddProductImageService(ctx context.Context, barcodeId string, image *multipart.FileHeader) (dto.ProductImageResponse, error)
+		ReorderProductImagesService(ctx context.Context, barcodeId string, imageIds []uint) ([]dto.ProductImageResponse, error)
+		SetPrimaryProductImageService(ctx context.Context, barcodeId string, imageId uint) error
+		DeleteProductImageService(ctx context.Context, barcodeId string, imageId uint) error
+	}
+	productImageService struct {
+		productImageRepository repository.ProductImageRepository
+		fileManagement         utils.FileManagement
+		maxUploadSize          int64
+	}
+)
+
+func NewProductImageService(productImageRepository repository.ProductImageRepository, fileManagement utils.FileManagement, cfg *config.Config) ProductImageService {
+	return &productImageService{
+		productImageRepository,
+		fileManagement,
+		cfg.Storage.MaxUploadSize,
+	}
+}
+
+func toProductImageResponses(images []entity.ProductImage) []dto.ProductImageResponse {
+	responses := []dto.ProductImageResponse{}
+	for _, image := range images {
+		responses = append(responses, toProductImageResponse(image))
+	}
+	return responses
+}
+
+func toProductImageResponse(image entity.ProductImage) dto.ProductImageResponse {
+	return dto.ProductImageResponse{
+		Id:       image.ID,
+		Image:    image.Image,
+		Position: image.Position,
+		Primary:  image.IsPrimary,
+		Urls:     productImages(image.Image),
+	}
+}
+
+func (p *productImageService) GetProductImagesService(ctx context.Context, barcodeId string) ([]dto.ProductImageResponse, error) {
+	images, err := p.productImageRepository.RetrieveProductImagesRepository(ctx, barcodeId)
+	if err != nil {
+		return nil, err
+	}
+	return toProductImageResponses(images), nil
+}
+
+// AddProductImageService stores the image and appends it to the product. The
+// image count is checked before uploading to spare the work, and again when
+// the image is added.
+func (p *productImageService) AddProductImageService(ctx context.Context, barcodeId string, image *multipart.FileHeader) (dto.ProductImageResponse, error) {
+	ext := p.fileManagement.GetFileNameExtension(image.Filename)
+	if ext != "jpg" && ext != "jpeg" && ext != "png" {
+		return dto.ProductImageResponse{}, dto.ErrWrongFileExtension
+	}
+	if image.Size > p.maxUploadSize {
+		return dto.ProductImageResponse{}, dto.ErrLimitSizeExceeded
+	}
+	images, err := p.productImageRepository.RetrieveProductImagesRepository(ctx, barcodeId)
+	if err != nil {
+		return dto.ProductImageResponse{}, err
+	}
+	if len(images) >= constant.MaxProductImages {
+		return dto.ProductImageResponse{}, dto.ErrTooManyProductImages
+	}
+	uow := utils.NewUnitOfWork(ctx, p.fileManagement)
+	defer uow.Rollback()
+	newFileName, err := uow.UploadImage(image)
+	if err != nil {
+		return dto.ProductImageResponse{}, err
+	}
+	newImage := entity.ProductImage{BarcodeId: barcodeId, Image: newFileName}
+	err = uow.Commit(func() error {
+		return p.productImageRepository.CreateProductImageRepository(ctx, &newImage)
+	})
+	if err != nil {
+		return dto.ProductImageResponse{}, err
+	}
+	return toProductImageResponse(newImage), nil
+}
+
+func (p *productImageService) ReorderProductImagesService(ctx context.Context, barcodeId string, imageIds []uint) ([]dto.ProductImageResponse, error) {
+	images, err := p.productImageRepository.ReorderProductImagesRepository(ctx, barcodeId, imageIds)
+	if err != nil {
+		return nil, err
+	}
+	return toProductImageResponses(images), nil
+}
+
+func (p *productImageService) SetPrimaryProductImageService(ctx context.Context, barcodeId string, imageId uint) error {
+	return p.productImageRepository.SetPrimaryProductImageRepository(ctx, barcodeId, imageId)
+}
+
+// DeleteProductImageService removes the image from the product and then its
+// files.
+func (p *productImageService) DeleteProductImageService(ctx context.Context, barcodeId string, imageId uint) error {
+	uow := utils.NewUnitOfWork(ctx, p.fileManagement)
+	return uow.Commit(func() error {
+		image, err := p.productImageRepository.DeleteProductImageRepository(ctx, barcodeId, imageId)
+		if err != nil {
+			return err
+		}
+		uow.DeleteImage(image.Image)
+		return nil
+	})
+}
@@ -0,0 +1,428 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/shopspring/decimal"
+)
+
+// importOptionalColumns are the product columns an import only overwrites
+// when its file has them, so a file without them keeps what products have.
+var importOptionalColumns = []string{"category", "min_stock", "reorder_quantity", "supplier_id"}
+
+type (
+	importCandidate struct {
+		result  int
+		product entity.Product
+		image   string
+		// imageMissing marks an image that isn't in the archive, which is only
+		// fine when the product already uses it.
+		imageMissing bool
+	}
+
+	// imageArchive indexes the entries of an uploaded ZIP by their full name, and
+	// by their base name when no entry already has it as its full name.
+	imageArchive struct {
+		file    multipart.File
+		entries map[string]*zip.File
+	}
+)
+
+func (p *productService) ImportProductsService(ctx context.Context, req dto.ImportProductRequest) (dto.ImportProductResult, error) {
+	format := p.fileManagement.GetFileNameExtension(req.File.Filename)
+	if format != constant.ExportFormatCSV && format != constant.ExportFormatXLSX {
+		return dto.ImportProductResult{}, dto.ErrWrongImportFileExtension
+	}
+	if req.File.Size > constant.MaxImportFileSize {
+		return dto.ImportProductResult{}, dto.ErrImportFileTooLarge
+	}
+	file, err := req.File.Open()
+	if err != nil {
+		return dto.ImportProductResult{}, dto.ErrInvalidImportFile
+	}
+	defer file.Close()
+	reader, err := utils.NewImportReader(format, file)
+	if err != nil {
+		return dto.ImportProductResult{}, err
+	}
+	defer reader.Close()
+
+	images, err := p.openImageArchive(req.Images)
+	if err != nil {
+		return dto.ImportProductResult{}, err
+	}
+	if images != nil {
+		defer images.file.Close()
+	}
+
+	result := dto.ImportProductResult{DryRun: req.DryRun, Rows: []dto.ImportProductRowResult{}}
+	candidates, columns, err := p.readImportRows(reader, images, &result)
+	if err != nil {
+		return dto.ImportProductResult{}, err
+	}
+	for start := 0; start < len(candidates); start += constant.ImportBatchSize {
+		end := min(start+constant.ImportBatchSize, len(candidates))
+		p.importBatch(ctx, candidates[start:end], columns, images, &result)
+	}
+
+	result.Total = len(result.Rows)
+	for _, row := range result.Rows {
+		switch row.Status {
+		case constant.ImportStatusCreated:
+			result.Created++
+		case constant.ImportStatusUpdated:
+			result.Updated++
+		case constant.ImportStatusRevived:
+			result.Revived++
+		case constant.ImportStatusInvalid:
+			result.Invalid++
+		case constant.ImportStatusFailed:
+			result.Failed++
+		case constant.ImportStatusSkipped:
+			result.Skipped++
+		case constant.ImportStatusUnchanged:
+			result.Unchanged++
+		}
+	}
+	return result, nil
+}
+
+// readImportRows validates every row with the rules of CreateProductService. An
+// image is optional here, but when a row names one it has to be acceptable.
+// Rows exported with a deleted status are skipped, so re-importing an export
+// doesn't revive them. It also returns which of importOptionalColumns the
+// file has.
+func (p *productService) readImportRows(reader utils.ImportReader, images *imageArchive, result *dto.ImportProductResult) ([]importCandidate, []string, error) {
+	header, _, err := reader.Next()
+	if err == io.EOF {
+		return nil, nil, dto.ErrInvalidImportHeader
+	} else if err != nil {
+		return nil, nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"barcode_id", "title", "price", "description"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, dto.ErrInvalidImportHeader
+		}
+	}
+	var optional []string
+	for _, column := range importOptionalColumns {
+		if _, ok := columns[column]; ok {
+			optional = append(optional, column)
+		}
+	}
+
+	var candidates []importCandidate
+	seen := make(map[string]bool)
+	for {
+		record, line, err := reader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+		get := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		status := strings.ToLower(get("status"))
+		if status == constant.ProductStatusDeleted {
+			result.Rows = append(result.Rows, dto.ImportProductRowResult{Row: line, BarcodeId: get("barcode_id"), Status: constant.ImportStatusSkipped})
+			continue
+		}
+
+		product := entity.Product{
+			BarcodeId:   get("barcode_id"),
+			Title:       get("title"),
+			Description: get("description"),
+			Category:    get("category"),
+		}
+		var errs []string
+		if status != "" && status != constant.ProductStatusActive {
+			errs = append(errs, dto.ErrImportInvalidStatus.Error())
+		}
+		if product.BarcodeId == "" || product.Title == "" || product.Description == "" || get("price") == "" {
+			errs = append(errs, dto.ErrImportMissingField.Error())
+		}
+		if value := get("price"); value != "" {
+			price, err := decimal.NewFromString(value)
+			if err != nil || price.IsNegative() {
+				errs = append(errs, dto.ErrImportInvalidPrice.Error())
+			}
+			product.Price = price
+		}
+		if err := parseStockLevels(&product, get("min_stock"), get("reorder_quantity"), get("supplier_id")); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if product.BarcodeId != "" {
+			if seen[product.BarcodeId] {
+				errs = append(errs, dto.ErrImportDuplicateBarcode.Error())
+			}
+			seen[product.BarcodeId] = true
+		}
+		image := get("image")
+		imageMissing := false
+		if image != "" {
+			err := p.checkImportImage(image, images)
+			if err == dto.ErrImportImageNotFound {
+				imageMissing = true
+			} else if err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+
+		row := dto.ImportProductRowResult{Row: line, BarcodeId: product.BarcodeId}
+		if len(errs) > 0 {
+			row.Status = constant.ImportStatusInvalid
+			row.Errors = errs
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+		result.Rows = append(result.Rows, row)
+		candidates = append(candidates, importCandidate{result: len(result.Rows) - 1, product: product, image: image, imageMissing: imageMissing})
+	}
+	return candidates, optional, nil
+}
+
+// importBatch upserts one batch of valid rows, leaving out products the row
+// wouldn't change. On a dry run it only reports what the batch would do.
+func (p *productService) importBatch(ctx context.Context, batch []importCandidate, columns []string, images *imageArchive, result *dto.ImportProductResult) {
+	barcodeIds := make([]string, len(batch))
+	for i, candidate := range batch {
+		barcodeIds[i] = candidate.product.BarcodeId
+	}
+	existing, err := p.producRepository.RetrieveProductsByBarcodeIdsRepository(ctx, barcodeIds)
+	if err != nil {
+		failImportBatch(batch, result, err)
+		return
+	}
+	var valid []importCandidate
+	for _, candidate := range batch {
+		row := &result.Rows[candidate.result]
+		product, ok := existing[candidate.product.BarcodeId]
+		switch {
+		case !ok:
+			row.Status = constant.ImportStatusCreated
+		case product.DeletedAt.Valid:
+			row.Status = constant.ImportStatusRevived
+		default:
+			row.Status = constant.ImportStatusUpdated
+		}
+		if ok && candidate.image == product.Image {
+			candidate.image = ""
+		} else if candidate.imageMissing {
+			row.Status = constant.ImportStatusInvalid
+			row.Errors = append(row.Errors, dto.ErrImportImageNotFound.Error())
+			continue
+		}
+		if ok && candidate.image == "" && sameProduct(product, candidate.product, columns) {
+			row.Status = constant.ImportStatusUnchanged
+			continue
+		}
+		valid = append(valid, candidate)
+	}
+	if result.DryRun {
+		return
+	}
+
+	uow := utils.NewUnitOfWork(ctx, p.fileManagement)
+	defer uow.Rollback()
+	var products []entity.Product
+	var written []importCandidate
+	for _, candidate := range valid {
+		if candidate.image != "" {
+			image, err := p.saveImportImage(ctx, candidate.image, images, uow)
+			if err != nil {
+				row := &result.Rows[candidate.result]
+				row.Status = constant.ImportStatusInvalid
+				row.Errors = append(row.Errors, err.Error())
+				continue
+			}
+			candidate.product.Image = image
+			uow.DeleteImage(existing[candidate.product.BarcodeId].Image)
+		}
+		products = append(products, candidate.product)
+		written = append(written, candidate)
+	}
+	if len(products) == 0 {
+		return
+	}
+	err = uow.Commit(func() error {
+		return p.producRepository.UpsertProductsRepository(ctx, products, columns)
+	})
+	if err != nil {
+		failImportBatch(written, result, err)
+	}
+}
+
+func (p *productService) openImageArchive(header *multipart.FileHeader) (*imageArchive, error) {
+	if header == nil {
+		return nil, nil
+	}
+	if p.fileManagement.GetFileNameExtension(header.Filename) != "zip" {
+		return nil, dto.ErrInvalidImageArchive
+	}
+	if header.Size > constant.MaxImportArchiveSize {
+		return nil, dto.ErrImportFileTooLarge
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, dto.ErrInvalidImageArchive
+	}
+	reader, err := zip.NewReader(file, header.Size)
+	if err != nil {
+		file.Close()
+		return nil, dto.ErrInvalidImageArchive
+	}
+	archive := &imageArchive{file: file, entries: make(map[string]*zip.File)}
+	for _, entry := range reader.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		archive.entries[entry.Name] = entry
+	}
+	for _, entry := range reader.File {
+		if _, ok := archive.entries[path.Base(entry.Name)]; !ok && !entry.FileInfo().IsDir() {
+			archive.entries[path.Base(entry.Name)] = entry
+		}
+	}
+	return archive, nil
+}
+
+func (p *productService) checkImportImage(source string, images *imageArchive) error {
+	ext := p.fileManagement.GetFileNameExtension(importImageName(source))
+	if ext != "jpg" && ext != "jpeg" && ext != "png" {
+		return dto.ErrWrongFileExtension
+	}
+	if isImageURL(source) {
+		return nil
+	}
+	if images == nil {
+		return dto.ErrImportImageNotFound
+	}
+	entry, ok := images.entries[source]
+	if !ok {
+		return dto.ErrImportImageNotFound
+	}
+	if entry.UncompressedSize64 > uint64(p.maxUploadSize) {
+		return dto.ErrLimitSizeExceeded
+	}
+	return nil
+}
+
+func (p *productService) saveImportImage(ctx context.Context, source string, images *imageArchive, uow *utils.UnitOfWork) (string, error) {
+	var content io.Reader
+	if isImageURL(source) {
+		downloaded, err := p.fileManagement.DownloadFile(ctx, source)
+		if err != nil {
+			return "", err
+		}
+		content = bytes.NewReader(downloaded)
+	} else {
+		entry, err := images.entries[source].Open()
+		if err != nil {
+			return "", dto.ErrInvalidImageArchive
+		}
+		defer entry.Close()
+		content = io.LimitReader(entry, p.maxUploadSize)
+	}
+	return uow.SaveImage(content)
+}
+
+func parseStockLevels(product *entity.Product, minStock, reorderQuantity, supplierId string) error {
+	var err error
+	if minStock != "" {
+		if product.MinStock, err = strconv.ParseInt(minStock, 10, 64); err != nil {
+			return dto.ErrImportInvalidNumber
+		}
+	}
+	if reorderQuantity != "" {
+		if product.ReorderQuantity, err = strconv.ParseInt(reorderQuantity, 10, 64); err != nil {
+			return dto.ErrImportInvalidNumber
+		}
+	}
+	if supplierId != "" {
+		id, err := strconv.ParseUint(supplierId, 10, 0)
+		if err != nil {
+			return dto.ErrImportInvalidNumber
+		}
+		supplier := uint(id)
+		product.SupplierId = &supplier
+	}
+	if product.MinStock < 0 || product.ReorderQuantity < 0 {
+		return dto.ErrInvalidStockLevel
+	}
+	return nil
+}
+
+// sameProduct tells whether an import row leaves an existing product as it is,
+// comparing only the columns the file has.
+func sameProduct(existing, product entity.Product, columns []string) bool {
+	if existing.DeletedAt.Valid || existing.Title != product.Title || !existing.Price.Equal(product.Price) || existing.Description != product.Description {
+		return false
+	}
+	for _, column := range columns {
+		switch column {
+		case "category":
+			if existing.Category != product.Category {
+				return false
+			}
+		case "min_stock":
+			if existing.MinStock != product.MinStock {
+				return false
+			}
+		case "reorder_quantity":
+			if existing.ReorderQuantity != product.ReorderQuantity {
+				return false
+			}
+		case "supplier_id":
+			if (existing.SupplierId == nil) != (product.SupplierId == nil) || (existing.SupplierId != nil && *existing.SupplierId != *product.SupplierId) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func failImportBatch(batch []importCandidate, result *dto.ImportProductResult, err error) {
+	for _, candidate := range batch {
+		row := &result.Rows[candidate.result]
+		row.Status = constant.ImportStatusFailed
+		row.Errors = append(row.Errors, err.Error())
+	}
+}
+
+func isImageURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// importImageName is the part of an image source that carries its extension.
+func importImageName(source string) string {
+	if isImageURL(source) {
+		if parsed, err := url.Parse(source); err == nil {
+			return path.Base(parsed.Path)
+		}
+	}
+	return source
+}
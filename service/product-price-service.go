@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// AdjustPricesService changes the catalog price of every product the filter
+// selects. A preview computes the same prices without writing them.
+func (p *productService) AdjustPricesService(ctx context.Context, req dto.PriceAdjustmentRequest) (dto.PriceAdjustmentResult, error) {
+	filter := req.ProductFilter
+	if filter.Category == "" && filter.SupplierId == nil && len(filter.BarcodeIds) == 0 && filter.Search == "" {
+		return dto.PriceAdjustmentResult{}, dto.ErrNoPriceFilter
+	}
+	if req.Value.IsZero() || req.RoundTo.IsNegative() {
+		return dto.PriceAdjustmentResult{}, dto.ErrInvalidPriceAdjustment
+	}
+	adjust := func(product entity.Product) (decimal.Decimal, error) {
+		price := adjustPrice(product.Price, req)
+		if price.IsNegative() {
+			return decimal.Decimal{}, dto.ErrNegativeAdjustedPrice
+		}
+		return price, nil
+	}
+
+	var changes []dto.PriceChange
+	if req.Preview {
+		products, err := p.producRepository.RetrieveProductsByFilterRepository(ctx, filter)
+		if err != nil {
+			return dto.PriceAdjustmentResult{}, err
+		}
+		if len(products) == 0 {
+			return dto.PriceAdjustmentResult{}, dto.ErrProductsNotFound
+		}
+		for _, product := range products {
+			price, err := adjust(product)
+			if err != nil {
+				return dto.PriceAdjustmentResult{}, err
+			}
+			changes = append(changes, dto.PriceChange{BarcodeId: product.BarcodeId, Title: product.Title, OldPrice: product.Price, NewPrice: price})
+		}
+	} else {
+		var err error
+		changes, err = p.producRepository.UpdateProductPricesRepository(ctx, filter, adjust)
+		if err != nil {
+			return dto.PriceAdjustmentResult{}, err
+		}
+	}
+
+	result := dto.PriceAdjustmentResult{Preview: req.Preview, Total: len(changes), Products: changes}
+	for _, change := range changes {
+		if !change.OldPrice.Equal(change.NewPrice) {
+			result.Changed++
+		}
+	}
+	return result, nil
+}
+
+// adjustPrice applies the change and rounds the result to a multiple of
+// RoundTo, or to cents when no step is given.
+func adjustPrice(price decimal.Decimal, req dto.PriceAdjustmentRequest) decimal.Decimal {
+	if req.Type == constant.PriceAdjustmentPercentage {
+		price = price.Add(price.Mul(req.Value).Div(decimal.NewFromInt(100)))
+	} else {
+		price = price.Add(req.Value)
+	}
+
+	step := req.RoundTo
+	if step.IsZero() {
+		step = decimal.New(1, -constant.PriceDecimalPlaces)
+	}
+	steps := price.Div(step)
+	switch req.Rounding {
+	case constant.PriceRoundingUp:
+		steps = steps.Ceil()
+	case constant.PriceRoundingDown:
+		steps = steps.Floor()
+	default:
+		steps = steps.Round(0)
+	}
+	return steps.Mul(step)
+}
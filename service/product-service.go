@@ -1,8 +1,16 @@
 package service
 
 import (
-	"fmt"
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
 	"math"
+	"path"
+	"slices"
+	"strings"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/constant"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
@@ -12,155 +20,335 @@ import (
 
 type (
 	ProductService interface {
-		GetProductService(page *uint16) (dto.AllProductsWithPagination, error)
-		GetProductDetailService(barcodeId *string) (dto.ProductWithoutTimeStamp, error)
-		SearchProductService(req *dto.SearchProductQuery) ([]dto.ProductWithoutTimeStamp, error)
-		CreateProductService(product dto.AddProductRequest) error
-		UpdateProductService(barcodeId string, product dto.UpdateProductRequest) error
-		DeleteProductService(barcodeId *string) error
+		GetProductService(ctx context.Context, req *dto.ProductListQuery) (dto.AllProductsWithPagination, error)
+		GetProductsByCursorService(ctx context.Context, req *dto.ProductListQuery) (dto.ProductsWithCursor, error)
+		GetProductDetailService(ctx context.Context, barcodeId *string, storeId uint) (dto.ProductWithoutTimeStamp, error)
+		SearchProductService(ctx context.Context, req *dto.SearchProductQuery) (dto.AllProductsWithPagination, error)
+		AutocompleteProductService(ctx context.Context, term string) ([]dto.ProductSuggestion, error)
+		CreateProductService(ctx context.Context, product dto.AddProductRequest) error
+		UpdateProductService(ctx context.Context, barcodeId string, product dto.UpdateProductRequest, version *uint) error
+		DeleteProductService(ctx context.Context, barcodeId *string, version *uint) error
+		ExportProductsService(ctx context.Context, includeDeleted bool, w utils.ExportWriter) ([]string, error)
+		ExportProductArchiveService(ctx context.Context, req dto.ExportProductQuery, w io.Writer) error
+		ImportProductsService(ctx context.Context, req dto.ImportProductRequest) (dto.ImportProductResult, error)
+		AdjustPricesService(ctx context.Context, req dto.PriceAdjustmentRequest) (dto.PriceAdjustmentResult, error)
 	}
 	productService struct {
 		producRepository repository.ProductRepository
 		fileManagement   utils.FileManagement
+		imageDir         string
+		maxUploadSize    int64
 	}
 )
 
-func NewProductService(productRepository repository.ProductRepository, fileManagement utils.FileManagement) ProductService {
+func NewProductService(productRepository repository.ProductRepository, fileManagement utils.FileManagement, cfg *config.Config) ProductService {
 	return &productService{
 		productRepository,
 		fileManagement,
+		cfg.Storage.ImageDir,
+		cfg.Storage.MaxUploadSize,
 	}
 }
 
-func (p *productService) GetProductService(page *uint16) (dto.AllProductsWithPagination, error) {
-	totalProducts, err := p.producRepository.CountProductsRepository()
+func (p *productService) GetProductService(ctx context.Context, req *dto.ProductListQuery) (dto.AllProductsWithPagination, error) {
+	totalProducts, err := p.producRepository.CountProductsRepository(ctx, req)
 	if err != nil {
 		return dto.AllProductsWithPagination{}, err
 	}
 	if totalProducts == 0 {
 		return dto.AllProductsWithPagination{}, dto.ErrProductsNotFound
 	}
-	var itemPerPage uint16 = 12
-	totalPage := uint16(math.Ceil(float64(totalProducts) / float64(itemPerPage)))
-	if *page == 0 {
-		*page = 1
-	} else if *page > totalPage {
-		*page = totalPage
-	}
+	pageMetaData, offset := paginate(req.Page, req.PageSize, totalProducts)
+	req.Page = pageMetaData.Page
 
-	offset := itemPerPage * (*page - 1)
-	allProducts, err := p.producRepository.RetrieveProductsRepository(itemPerPage, offset)
+	allProducts, err := p.producRepository.RetrieveProductsRepository(ctx, req, pageMetaData.PageSize, offset)
 	if err != nil {
 		return dto.AllProductsWithPagination{}, err
 	}
-	var finalProducts []dto.ProductWithoutTimeStamp
-
-	for _, product := range allProducts {
-		finalProducts = append(finalProducts, dto.ProductWithoutTimeStamp{
-			BarcodeId:   product.BarcodeId,
-			Title:       product.Title,
-			Image:       product.Image,
-			Price:       product.Price,
-			Description: product.Description,
-		})
+	finalProducts := toProductResponses(allProducts)
+	if err := p.applyStorePrices(ctx, req.StoreId, finalProducts); err != nil {
+		return dto.AllProductsWithPagination{}, err
 	}
 
-	var nextPage, prevPage uint16
-	if *page == totalPage {
-		nextPage = *page
-	} else {
-		nextPage = *page + 1
-	}
+	return dto.AllProductsWithPagination{
+		Products:     finalProducts,
+		PageMetaData: pageMetaData,
+	}, nil
+}
 
-	if *page == 1 {
-		prevPage = *page
-	} else {
-		prevPage = *page - 1
+// GetProductsByCursorService pages through the catalog by cursor, which keeps
+// pages stable while products are added at the till.
+func (p *productService) GetProductsByCursorService(ctx context.Context, req *dto.ProductListQuery) (dto.ProductsWithCursor, error) {
+	var after *utils.Cursor
+	if req.Cursor != "" {
+		cursor, err := utils.DecodeCursor(req.Cursor)
+		if err != nil {
+			return dto.ProductsWithCursor{}, err
+		}
+		if cursor.Sort != req.Sort || cursor.Order != req.Order {
+			return dto.ProductsWithCursor{}, dto.ErrInvalidCursor
+		}
+		after = &cursor
 	}
+	pageSize := productPageSize(req.PageSize)
 
-	return dto.AllProductsWithPagination{
+	products, next, err := p.producRepository.RetrieveProductsAfterRepository(ctx, req, after, pageSize)
+	if err != nil {
+		return dto.ProductsWithCursor{}, err
+	}
+	if len(products) == 0 && after == nil {
+		return dto.ProductsWithCursor{}, dto.ErrProductsNotFound
+	}
+	finalProducts := toProductResponses(products)
+	if err := p.applyStorePrices(ctx, req.StoreId, finalProducts); err != nil {
+		return dto.ProductsWithCursor{}, err
+	}
+	if finalProducts == nil {
+		finalProducts = []dto.ProductWithoutTimeStamp{}
+	}
+	return dto.ProductsWithCursor{
 		Products: finalProducts,
-		PageMetaData: dto.PaginationResponse{
-			Page:      *page,
-			PrevPage:  prevPage,
-			NextPage:  nextPage,
-			TotalPage: totalPage,
+		Cursor: dto.CursorResponse{
+			NextCursor: next,
+			HasMore:    next != "",
+			PageSize:   pageSize,
 		},
 	}, nil
 }
 
-func (p *productService) GetProductDetailService(barcodeId *string) (dto.ProductWithoutTimeStamp, error) {
-	productExist, ok := p.producRepository.RetrieveProductByBarcodeId(barcodeId)
-	if !ok {
-		return dto.ProductWithoutTimeStamp{}, dto.ErrProductDoesntExist
+// productPageSize keeps a requested page size within 1..MaxProductsPerPage,
+// using the default when none is given.
+func productPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return constant.ProductsPerPage
+	} else if pageSize > constant.MaxProductsPerPage {
+		return constant.MaxProductsPerPage
 	}
-	return productExist, nil
+	return pageSize
 }
 
-func (p *productService) SearchProductService(req *dto.SearchProductQuery) ([]dto.ProductWithoutTimeStamp, error) {
-	products, err := p.producRepository.RetrieveProductForSearch(req)
-	if err != nil {
-		return []dto.ProductWithoutTimeStamp{}, err
+// paginate clamps page into the pages total products fill and returns the
+// metadata for it with the offset of its first product.
+func paginate(page, pageSize int, totalProducts int64) (dto.PaginationResponse, int) {
+	pageSize = productPageSize(pageSize)
+	totalPage := int(math.Ceil(float64(totalProducts) / float64(pageSize)))
+	if page <= 0 {
+		page = 1
+	} else if page > totalPage {
+		page = totalPage
 	}
-	if len(products) < 1 {
-		return []dto.ProductWithoutTimeStamp{}, dto.ErrProductsNotFound
+
+	var nextPage, prevPage int
+	if page == totalPage {
+		nextPage = page
+	} else {
+		nextPage = page + 1
+	}
+
+	if page == 1 {
+		prevPage = page
+	} else {
+		prevPage = page - 1
 	}
+
+	return dto.PaginationResponse{
+		Page:       page,
+		PrevPage:   prevPage,
+		NextPage:   nextPage,
+		TotalPage:  totalPage,
+		PageSize:   pageSize,
+		TotalItems: totalProducts,
+	}, pageSize * (page - 1)
+}
+
+func toProductResponses(products []entity.Product) []dto.ProductWithoutTimeStamp {
 	var finalProducts []dto.ProductWithoutTimeStamp
 	for _, product := range products {
 		finalProducts = append(finalProducts, dto.ProductWithoutTimeStamp{
-			BarcodeId:   product.BarcodeId,
-			Image:       product.Image,
-			Title:       product.Title,
-			Price:       product.Price,
-			Description: product.Description,
+			BarcodeId:       product.BarcodeId,
+			Title:           product.Title,
+			Image:           product.Image,
+			Price:           product.Price,
+			Description:     product.Description,
+			Category:        product.Category,
+			MinStock:        product.MinStock,
+			ReorderQuantity: product.ReorderQuantity,
+			SupplierId:      product.SupplierId,
+			Images:          productImages(product.Image),
 		})
 	}
-	return finalProducts, nil
+	return finalProducts
 }
 
-func (p *productService) CreateProductService(product dto.AddProductRequest) error {
-	_, ok := p.producRepository.RetrieveDeletedProductByBarcodeId(&product.BarcodeId)
-	if ok {
-		if err := p.producRepository.UpdateDeletedProductRepository(&product.BarcodeId); err != nil {
-			return err
+// productImages links every size generated for a product image. Images from
+// before sizes were generated link the one file they have for every size.
+func productImages(image string) dto.ProductImages {
+	if image == "" {
+		return dto.ProductImages{}
+	}
+	url := func(size string) string {
+		return path.Join(constant.ImageURLPath, utils.ImageVariantName(image, size))
+	}
+	return dto.ProductImages{
+		Original:  url(constant.ImageSizeOriginal),
+		Medium:    url(constant.ImageSizeMedium),
+		Thumbnail: url(constant.ImageSizeThumbnail),
+	}
+}
+
+func (p *productService) GetProductDetailService(ctx context.Context, barcodeId *string, storeId uint) (dto.ProductWithoutTimeStamp, error) {
+	productExist, ok := p.producRepository.RetrieveProductByBarcodeId(ctx, barcodeId)
+	if !ok {
+		// The lookup can't tell a missing product from an abandoned request.
+		if err := ctx.Err(); err != nil {
+			return dto.ProductWithoutTimeStamp{}, err
 		}
-		return nil
-	} else {
+		return dto.ProductWithoutTimeStamp{}, dto.ErrProductDoesntExist
+	}
+	productExist.Images = productImages(productExist.Image)
+	products := []dto.ProductWithoutTimeStamp{productExist}
+	if err := p.applyStorePrices(ctx, storeId, products); err != nil {
+		return dto.ProductWithoutTimeStamp{}, err
+	}
+	return products[0], nil
+}
+
+// SearchProductService returns the matching products best match first, a page
+// at a time.
+func (p *productService) SearchProductService(ctx context.Context, req *dto.SearchProductQuery) (dto.AllProductsWithPagination, error) {
+	totalProducts, err := p.producRepository.CountProductsForSearchRepository(ctx, req)
+	if err != nil {
+		return dto.AllProductsWithPagination{}, err
+	}
+	if totalProducts == 0 {
+		return dto.AllProductsWithPagination{}, dto.ErrProductsNotFound
+	}
+	pageMetaData, offset := paginate(req.Page, req.PageSize, totalProducts)
+
+	products, err := p.producRepository.RetrieveProductForSearch(ctx, req, pageMetaData.PageSize, offset)
+	if err != nil {
+		return dto.AllProductsWithPagination{}, err
+	}
+	finalProducts := toProductResponses(products)
+	if err := p.applyStorePrices(ctx, req.StoreId, finalProducts); err != nil {
+		return dto.AllProductsWithPagination{}, err
+	}
+	return dto.AllProductsWithPagination{
+		Products:     finalProducts,
+		PageMetaData: pageMetaData,
+	}, nil
+}
+
+func (p *productService) AutocompleteProductService(ctx context.Context, term string) ([]dto.ProductSuggestion, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return []dto.ProductSuggestion{}, nil
+	}
+	suggestions, err := p.producRepository.RetrieveProductSuggestionsRepository(ctx, term, constant.AutocompleteLimit)
+	if err != nil {
+		return []dto.ProductSuggestion{}, err
+	}
+	if suggestions == nil {
+		return []dto.ProductSuggestion{}, nil
+	}
+	return suggestions, nil
+}
+
+// CreateProductService adds a product, or revives a deleted one with the
+// uploaded image since its images went with it. The check and the write share
+// a transaction, so a product created or revived in between is not missed.
+func (p *productService) CreateProductService(ctx context.Context, product dto.AddProductRequest) error {
+	ext := p.fileManagement.GetFileNameExtension(product.Image.Filename)
+	if ext != "jpg" && ext != "jpeg" && ext != "png" {
+		return dto.ErrWrongFileExtension
+	}
+	if product.Image.Size > p.maxUploadSize {
+		return dto.ErrLimitSizeExceeded
+	}
+	// The image is uploaded before the transaction opens, so nothing stays
+	// locked while it is processed; the unit of work removes it again when
+	// the write fails.
+	uow := utils.NewUnitOfWork(ctx, p.fileManagement)
+	defer uow.Rollback()
+	newFileName, err := uow.UploadImage(product.Image)
+	if err != nil {
+		return err
+	}
+	return uow.Commit(func() error {
+		return p.producRepository.WithTx(ctx, func(repo repository.ProductRepository) error {
+			_, deleted := repo.RetrieveDeletedProductByBarcodeId(ctx, &product.BarcodeId)
+			if !deleted {
+				if _, ok := repo.RetrieveProductByBarcodeId(ctx, &product.BarcodeId); ok {
+					return dto.ErrProductExist
+				}
+			}
+			if deleted {
+				return repo.UpdateDeletedProductRepository(ctx, &product.BarcodeId, newFileName)
+			}
+			newProduct := entity.Product{
+				BarcodeId:       product.BarcodeId,
+				Image:           newFileName,
+				Title:           product.Title,
+				Price:           product.Price,
+				Description:     product.Description,
+				Category:        product.Category,
+				MinStock:        product.MinStock,
+				ReorderQuantity: product.ReorderQuantity,
+				SupplierId:      product.SupplierId,
+			}
+			return repo.CreateProductRepository(ctx, &newProduct)
+		})
+	})
+}
+
+// UpdateProductService applies the changes in the request. Reading the product
+// and writing it share a transaction, so the version checked and the image
+// replaced are the ones the update overwrites. A new image is uploaded before
+// that transaction opens, so the product stays locked only for the write.
+func (p *productService) UpdateProductService(ctx context.Context, barcodeId string, product dto.UpdateProductRequest, version *uint) error {
+	updates, err := productUpdates(product)
+	if err != nil {
+		return err
+	}
+	if product.Image == nil && len(updates) == 0 {
+		return dto.ErrNoChangesRequest
+	}
+	uow := utils.NewUnitOfWork(ctx, p.fileManagement)
+	defer uow.Rollback()
+	if product.Image != nil {
 		ext := p.fileManagement.GetFileNameExtension(product.Image.Filename)
 		if ext != "jpg" && ext != "jpeg" && ext != "png" {
 			return dto.ErrWrongFileExtension
 		}
-		if product.Image.Size > constant.MaxUploadSize {
+		if product.Image.Size > p.maxUploadSize {
 			return dto.ErrLimitSizeExceeded
 		}
-		_, ok := p.producRepository.RetrieveProductByBarcodeId(&product.BarcodeId)
-		if ok {
-			return dto.ErrProductExist
-		}
-		var newFileName = p.fileManagement.GenerateNewFileName(ext)
-		pathDir := constant.ImageDir
-		if err := p.fileManagement.UploadFile(product.Image, newFileName, pathDir); err != nil {
-			return err
-		}
-		newProduct := entity.Product{
-			BarcodeId:   product.BarcodeId,
-			Image:       newFileName,
-			Title:       product.Title,
-			Price:       product.Price,
-			Description: product.Description,
-		}
-		if err := p.producRepository.CreateProductRepository(&newProduct); err != nil {
+		newFileName, err := uow.UploadImage(product.Image)
+		if err != nil {
 			return err
 		}
-		return nil
+		updates["image"] = newFileName
 	}
+	return uow.Commit(func() error {
+		return p.producRepository.WithTx(ctx, func(repo repository.ProductRepository) error {
+			productExist, ok := repo.RetrieveProductByBarcodeId(ctx, &barcodeId)
+			if !ok {
+				return dto.ErrProductDoesntExist
+			}
+			if version != nil && *version != productExist.Version {
+				return dto.ErrVersionMismatch
+			}
+			if product.Image != nil {
+				uow.DeleteImage(productExist.Image)
+			}
+			return repo.UpdateProductRepository(ctx, &barcodeId, &updates, version)
+		})
+	})
 }
 
-func (p *productService) UpdateProductService(barcodeId string, product dto.UpdateProductRequest) error {
-	productExist, ok := p.producRepository.RetrieveProductByBarcodeId(&barcodeId)
-	if !ok {
-		return dto.ErrProductDoesntExist
-	}
+// productUpdates collects the columns an update request changes, apart from
+// the image.
+func productUpdates(product dto.UpdateProductRequest) (map[string]interface{}, error) {
 	updates := make(map[string]interface{})
 	if product.Title != nil {
 		updates["title"] = *product.Title
@@ -171,44 +359,144 @@ func (p *productService) UpdateProductService(barcodeId string, product dto.Upda
 	if product.Description != nil {
 		updates["description"] = *product.Description
 	}
-
-	if product.Image != nil {
-		ext := p.fileManagement.GetFileNameExtension(product.Image.Filename)
-		if ext != "jpg" && ext != "jpeg" && ext != "png" {
-			return dto.ErrWrongFileExtension
-		}
-		if product.Image.Size > constant.MaxUploadSize {
-			return dto.ErrLimitSizeExceeded
+	if product.Category != nil {
+		updates["category"] = *product.Category
+	}
+	if product.MinStock != nil {
+		if *product.MinStock < 0 {
+			return nil, dto.ErrInvalidStockLevel
 		}
-		var newFileName = p.fileManagement.GenerateNewFileName(ext)
-		pathDir := constant.ImageDir
-		if err := p.fileManagement.UploadFile(product.Image, newFileName, pathDir); err != nil {
-			return err
+		updates["min_stock"] = *product.MinStock
+	}
+	if product.ReorderQuantity != nil {
+		if *product.ReorderQuantity < 0 {
+			return nil, dto.ErrInvalidStockLevel
 		}
-		pathDeletedImage := fmt.Sprintf("%s/%s", constant.ImageDir, productExist.Image)
-		err := p.fileManagement.DeleteFile(pathDeletedImage)
+		updates["reorder_quantity"] = *product.ReorderQuantity
+	}
+	if product.SupplierId != nil {
+		updates["supplier_id"] = *product.SupplierId
+	}
+	return updates, nil
+}
+
+func (p *productService) DeleteProductService(ctx context.Context, barcodeId *string, version *uint) error {
+	productExist, ok := p.producRepository.RetrieveProductByBarcodeId(ctx, barcodeId)
+	if !ok {
+		return dto.ErrProductDoesntExist
+	}
+	if version != nil && *version != productExist.Version {
+		return dto.ErrVersionMismatch
+	}
+	uow := utils.NewUnitOfWork(ctx, p.fileManagement)
+	return uow.Commit(func() error {
+		images, err := p.producRepository.DeleteProductRepository(ctx, barcodeId, version)
 		if err != nil {
 			return err
 		}
-		updates["image"] = newFileName
-	}
-	if len(updates) > 0 {
-		if err := p.producRepository.UpdateProductRepository(&barcodeId, &updates); err != nil {
-			return err
+		// Products created before images were collected may only know their own.
+		if productExist.Image != "" && !slices.Contains(images, productExist.Image) {
+			images = append(images, productExist.Image)
+		}
+		for _, image := range images {
+			uow.DeleteImage(image)
 		}
 		return nil
-	} else {
-		return dto.ErrNoChangesRequest
+	})
+}
+
+// applyStorePrices replaces the catalog price with the store's own price where it
+// has one. Without a store the catalog price is returned as it is.
+func (p *productService) applyStorePrices(ctx context.Context, storeId uint, products []dto.ProductWithoutTimeStamp) error {
+	if storeId == 0 || len(products) == 0 {
+		return nil
+	}
+	var barcodeIds []string
+	for _, product := range products {
+		barcodeIds = append(barcodeIds, product.BarcodeId)
 	}
+	prices, err := p.producRepository.RetrieveStorePriceOverridesRepository(ctx, storeId, barcodeIds)
+	if err != nil {
+		return err
+	}
+	for i := range products {
+		if price, ok := prices[products[i].BarcodeId]; ok {
+			products[i].Price = price
+		}
+	}
+	return nil
 }
 
-func (p *productService) DeleteProductService(barcodeId *string) error {
-	_, ok := p.producRepository.RetrieveProductByBarcodeId(barcodeId)
-	if !ok {
-		return dto.ErrProductDoesntExist
+// ExportProductsService writes the catalog with the columns the import reads, so
+// the file can be edited and imported again. It returns the images the rows
+// refer to.
+func (p *productService) ExportProductsService(ctx context.Context, includeDeleted bool, w utils.ExportWriter) ([]string, error) {
+	columns := dto.PRODUCT_IMPORT_COLUMNS
+	if includeDeleted {
+		columns = append(columns[:len(columns):len(columns)], "status")
+	}
+	if err := w.WriteHeader(columns...); err != nil {
+		return nil, err
 	}
-	if err := p.producRepository.DeleteProductRepository(barcodeId); err != nil {
+	var images []string
+	err := p.producRepository.StreamProductsRepository(ctx, includeDeleted, func(product entity.Product) error {
+		values := []interface{}{product.BarcodeId, product.Title, product.Price, product.Description, product.Category, product.Image, product.MinStock, product.ReorderQuantity, product.SupplierId}
+		if includeDeleted {
+			status := constant.ProductStatusActive
+			if product.DeletedAt.Valid {
+				status = constant.ProductStatusDeleted
+			}
+			values = append(values, status)
+		}
+		if product.Image != "" {
+			images = append(images, product.Image)
+		}
+		return w.WriteRow(values...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// ExportProductArchiveService zips the catalog export together with its images,
+// which is also the layout the import accepts for its image archive.
+func (p *productService) ExportProductArchiveService(ctx context.Context, req dto.ExportProductQuery, w io.Writer) error {
+	archive := zip.NewWriter(w)
+	entry, err := archive.Create(utils.ExportFileName("products", req.Format))
+	if err != nil {
+		return dto.ErrToWriteExport
+	}
+	table, err := utils.NewExportWriter(req.Format, "Products", entry)
+	if err != nil {
+		return err
+	}
+	images, err := p.ExportProductsService(ctx, req.IncludeDeleted, table)
+	if err != nil {
+		table.Discard()
+		return err
+	}
+	if err := table.Close(); err != nil {
 		return err
 	}
+	for _, image := range images {
+		file, err := p.fileManagement.OpenFile(ctx, path.Join(p.imageDir, path.Base(image)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return dto.ErrToWriteExport
+		}
+		entry, err := archive.Create("images/" + path.Base(image))
+		if err == nil {
+			_, err = io.Copy(entry, file)
+		}
+		file.Close()
+		if err != nil {
+			return dto.ErrToWriteExport
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return dto.ErrToWriteExport
+	}
 	return nil
 }
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	"tiga-putra-cashier-be/utils"
+	"time"
+)
+
+type (
+	ReportService interface {
+		GetSalesSummaryService(ctx context.Context, req dto.SalesSummaryQuery) ([]dto.SalesPeriodTotal, error)
+		GetHourlySalesService(ctx context.Context, req dto.ReportQuery) ([]dto.HourlySales, error)
+		GetCashierSalesService(ctx context.Context, req dto.ReportQuery) ([]dto.CashierSales, error)
+		GetPaymentMethodSalesService(ctx context.Context, req dto.ReportQuery) ([]dto.PaymentMethodSales, error)
+		GetCategorySalesService(ctx context.Context, req dto.ReportQuery) ([]dto.CategorySales, error)
+		GetTopProductsService(ctx context.Context, req dto.TopProductsQuery) ([]dto.ProductSales, error)
+		GetStockValuationService(ctx context.Context, storeId uint) ([]dto.StockValuation, error)
+		ExportSalesSummaryService(ctx context.Context, req dto.SalesSummaryQuery, w utils.ExportWriter) error
+		ExportHourlySalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error
+		ExportCashierSalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error
+		ExportPaymentMethodSalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error
+		ExportCategorySalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error
+		ExportTopProductsService(ctx context.Context, req dto.TopProductsQuery, w utils.ExportWriter) error
+		ExportStockValuationService(ctx context.Context, storeId uint, w utils.ExportWriter) error
+	}
+	reportService struct {
+		reportRepository repository.ReportRepository
+	}
+)
+
+func NewReportService(reportRepository repository.ReportRepository) ReportService {
+	return &reportService{reportRepository}
+}
+
+func (r *reportService) GetSalesSummaryService(ctx context.Context, req dto.SalesSummaryQuery) ([]dto.SalesPeriodTotal, error) {
+	if err := validateReportRange(req.ReportQuery); err != nil {
+		return []dto.SalesPeriodTotal{}, err
+	}
+	if req.Period == "" {
+		req.Period = constant.ReportPeriodDay
+	}
+	totals, err := r.reportRepository.RetrieveSalesByPeriodRepository(ctx, req.ReportQuery, req.Period)
+	if err != nil {
+		return []dto.SalesPeriodTotal{}, err
+	}
+	if totals == nil {
+		totals = []dto.SalesPeriodTotal{}
+	}
+	return totals, nil
+}
+
+func (r *reportService) GetHourlySalesService(ctx context.Context, req dto.ReportQuery) ([]dto.HourlySales, error) {
+	if err := validateReportRange(req); err != nil {
+		return []dto.HourlySales{}, err
+	}
+	sales, err := r.reportRepository.RetrieveSalesByHourRepository(ctx, req)
+	if err != nil {
+		return []dto.HourlySales{}, err
+	}
+	if sales == nil {
+		sales = []dto.HourlySales{}
+	}
+	return sales, nil
+}
+
+func (r *reportService) GetCashierSalesService(ctx context.Context, req dto.ReportQuery) ([]dto.CashierSales, error) {
+	if err := validateReportRange(req); err != nil {
+		return []dto.CashierSales{}, err
+	}
+	sales, err := r.reportRepository.RetrieveSalesByCashierRepository(ctx, req)
+	if err != nil {
+		return []dto.CashierSales{}, err
+	}
+	if sales == nil {
+		sales = []dto.CashierSales{}
+	}
+	return sales, nil
+}
+
+func (r *reportService) GetPaymentMethodSalesService(ctx context.Context, req dto.ReportQuery) ([]dto.PaymentMethodSales, error) {
+	if err := validateReportRange(req); err != nil {
+		return []dto.PaymentMethodSales{}, err
+	}
+	sales, err := r.reportRepository.RetrieveSalesByPaymentMethodRepository(ctx, req)
+	if err != nil {
+		return []dto.PaymentMethodSales{}, err
+	}
+	if sales == nil {
+		sales = []dto.PaymentMethodSales{}
+	}
+	return sales, nil
+}
+
+func (r *reportService) GetCategorySalesService(ctx context.Context, req dto.ReportQuery) ([]dto.CategorySales, error) {
+	if err := validateReportRange(req); err != nil {
+		return []dto.CategorySales{}, err
+	}
+	sales, err := r.reportRepository.RetrieveSalesByCategoryRepository(ctx, req)
+	if err != nil {
+		return []dto.CategorySales{}, err
+	}
+	if sales == nil {
+		sales = []dto.CategorySales{}
+	}
+	return sales, nil
+}
+
+func (r *reportService) GetTopProductsService(ctx context.Context, req dto.TopProductsQuery) ([]dto.ProductSales, error) {
+	if err := validateReportRange(req.ReportQuery); err != nil {
+		return []dto.ProductSales{}, err
+	}
+	if req.By == "" {
+		req.By = constant.ReportRankByRevenue
+	}
+	if req.Limit == 0 {
+		req.Limit = dto.DEFAULT_TOP_PRODUCTS_LIMIT
+	}
+	products, err := r.reportRepository.RetrieveTopProductsRepository(ctx, req.ReportQuery, req.By, req.Limit)
+	if err != nil {
+		return []dto.ProductSales{}, err
+	}
+	if products == nil {
+		products = []dto.ProductSales{}
+	}
+	return products, nil
+}
+
+func (r *reportService) GetStockValuationService(ctx context.Context, storeId uint) ([]dto.StockValuation, error) {
+	valuations := []dto.StockValuation{}
+	err := r.reportRepository.StreamStockValuationRepository(ctx, storeId, func(valuation dto.StockValuation) error {
+		valuations = append(valuations, valuation)
+		return nil
+	})
+	if err != nil {
+		return []dto.StockValuation{}, err
+	}
+	return valuations, nil
+}
+
+func (r *reportService) ExportSalesSummaryService(ctx context.Context, req dto.SalesSummaryQuery, w utils.ExportWriter) error {
+	totals, err := r.GetSalesSummaryService(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader("Period", "Transactions", "Revenue", "Average Ticket"); err != nil {
+		return err
+	}
+	for _, total := range totals {
+		if err := w.WriteRow(total.Period, total.Transactions, total.Revenue, total.AverageTicket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reportService) ExportHourlySalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	sales, err := r.GetHourlySalesService(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader("Hour", "Transactions", "Revenue"); err != nil {
+		return err
+	}
+	for _, sale := range sales {
+		if err := w.WriteRow(sale.Hour, sale.Transactions, sale.Revenue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reportService) ExportCashierSalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	sales, err := r.GetCashierSalesService(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader("Cashier", "Transactions", "Revenue"); err != nil {
+		return err
+	}
+	for _, sale := range sales {
+		if err := w.WriteRow(sale.Cashier, sale.Transactions, sale.Revenue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reportService) ExportPaymentMethodSalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	sales, err := r.GetPaymentMethodSalesService(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader("Payment Method", "Transactions", "Revenue"); err != nil {
+		return err
+	}
+	for _, sale := range sales {
+		if err := w.WriteRow(sale.PaymentMethod, sale.Transactions, sale.Revenue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reportService) ExportCategorySalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	sales, err := r.GetCategorySalesService(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader("Category", "Quantity", "Revenue"); err != nil {
+		return err
+	}
+	for _, sale := range sales {
+		if err := w.WriteRow(sale.Category, sale.Quantity, sale.Revenue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reportService) ExportTopProductsService(ctx context.Context, req dto.TopProductsQuery, w utils.ExportWriter) error {
+	products, err := r.GetTopProductsService(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader("Barcode", "Title", "Quantity", "Revenue"); err != nil {
+		return err
+	}
+	for _, product := range products {
+		if err := w.WriteRow(product.BarcodeId, product.Title, product.Quantity, product.Revenue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reportService) ExportStockValuationService(ctx context.Context, storeId uint, w utils.ExportWriter) error {
+	if err := w.WriteHeader("Store", "Barcode", "Title", "Quantity", "Unit Price", "Value"); err != nil {
+		return err
+	}
+	return r.reportRepository.StreamStockValuationRepository(ctx, storeId, func(valuation dto.StockValuation) error {
+		return w.WriteRow(valuation.StoreName, valuation.BarcodeId, valuation.Title, valuation.Quantity, valuation.UnitPrice, valuation.Value)
+	})
+}
+
+// validateReportRange only checks the dates; turning them into day boundaries
+// in the report time zone is left to the database.
+func validateReportRange(req dto.ReportQuery) error {
+	from, err := time.Parse(time.DateOnly, req.From)
+	if err != nil {
+		return dto.ErrInvalidReportRange
+	}
+	to, err := time.Parse(time.DateOnly, req.To)
+	if err != nil {
+		return dto.ErrInvalidReportRange
+	}
+	if from.After(to) {
+		return dto.ErrInvalidReportRange
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"time"
+)
+
+type (
+	StockCountService interface {
+		StartStockCountService(ctx context.Context, req dto.StartStockCountRequest) (dto.StockCountSessionResponse, error)
+		SubmitStockCountService(ctx context.Context, sessionId uint, req dto.SubmitStockCountRequest) error
+		GetStockCountReportService(ctx context.Context, sessionId uint) (dto.StockCountReport, error)
+		GetUncountedProductsService(ctx context.Context, sessionId uint) ([]dto.UncountedProduct, error)
+		ApproveStockCountService(ctx context.Context, sessionId uint, req dto.ApproveStockCountRequest) ([]dto.StockVariance, error)
+	}
+	stockCountService struct {
+		stockCountRepository repository.StockCountRepository
+	}
+)
+
+func NewStockCountService(stockCountRepository repository.StockCountRepository) StockCountService {
+	return &stockCountService{stockCountRepository}
+}
+
+func (s *stockCountService) StartStockCountService(ctx context.Context, req dto.StartStockCountRequest) (dto.StockCountSessionResponse, error) {
+	if req.StoreId == 0 {
+		req.StoreId = constant.DefaultStoreId
+	}
+	if _, ok := s.stockCountRepository.RetrieveOpenSessionRepository(ctx, req.StoreId); ok {
+		return dto.StockCountSessionResponse{}, dto.ErrStockCountAlreadyOpen
+	}
+	session := entity.StockCountSession{
+		Status:    constant.StockCountOpen,
+		StartedAt: time.Now(),
+		Note:      req.Note,
+		StoreId:   req.StoreId,
+	}
+	if err := s.stockCountRepository.CreateSessionRepository(ctx, &session); err != nil {
+		return dto.StockCountSessionResponse{}, err
+	}
+	return toStockCountSessionResponse(session), nil
+}
+
+func (s *stockCountService) SubmitStockCountService(ctx context.Context, sessionId uint, req dto.SubmitStockCountRequest) error {
+	quantities := make(map[string]int64)
+	var barcodeIds []string
+	for _, item := range req.Items {
+		if _, ok := quantities[item.BarcodeId]; !ok {
+			barcodeIds = append(barcodeIds, item.BarcodeId)
+		}
+		quantities[item.BarcodeId] += item.Quantity
+	}
+
+	existing, err := s.stockCountRepository.RetrieveExistingBarcodesRepository(ctx, barcodeIds)
+	if err != nil {
+		return err
+	}
+	if len(existing) != len(barcodeIds) {
+		return dto.ErrUnknownBarcode
+	}
+
+	var items []entity.StockCountItem
+	for _, barcodeId := range barcodeIds {
+		items = append(items, entity.StockCountItem{
+			SessionId:       sessionId,
+			BarcodeId:       barcodeId,
+			DeviceId:        req.DeviceId,
+			CountedBy:       req.CountedBy,
+			CountedQuantity: quantities[barcodeId],
+		})
+	}
+	return s.stockCountRepository.UpsertCountItemsRepository(ctx, sessionId, items)
+}
+
+func (s *stockCountService) GetStockCountReportService(ctx context.Context, sessionId uint) (dto.StockCountReport, error) {
+	session, ok := s.stockCountRepository.RetrieveSessionByIdRepository(ctx, sessionId)
+	if !ok {
+		return dto.StockCountReport{}, dto.ErrStockCountNotFound
+	}
+	variances, err := s.stockCountRepository.RetrieveVariancesRepository(ctx, session)
+	if err != nil {
+		return dto.StockCountReport{}, err
+	}
+	uncounted, err := s.stockCountRepository.RetrieveUncountedProductsRepository(ctx, session)
+	if err != nil {
+		return dto.StockCountReport{}, err
+	}
+	return dto.StockCountReport{
+		Session:   toStockCountSessionResponse(session),
+		Variances: variances,
+		Uncounted: uncounted,
+	}, nil
+}
+
+func (s *stockCountService) GetUncountedProductsService(ctx context.Context, sessionId uint) ([]dto.UncountedProduct, error) {
+	session, ok := s.stockCountRepository.RetrieveSessionByIdRepository(ctx, sessionId)
+	if !ok {
+		return []dto.UncountedProduct{}, dto.ErrStockCountNotFound
+	}
+	return s.stockCountRepository.RetrieveUncountedProductsRepository(ctx, session)
+}
+
+func (s *stockCountService) ApproveStockCountService(ctx context.Context, sessionId uint, req dto.ApproveStockCountRequest) ([]dto.StockVariance, error) {
+	return s.stockCountRepository.ApproveSessionRepository(ctx, sessionId, req.ApprovedBy, req.IncludeUncounted)
+}
+
+func toStockCountSessionResponse(session entity.StockCountSession) dto.StockCountSessionResponse {
+	return dto.StockCountSessionResponse{
+		Id:         session.ID,
+		StoreId:    session.StoreId,
+		Status:     session.Status,
+		StartedAt:  session.StartedAt,
+		ApprovedBy: session.ApprovedBy,
+		ApprovedAt: session.ApprovedAt,
+		Note:       session.Note,
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	StockService interface {
+		GetLowStockProductsService(ctx context.Context, storeId uint) ([]dto.LowStockProduct, error)
+		GetReorderSuggestionService(ctx context.Context, req dto.ReorderSuggestionQuery) (dto.ReorderSuggestion, error)
+		ReceiveStockService(ctx context.Context, req dto.ReceiveStockRequest) (dto.StockBatch, error)
+		GetExpiringBatchesService(ctx context.Context, req dto.ExpiringBatchQuery) ([]dto.ExpiringBatch, error)
+		WriteOffBatchService(ctx context.Context, batchId uint, req dto.WriteOffBatchRequest) (dto.StockBatch, error)
+		GetStockLevelsService(ctx context.Context, barcodeId string) ([]dto.StoreStockLevel, error)
+	}
+	stockService struct {
+		stockRepository repository.StockRepository
+	}
+)
+
+func NewStockService(stockRepository repository.StockRepository) StockService {
+	return &stockService{stockRepository}
+}
+
+func (s *stockService) GetLowStockProductsService(ctx context.Context, storeId uint) ([]dto.LowStockProduct, error) {
+	products, err := s.stockRepository.RetrieveLowStockProductsRepository(ctx, storeId)
+	if err != nil {
+		return []dto.LowStockProduct{}, err
+	}
+	if products == nil {
+		products = []dto.LowStockProduct{}
+	}
+	return products, nil
+}
+
+// GetReorderSuggestionService proposes enough stock to cover the supplier lead
+// time at the average daily sales of the window on top of the minimum stock,
+// never ordering less than the product's reorder quantity.
+func (s *stockService) GetReorderSuggestionService(ctx context.Context, req dto.ReorderSuggestionQuery) (dto.ReorderSuggestion, error) {
+	windowDays := req.WindowDays
+	if windowDays == 0 {
+		windowDays = dto.DEFAULT_REORDER_SUGGESTION_WINDOW_DAYS
+	}
+	storeId := req.StoreId
+	if storeId == 0 {
+		storeId = constant.DefaultStoreId
+	}
+	now := time.Now()
+	candidates, err := s.stockRepository.RetrieveReorderCandidatesRepository(ctx, storeId, now.AddDate(0, 0, -int(windowDays)))
+	if err != nil {
+		return dto.ReorderSuggestion{}, err
+	}
+
+	purchaseOrders := []dto.PurchaseOrderDraft{}
+	orderIndex := make(map[uint]int)
+	for _, candidate := range candidates {
+		averageDailySales := decimal.NewFromInt(candidate.SoldQuantity).Div(decimal.NewFromInt(int64(windowDays)))
+		leadTimeDemand := averageDailySales.Mul(decimal.NewFromInt(int64(candidate.LeadTimeDays))).Ceil().IntPart()
+		suggested := candidate.MinStock + leadTimeDemand - candidate.CurrentStock
+		if suggested < candidate.ReorderQuantity {
+			suggested = candidate.ReorderQuantity
+		}
+		if suggested <= 0 {
+			continue
+		}
+
+		var supplierKey uint
+		if candidate.SupplierId != nil {
+			supplierKey = *candidate.SupplierId
+		}
+		index, ok := orderIndex[supplierKey]
+		if !ok {
+			purchaseOrders = append(purchaseOrders, dto.PurchaseOrderDraft{
+				SupplierId:   candidate.SupplierId,
+				SupplierName: candidate.SupplierName,
+				LeadTimeDays: candidate.LeadTimeDays,
+				Lines:        []dto.ReorderLine{},
+			})
+			index = len(purchaseOrders) - 1
+			orderIndex[supplierKey] = index
+		}
+		purchaseOrders[index].Lines = append(purchaseOrders[index].Lines, dto.ReorderLine{
+			BarcodeId:         candidate.BarcodeId,
+			Title:             candidate.Title,
+			CurrentStock:      candidate.CurrentStock,
+			MinStock:          candidate.MinStock,
+			AverageDailySales: averageDailySales.Round(2),
+			SuggestedQuantity: suggested,
+		})
+	}
+
+	return dto.ReorderSuggestion{
+		StoreId:        storeId,
+		WindowDays:     windowDays,
+		GeneratedAt:    now,
+		PurchaseOrders: purchaseOrders,
+	}, nil
+}
+
+func (s *stockService) ReceiveStockService(ctx context.Context, req dto.ReceiveStockRequest) (dto.StockBatch, error) {
+	batch := entity.StockBatch{
+		BarcodeId:         req.BarcodeId,
+		BatchNumber:       req.BatchNumber,
+		ReceivedQuantity:  req.Quantity,
+		RemainingQuantity: req.Quantity,
+		StoreId:           req.StoreId,
+	}
+	if batch.StoreId == 0 {
+		batch.StoreId = constant.DefaultStoreId
+	}
+	if req.ExpiryDate != "" {
+		expiryDate, err := time.Parse(time.DateOnly, req.ExpiryDate)
+		if err != nil {
+			return dto.StockBatch{}, dto.ErrInvalidExpiryDate
+		}
+		batch.ExpiryDate = &expiryDate
+	}
+	if err := s.stockRepository.ReceiveStockRepository(ctx, &batch, req.Reference); err != nil {
+		return dto.StockBatch{}, err
+	}
+	return toStockBatchResponse(batch), nil
+}
+
+func (s *stockService) GetExpiringBatchesService(ctx context.Context, req dto.ExpiringBatchQuery) ([]dto.ExpiringBatch, error) {
+	withinDays := req.Days
+	if withinDays == 0 {
+		withinDays = dto.DEFAULT_EXPIRING_WITHIN_DAYS
+	}
+	batches, err := s.stockRepository.RetrieveExpiringBatchesRepository(ctx, req.StoreId, withinDays)
+	if err != nil {
+		return []dto.ExpiringBatch{}, err
+	}
+	if batches == nil {
+		batches = []dto.ExpiringBatch{}
+	}
+	return batches, nil
+}
+
+func (s *stockService) WriteOffBatchService(ctx context.Context, batchId uint, req dto.WriteOffBatchRequest) (dto.StockBatch, error) {
+	batch, err := s.stockRepository.WriteOffBatchRepository(ctx, batchId, req.Note)
+	if err != nil {
+		return dto.StockBatch{}, err
+	}
+	return toStockBatchResponse(batch), nil
+}
+
+func (s *stockService) GetStockLevelsService(ctx context.Context, barcodeId string) ([]dto.StoreStockLevel, error) {
+	levels, err := s.stockRepository.RetrieveStockLevelsRepository(ctx, barcodeId)
+	if err != nil {
+		return []dto.StoreStockLevel{}, err
+	}
+	if levels == nil {
+		levels = []dto.StoreStockLevel{}
+	}
+	return levels, nil
+}
+
+func toStockBatchResponse(batch entity.StockBatch) dto.StockBatch {
+	return dto.StockBatch{
+		Id:                batch.ID,
+		BarcodeId:         batch.BarcodeId,
+		BatchNumber:       batch.BatchNumber,
+		ExpiryDate:        batch.ExpiryDate,
+		ReceivedQuantity:  batch.ReceivedQuantity,
+		RemainingQuantity: batch.RemainingQuantity,
+		StoreId:           batch.StoreId,
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+)
+
+type (
+	StockTransferService interface {
+		CreateStockTransferService(ctx context.Context, req dto.CreateStockTransferRequest) (dto.StockTransferResponse, error)
+		GetStockTransfersService(ctx context.Context, req dto.StockTransferQuery) ([]dto.StockTransferResponse, error)
+		GetStockTransferDetailService(ctx context.Context, transferId uint) (dto.StockTransferResponse, error)
+		ReceiveStockTransferService(ctx context.Context, transferId uint, req dto.ReceiveStockTransferRequest) (dto.StockTransferResponse, error)
+	}
+	stockTransferService struct {
+		stockTransferRepository repository.StockTransferRepository
+	}
+)
+
+func NewStockTransferService(stockTransferRepository repository.StockTransferRepository) StockTransferService {
+	return &stockTransferService{stockTransferRepository}
+}
+
+func (s *stockTransferService) CreateStockTransferService(ctx context.Context, req dto.CreateStockTransferRequest) (dto.StockTransferResponse, error) {
+	if req.FromStoreId == req.ToStoreId {
+		return dto.StockTransferResponse{}, dto.ErrSameStoreTransfer
+	}
+	quantities := make(map[string]int64)
+	for _, item := range req.Items {
+		quantities[item.BarcodeId] += item.Quantity
+	}
+	transfer := entity.StockTransfer{
+		FromStoreId: req.FromStoreId,
+		ToStoreId:   req.ToStoreId,
+		Status:      constant.TransferInTransit,
+		CreatedBy:   req.CreatedBy,
+		Note:        req.Note,
+	}
+	if err := s.stockTransferRepository.CreateTransferRepository(ctx, &transfer, quantities); err != nil {
+		return dto.StockTransferResponse{}, err
+	}
+	return toStockTransferResponse(transfer), nil
+}
+
+func (s *stockTransferService) GetStockTransfersService(ctx context.Context, req dto.StockTransferQuery) ([]dto.StockTransferResponse, error) {
+	transfers, err := s.stockTransferRepository.RetrieveTransfersRepository(ctx, req.Status, req.StoreId)
+	if err != nil {
+		return []dto.StockTransferResponse{}, err
+	}
+	finalTransfers := []dto.StockTransferResponse{}
+	for _, transfer := range transfers {
+		finalTransfers = append(finalTransfers, toStockTransferResponse(transfer))
+	}
+	return finalTransfers, nil
+}
+
+func (s *stockTransferService) GetStockTransferDetailService(ctx context.Context, transferId uint) (dto.StockTransferResponse, error) {
+	transfer, ok := s.stockTransferRepository.RetrieveTransferByIdRepository(ctx, transferId)
+	if !ok {
+		return dto.StockTransferResponse{}, dto.ErrTransferNotFound
+	}
+	return toStockTransferResponse(transfer), nil
+}
+
+func (s *stockTransferService) ReceiveStockTransferService(ctx context.Context, transferId uint, req dto.ReceiveStockTransferRequest) (dto.StockTransferResponse, error) {
+	transfer, err := s.stockTransferRepository.ReceiveTransferRepository(ctx, transferId, req.ReceivedBy)
+	if err != nil {
+		return dto.StockTransferResponse{}, err
+	}
+	return toStockTransferResponse(transfer), nil
+}
+
+func toStockTransferResponse(transfer entity.StockTransfer) dto.StockTransferResponse {
+	items := []dto.StockTransferItem{}
+	for _, item := range transfer.Items {
+		items = append(items, dto.StockTransferItem{
+			BarcodeId:     item.BarcodeId,
+			Quantity:      item.Quantity,
+			SourceBatchId: item.SourceBatchId,
+		})
+	}
+	return dto.StockTransferResponse{
+		Id:          transfer.ID,
+		FromStoreId: transfer.FromStoreId,
+		ToStoreId:   transfer.ToStoreId,
+		Status:      transfer.Status,
+		CreatedBy:   transfer.CreatedBy,
+		ReceivedBy:  transfer.ReceivedBy,
+		ReceivedAt:  transfer.ReceivedAt,
+		Note:        transfer.Note,
+		CreatedAt:   transfer.CreatedAt,
+		Items:       items,
+	}
+}
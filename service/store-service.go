@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+)
+
+type (
+	StoreService interface {
+		GetStoresService(ctx context.Context) ([]dto.Store, error)
+		CreateStoreService(ctx context.Context, req dto.AddStoreRequest) (dto.Store, error)
+		UpdateStoreService(ctx context.Context, storeId uint, req dto.UpdateStoreRequest) error
+		GetStorePricesService(ctx context.Context, storeId uint) ([]dto.StorePrice, error)
+		SetStorePriceService(ctx context.Context, storeId uint, barcodeId string, req dto.SetStorePriceRequest) error
+		DeleteStorePriceService(ctx context.Context, storeId uint, barcodeId string) error
+	}
+	storeService struct {
+		storeRepository   repository.StoreRepository
+		productRepository repository.ProductRepository
+	}
+)
+
+func NewStoreService(storeRepository repository.StoreRepository, productRepository repository.ProductRepository) StoreService {
+	return &storeService{
+		storeRepository,
+		productRepository,
+	}
+}
+
+func (s *storeService) GetStoresService(ctx context.Context) ([]dto.Store, error) {
+	stores, err := s.storeRepository.RetrieveStoresRepository(ctx)
+	if err != nil {
+		return []dto.Store{}, err
+	}
+	if len(stores) < 1 {
+		return []dto.Store{}, dto.ErrStoresNotFound
+	}
+	var finalStores []dto.Store
+	for _, store := range stores {
+		finalStores = append(finalStores, toStoreResponse(store))
+	}
+	return finalStores, nil
+}
+
+func (s *storeService) CreateStoreService(ctx context.Context, req dto.AddStoreRequest) (dto.Store, error) {
+	if req.Type == "" {
+		req.Type = constant.StoreTypeStore
+	}
+	if !isValidStoreType(req.Type) {
+		return dto.Store{}, dto.ErrInvalidStoreType
+	}
+	if _, ok := s.storeRepository.RetrieveStoreByCodeRepository(ctx, req.Code); ok {
+		return dto.Store{}, dto.ErrStoreExist
+	}
+	store := entity.Store{
+		Code:    req.Code,
+		Name:    req.Name,
+		Type:    req.Type,
+		Address: req.Address,
+	}
+	if err := s.storeRepository.CreateStoreRepository(ctx, &store); err != nil {
+		return dto.Store{}, err
+	}
+	return toStoreResponse(store), nil
+}
+
+func (s *storeService) UpdateStoreService(ctx context.Context, storeId uint, req dto.UpdateStoreRequest) error {
+	if _, ok := s.storeRepository.RetrieveStoreByIdRepository(ctx, storeId); !ok {
+		return dto.ErrStoreDoesntExist
+	}
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Type != nil {
+		if !isValidStoreType(*req.Type) {
+			return dto.ErrInvalidStoreType
+		}
+		updates["type"] = *req.Type
+	}
+	if req.Address != nil {
+		updates["address"] = *req.Address
+	}
+	if len(updates) == 0 {
+		return dto.ErrNoChangesRequest
+	}
+	return s.storeRepository.UpdateStoreRepository(ctx, storeId, &updates)
+}
+
+func (s *storeService) GetStorePricesService(ctx context.Context, storeId uint) ([]dto.StorePrice, error) {
+	if _, ok := s.storeRepository.RetrieveStoreByIdRepository(ctx, storeId); !ok {
+		return []dto.StorePrice{}, dto.ErrStoreDoesntExist
+	}
+	prices, err := s.storeRepository.RetrieveStorePricesRepository(ctx, storeId)
+	if err != nil {
+		return []dto.StorePrice{}, err
+	}
+	if prices == nil {
+		prices = []dto.StorePrice{}
+	}
+	return prices, nil
+}
+
+func (s *storeService) SetStorePriceService(ctx context.Context, storeId uint, barcodeId string, req dto.SetStorePriceRequest) error {
+	if req.Price.IsNegative() {
+		return dto.ErrInvalidPrice
+	}
+	if _, ok := s.storeRepository.RetrieveStoreByIdRepository(ctx, storeId); !ok {
+		return dto.ErrStoreDoesntExist
+	}
+	if _, ok := s.productRepository.RetrieveProductByBarcodeId(ctx, &barcodeId); !ok {
+		return dto.ErrProductDoesntExist
+	}
+	return s.storeRepository.UpsertStorePriceRepository(ctx, &entity.StorePrice{
+		StoreId:   storeId,
+		BarcodeId: barcodeId,
+		Price:     req.Price,
+	})
+}
+
+func (s *storeService) DeleteStorePriceService(ctx context.Context, storeId uint, barcodeId string) error {
+	return s.storeRepository.DeleteStorePriceRepository(ctx, storeId, barcodeId)
+}
+
+func isValidStoreType(storeType string) bool {
+	return storeType == constant.StoreTypeStore || storeType == constant.StoreTypeWarehouse
+}
+
+func toStoreResponse(store entity.Store) dto.Store {
+	return dto.Store{
+		Id:      store.ID,
+		Code:    store.Code,
+		Name:    store.Name,
+		Type:    store.Type,
+		Address: store.Address,
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+)
+
+type (
+	SupplierService interface {
+		GetSuppliersService(ctx context.Context) ([]dto.Supplier, error)
+		CreateSupplierService(ctx context.Context, req dto.AddSupplierRequest) (dto.Supplier, error)
+		UpdateSupplierService(ctx context.Context, supplierId uint, req dto.UpdateSupplierRequest) error
+	}
+	supplierService struct {
+		supplierRepository repository.SupplierRepository
+	}
+)
+
+func NewSupplierService(supplierRepository repository.SupplierRepository) SupplierService {
+	return &supplierService{supplierRepository}
+}
+
+func (s *supplierService) GetSuppliersService(ctx context.Context) ([]dto.Supplier, error) {
+	suppliers, err := s.supplierRepository.RetrieveSuppliersRepository(ctx)
+	if err != nil {
+		return []dto.Supplier{}, err
+	}
+	if len(suppliers) < 1 {
+		return []dto.Supplier{}, dto.ErrSuppliersNotFound
+	}
+	var finalSuppliers []dto.Supplier
+	for _, supplier := range suppliers {
+		finalSuppliers = append(finalSuppliers, toSupplierResponse(supplier))
+	}
+	return finalSuppliers, nil
+}
+
+func (s *supplierService) CreateSupplierService(ctx context.Context, req dto.AddSupplierRequest) (dto.Supplier, error) {
+	supplier := entity.Supplier{
+		Name:         req.Name,
+		Phone:        req.Phone,
+		LeadTimeDays: req.LeadTimeDays,
+	}
+	if err := s.supplierRepository.CreateSupplierRepository(ctx, &supplier); err != nil {
+		return dto.Supplier{}, err
+	}
+	return toSupplierResponse(supplier), nil
+}
+
+func (s *supplierService) UpdateSupplierService(ctx context.Context, supplierId uint, req dto.UpdateSupplierRequest) error {
+	if _, ok := s.supplierRepository.RetrieveSupplierByIdRepository(ctx, supplierId); !ok {
+		return dto.ErrSupplierDoesntExist
+	}
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Phone != nil {
+		updates["phone"] = *req.Phone
+	}
+	if req.LeadTimeDays != nil {
+		updates["lead_time_days"] = *req.LeadTimeDays
+	}
+	if len(updates) == 0 {
+		return dto.ErrNoChangesRequest
+	}
+	return s.supplierRepository.UpdateSupplierRepository(ctx, supplierId, &updates)
+}
+
+func toSupplierResponse(supplier entity.Supplier) dto.Supplier {
+	return dto.Supplier{
+		Id:           supplier.ID,
+		Name:         supplier.Name,
+		Phone:        supplier.Phone,
+		LeadTimeDays: supplier.LeadTimeDays,
+	}
+}
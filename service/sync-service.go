@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"tiga-putra-cashier-be/utils"
+	"time"
+)
+
+const syncTokenSort = "sync"
+
+type (
+	SyncService interface {
+		SyncProductsService(ctx context.Context, since string) (dto.ProductSyncResponse, error)
+	}
+	syncService struct {
+		syncRepository repository.SyncRepository
+		safetyWindow   time.Duration
+	}
+)
+
+// NewSyncService holds back changes younger than the longest a write can stay
+// uncommitted. Such a write can carry an older timestamp than one already
+// visible, and handing out a token past it would make clients skip it.
+func NewSyncService(syncRepository repository.SyncRepository, cfg *config.Config) SyncService {
+	timeouts := cfg.Database.Timeouts
+	safetyWindow := max(timeouts.Query, timeouts.Bulk, timeouts.Tx) + constant.SyncSafetyMargin
+	return &syncService{syncRepository, safetyWindow}
+}
+
+// SyncProductsService returns the catalog changes a client hasn't seen yet.
+// Without a token it starts from the beginning, which is how a till fills an
+// empty cache. Store price overrides aren't part of the catalog and don't
+// sync here.
+func (s *syncService) SyncProductsService(ctx context.Context, since string) (dto.ProductSyncResponse, error) {
+	var after *utils.Cursor
+	if since != "" {
+		cursor, err := utils.DecodeCursor(since)
+		if err != nil || cursor.Sort != syncTokenSort {
+			return dto.ProductSyncResponse{}, dto.ErrInvalidSyncToken
+		}
+		after = &cursor
+	}
+
+	upTo := time.Now().Add(-s.safetyWindow)
+	products, last, err := s.syncRepository.RetrieveProductChangesRepository(ctx, after, upTo, constant.SyncBatchSize)
+	if err != nil {
+		return dto.ProductSyncResponse{}, err
+	}
+
+	result := dto.ProductSyncResponse{
+		Products:  []dto.ProductWithoutTimeStamp{},
+		Deleted:   []string{},
+		NextToken: since,
+		HasMore:   len(products) == constant.SyncBatchSize,
+	}
+	var changed []entity.Product
+	for _, product := range products {
+		if product.DeletedAt.Valid {
+			result.Deleted = append(result.Deleted, product.BarcodeId)
+		} else {
+			changed = append(changed, product)
+		}
+	}
+	if len(changed) > 0 {
+		result.Products = toProductResponses(changed)
+	}
+	if last != nil {
+		last.Sort = syncTokenSort
+		result.NextToken = utils.EncodeCursor(*last)
+	}
+	return result, nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	TransactionService interface {
+		CreateTransactionService(ctx context.Context, req dto.CreateTransactionRequest) (dto.TransactionResponse, error)
+		GetTransactionDetailService(ctx context.Context, transactionId uint) (dto.TransactionResponse, error)
+		UploadOfflineSalesService(ctx context.Context, req dto.UploadOfflineSalesRequest) ([]dto.OfflineSaleResult, error)
+		GetSaleConflictsService(ctx context.Context, query dto.SaleConflictQuery) ([]dto.SaleConflictResponse, error)
+		ResolveSaleConflictService(ctx context.Context, conflictId uint, req dto.ResolveSaleConflictRequest) (dto.SaleConflictResponse, error)
+	}
+	transactionService struct {
+		transactionRepository repository.TransactionRepository
+		productRepository     repository.ProductRepository
+	}
+)
+
+func NewTransactionService(transactionRepository repository.TransactionRepository, productRepository repository.ProductRepository) TransactionService {
+	return &transactionService{
+		transactionRepository,
+		productRepository,
+	}
+}
+
+func (t *transactionService) CreateTransactionService(ctx context.Context, req dto.CreateTransactionRequest) (dto.TransactionResponse, error) {
+	if req.AllowExpired && req.OverrideBy == "" {
+		return dto.TransactionResponse{}, dto.ErrOverrideApproverRequired
+	}
+
+	quantities := make(map[string]int64)
+	var barcodeIds []string
+	for _, item := range req.Items {
+		if _, ok := quantities[item.BarcodeId]; !ok {
+			barcodeIds = append(barcodeIds, item.BarcodeId)
+		}
+		quantities[item.BarcodeId] += item.Quantity
+	}
+
+	if req.StoreId == 0 {
+		req.StoreId = constant.DefaultStoreId
+	}
+	prices, err := t.productRepository.RetrieveStorePriceOverridesRepository(ctx, req.StoreId, barcodeIds)
+	if err != nil {
+		return dto.TransactionResponse{}, err
+	}
+
+	transaction := entity.Transaction{
+		Cashier:       req.Cashier,
+		PaymentMethod: req.PaymentMethod,
+		Total:         decimal.Zero,
+		StoreId:       req.StoreId,
+	}
+	if req.AllowExpired {
+		transaction.ExpiredOverrideBy = req.OverrideBy
+	}
+	for _, barcodeId := range barcodeIds {
+		product, ok := t.productRepository.RetrieveProductByBarcodeId(ctx, &barcodeId)
+		if !ok {
+			return dto.TransactionResponse{}, dto.ErrProductDoesntExist
+		}
+		unitPrice := product.Price
+		if price, ok := prices[barcodeId]; ok {
+			unitPrice = price
+		}
+		subtotal := unitPrice.Mul(decimal.NewFromInt(quantities[barcodeId]))
+		transaction.Items = append(transaction.Items, entity.TransactionItem{
+			BarcodeId: barcodeId,
+			Title:     product.Title,
+			Quantity:  quantities[barcodeId],
+			UnitPrice: unitPrice,
+			Subtotal:  subtotal,
+		})
+		transaction.Total = transaction.Total.Add(subtotal)
+	}
+
+	if err := t.transactionRepository.CreateTransactionRepository(ctx, &transaction, req.AllowExpired); err != nil {
+		return dto.TransactionResponse{}, err
+	}
+	return toTransactionResponse(transaction), nil
+}
+
+func (t *transactionService) GetTransactionDetailService(ctx context.Context, transactionId uint) (dto.TransactionResponse, error) {
+	transaction, ok := t.transactionRepository.RetrieveTransactionByIdRepository(ctx, transactionId)
+	if !ok {
+		return dto.TransactionResponse{}, dto.ErrTransactionNotFound
+	}
+	return toTransactionResponse(transaction), nil
+}
+
+// UploadOfflineSalesService applies the sales a till recorded offline one by
+// one and reports what happened to each. Prices are checked against what the
+// store sold at at the time of sale, which is what an offline till knows.
+func (t *transactionService) UploadOfflineSalesService(ctx context.Context, req dto.UploadOfflineSalesRequest) ([]dto.OfflineSaleResult, error) {
+	if req.StoreId == 0 {
+		req.StoreId = constant.DefaultStoreId
+	}
+	results := make([]dto.OfflineSaleResult, 0, len(req.Sales))
+	for _, sale := range req.Sales {
+		result, err := t.uploadOfflineSale(ctx, req.StoreId, sale)
+		if err == dto.ErrStoreDoesntExist {
+			return nil, err
+		}
+		if err != nil {
+			result = dto.OfflineSaleResult{ClientId: sale.ClientId, Status: constant.OfflineSaleFailed, Error: err.Error()}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (t *transactionService) uploadOfflineSale(ctx context.Context, storeId uint, sale dto.OfflineSaleRequest) (dto.OfflineSaleResult, error) {
+	var barcodeIds []string
+	for _, item := range sale.Items {
+		barcodeIds = append(barcodeIds, item.BarcodeId)
+	}
+	prices, err := t.productRepository.RetrievePricesAtRepository(ctx, storeId, barcodeIds, *sale.SoldAt)
+	if err != nil {
+		return dto.OfflineSaleResult{}, err
+	}
+
+	clientId := sale.ClientId
+	transaction := entity.Transaction{
+		ClientId:      &clientId,
+		Cashier:       sale.Cashier,
+		PaymentMethod: sale.PaymentMethod,
+		Total:         decimal.Zero,
+		StoreId:       storeId,
+	}
+	transaction.CreatedAt = *sale.SoldAt
+	var conflicts []entity.SaleConflict
+	for _, item := range sale.Items {
+		title := item.Title
+		product, ok := prices[item.BarcodeId]
+		switch {
+		case !ok:
+			conflicts = append(conflicts, entity.SaleConflict{BarcodeId: item.BarcodeId, Type: constant.ConflictUnknownProduct, Quantity: item.Quantity, Detail: dto.ErrProductDoesntExist.Error()})
+		case product.Deleted:
+			title = product.Title
+			conflicts = append(conflicts, entity.SaleConflict{BarcodeId: item.BarcodeId, Type: constant.ConflictProductDeleted, Quantity: item.Quantity, Detail: "Product has been deleted"})
+		default:
+			title = product.Title
+		}
+		unitPrice := *item.UnitPrice
+		if ok && !unitPrice.Equal(product.Price) {
+			conflicts = append(conflicts, entity.SaleConflict{
+				BarcodeId: item.BarcodeId,
+				Type:      constant.ConflictPriceMismatch,
+				Quantity:  item.Quantity,
+				Detail:    fmt.Sprintf("Sold at %s, store price was %s", unitPrice, product.Price),
+			})
+		}
+		subtotal := unitPrice.Mul(decimal.NewFromInt(item.Quantity))
+		transaction.Items = append(transaction.Items, entity.TransactionItem{
+			BarcodeId: item.BarcodeId,
+			Title:     title,
+			Quantity:  item.Quantity,
+			UnitPrice: unitPrice,
+			Subtotal:  subtotal,
+		})
+		transaction.Total = transaction.Total.Add(subtotal)
+	}
+
+	conflicts, err = t.transactionRepository.CreateOfflineTransactionRepository(ctx, &transaction, conflicts)
+	if err == dto.ErrTransactionUploaded {
+		return dto.OfflineSaleResult{ClientId: sale.ClientId, Status: constant.OfflineSaleDuplicate, TransactionId: transaction.ID}, nil
+	}
+	if err != nil {
+		return dto.OfflineSaleResult{}, err
+	}
+	result := dto.OfflineSaleResult{ClientId: sale.ClientId, Status: constant.OfflineSaleAccepted, TransactionId: transaction.ID}
+	if len(conflicts) > 0 {
+		result.Status = constant.OfflineSaleConflict
+		result.Conflicts = toSaleConflictResponses(conflicts)
+	}
+	return result, nil
+}
+
+func (t *transactionService) GetSaleConflictsService(ctx context.Context, query dto.SaleConflictQuery) ([]dto.SaleConflictResponse, error) {
+	conflicts, err := t.transactionRepository.RetrieveSaleConflictsRepository(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return toSaleConflictResponses(conflicts), nil
+}
+
+func (t *transactionService) ResolveSaleConflictService(ctx context.Context, conflictId uint, req dto.ResolveSaleConflictRequest) (dto.SaleConflictResponse, error) {
+	conflict, err := t.transactionRepository.ResolveSaleConflictRepository(ctx, conflictId, req.ResolvedBy)
+	if err != nil {
+		return dto.SaleConflictResponse{}, err
+	}
+	return toSaleConflictResponse(conflict), nil
+}
+
+func toSaleConflictResponses(conflicts []entity.SaleConflict) []dto.SaleConflictResponse {
+	responses := []dto.SaleConflictResponse{}
+	for _, conflict := range conflicts {
+		responses = append(responses, toSaleConflictResponse(conflict))
+	}
+	return responses
+}
+
+func toSaleConflictResponse(conflict entity.SaleConflict) dto.SaleConflictResponse {
+	return dto.SaleConflictResponse{
+		Id:            conflict.ID,
+		TransactionId: conflict.TransactionId,
+		BarcodeId:     conflict.BarcodeId,
+		Type:          conflict.Type,
+		Quantity:      conflict.Quantity,
+		Detail:        conflict.Detail,
+		CreatedAt:     conflict.CreatedAt,
+		ResolvedBy:    conflict.ResolvedBy,
+		ResolvedAt:    conflict.ResolvedAt,
+	}
+}
+
+func toTransactionResponse(transaction entity.Transaction) dto.TransactionResponse {
+	items := []dto.TransactionItemResponse{}
+	for _, item := range transaction.Items {
+		items = append(items, dto.TransactionItemResponse{
+			BarcodeId: item.BarcodeId,
+			Title:     item.Title,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Subtotal:  item.Subtotal,
+		})
+	}
+	return dto.TransactionResponse{
+		Id:                transaction.ID,
+		StoreId:           transaction.StoreId,
+		Cashier:           transaction.Cashier,
+		PaymentMethod:     transaction.PaymentMethod,
+		Total:             transaction.Total,
+		ExpiredOverrideBy: transaction.ExpiredOverrideBy,
+		CreatedAt:         transaction.CreatedAt,
+		Items:             items,
+	}
+}
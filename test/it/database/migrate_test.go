@@ -0,0 +1,72 @@
+package database_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"tiga-putra-cashier-be/database"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// baselineProducts is the products table the first release created with
+// AutoMigrate, before versioned migrations.
+const baselineProducts = `CREATE TABLE "products" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"barcode_id" text,"image" text,"title" text,"price" text,"description" text,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "idx_products_barcode_id" ON "products" ("barcode_id");
+CREATE INDEX "idx_products_deleted_at" ON "products" ("deleted_at")`
+
+type migrateTestSuite struct {
+	suite.Suite
+	dbConn   *gorm.DB
+	migrator *database.Migrator
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, &migrateTestSuite{})
+}
+
+func (m *migrateTestSuite) SetupSuite() {
+	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=Asia/Jakarta",
+		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASS"), os.Getenv("DB_NAME"), os.Getenv("DB_PORT"))
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  dsn,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	m.Require().NoError(err)
+
+	m.dbConn = db
+	m.migrator, err = database.NewMigrator(db, database.MigrationFiles)
+	m.Require().NoError(err)
+	m.Require().NoError(m.migrator.To(0))
+}
+
+func (m *migrateTestSuite) TearDownTest() {
+	m.NoError(m.migrator.To(0))
+}
+
+func (m *migrateTestSuite) TestUp_UpgradesBaselineSchema() {
+	m.Require().NoError(m.dbConn.Exec(baselineProducts).Error)
+	m.Require().NoError(m.dbConn.Exec(`INSERT INTO products (barcode_id, title, price) VALUES ('1', 'title-1', '1500.5')`).Error)
+
+	m.Require().NoError(m.migrator.Up(0))
+
+	var columns []string
+	m.Require().NoError(m.dbConn.Raw(`SELECT column_name FROM information_schema.columns
+		WHERE table_name = 'products' AND column_name IN ('category', 'min_stock', 'reorder_quantity', 'supplier_id', 'version')
+		ORDER BY column_name`).Scan(&columns).Error)
+	m.Equal([]string{"category", "min_stock", "reorder_quantity", "supplier_id", "version"}, columns)
+	var priceType string
+	m.Require().NoError(m.dbConn.Raw(`SELECT data_type FROM information_schema.columns
+		WHERE table_name = 'products' AND column_name = 'price'`).Scan(&priceType).Error)
+	m.Equal("numeric", priceType)
+
+	var product struct {
+		Price   string
+		Version uint
+	}
+	m.Require().NoError(m.dbConn.Raw(`SELECT price::text AS price, version FROM products WHERE barcode_id = '1'`).Scan(&product).Error)
+	m.Equal("1500.50", product.Price)
+	m.Equal(uint(1), product.Version)
+}
@@ -23,7 +23,8 @@ import (
 
 type e2eProductTestSuite struct {
 	suite.Suite
-	dbConn *gorm.DB
+	dbConn   *gorm.DB
+	migrator *database.Migrator
 }
 
 func TestE2ETestSuite(t *testing.T) {
@@ -46,8 +47,10 @@ func (e *e2eProductTestSuite) SetupSuite() {
 	e.NoError(err)
 
 	e.dbConn = db
+	e.migrator, err = database.NewMigrator(e.dbConn, database.MigrationFiles)
+	e.Require().NoError(err)
 
-	err = database.MigrateUp(e.dbConn)
+	err = e.migrator.Up(0)
 	e.Require().NoError(err)
 
 	container := di.BuildContainer()
@@ -67,12 +70,12 @@ func (e *e2eProductTestSuite) TearDownSuite() {
 }
 
 func (e *e2eProductTestSuite) SetupTest() {
-	err := database.MigrateUp(e.dbConn)
+	err := e.migrator.Up(0)
 	e.Require().NoError(err)
 }
 
 func (e *e2eProductTestSuite) TearDownTest() {
-	e.NoError(database.MigrateDown(e.dbConn))
+	e.NoError(e.migrator.To(0))
 }
 
 func (e *e2eProductTestSuite) Test_E2EProduct_GetProduct() {
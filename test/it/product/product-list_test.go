@@ -0,0 +1,47 @@
+package product_test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/shopspring/decimal"
+)
+
+// Test_E2EProduct_ListByPrice checks prices are compared as numbers, so 900
+// sorts before 1000 and the cursor continues after the right product.
+func (e *e2eProductTestSuite) Test_E2EProduct_ListByPrice() {
+	e.NoError(e.dbConn.Create(&[]entity.Product{
+		{BarcodeId: "1", Title: "title-1", Price: decimal.NewFromInt32(1000)},
+		{BarcodeId: "2", Title: "title-2", Price: decimal.NewFromInt32(900)},
+		{BarcodeId: "3", Title: "title-3", Price: decimal.NewFromInt32(20000)},
+		{BarcodeId: "4", Title: "title-4", Price: decimal.NewFromInt32(50)},
+	}).Error)
+	productRepository := repository.NewProductRepository(e.dbConn, config.Default())
+	minPrice := decimal.NewFromInt32(100)
+	query := &dto.ProductListQuery{Sort: "price", MinPrice: &minPrice}
+
+	products, next, err := productRepository.RetrieveProductsAfterRepository(context.Background(), query, nil, 2)
+
+	e.Require().NoError(err)
+	e.Equal([]string{"2", "1"}, barcodeIds(products))
+	cursor, err := utils.DecodeCursor(next)
+	e.Require().NoError(err)
+
+	products, next, err = productRepository.RetrieveProductsAfterRepository(context.Background(), query, &cursor, 2)
+
+	e.Require().NoError(err)
+	e.Equal([]string{"3"}, barcodeIds(products))
+	e.Empty(next)
+}
+
+func barcodeIds(products []entity.Product) []string {
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.BarcodeId
+	}
+	return ids
+}
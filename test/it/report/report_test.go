@@ -0,0 +1,106 @@
+package report_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/database"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// reportTestSuite runs the report queries against Postgres, which the mocked
+// unit tests can't tell apart from SQL it would reject.
+type reportTestSuite struct {
+	suite.Suite
+	dbConn     *gorm.DB
+	migrator   *database.Migrator
+	repository repository.ReportRepository
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, &reportTestSuite{})
+}
+
+func (r *reportTestSuite) SetupSuite() {
+	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=Asia/Jakarta",
+		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASS"), os.Getenv("DB_NAME"), os.Getenv("DB_PORT"))
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  dsn,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	r.Require().NoError(err)
+
+	r.dbConn = db
+	r.migrator, err = database.NewMigrator(db, database.MigrationFiles)
+	r.Require().NoError(err)
+	r.repository = repository.NewReportRepository(db, config.Default())
+}
+
+func (r *reportTestSuite) SetupTest() {
+	r.Require().NoError(r.migrator.Up(0))
+}
+
+func (r *reportTestSuite) TearDownTest() {
+	r.NoError(r.migrator.To(0))
+}
+
+func (r *reportTestSuite) today() dto.ReportQuery {
+	zone, err := time.LoadLocation(constant.ReportTimeZone)
+	r.Require().NoError(err)
+	today := time.Now().In(zone).Format(time.DateOnly)
+	return dto.ReportQuery{From: today, To: today}
+}
+
+func (r *reportTestSuite) TestSalesByPeriod() {
+	for _, total := range []string{"10000.50", "2500.25"} {
+		r.Require().NoError(r.dbConn.Create(&entity.Transaction{
+			Cashier:       "cashier",
+			PaymentMethod: "cash",
+			Total:         decimal.RequireFromString(total),
+			StoreId:       constant.DefaultStoreId,
+		}).Error)
+	}
+
+	totals, err := r.repository.RetrieveSalesByPeriodRepository(context.Background(), r.today(), "day")
+
+	r.Require().NoError(err)
+	r.Require().Len(totals, 1)
+	r.Equal(int64(2), totals[0].Transactions)
+	r.Equal("12500.75", totals[0].Revenue.String())
+	r.Equal("6250.38", totals[0].AverageTicket.String())
+}
+
+func (r *reportTestSuite) TestStockValuation() {
+	r.Require().NoError(r.dbConn.Create(&[]entity.Product{
+		{BarcodeId: "1", Title: "title-1", Price: decimal.RequireFromString("1500.50")},
+		{BarcodeId: "2", Title: "title-2", Price: decimal.RequireFromString("2000")},
+	}).Error)
+	r.Require().NoError(r.dbConn.Create(&entity.StorePrice{StoreId: constant.DefaultStoreId, BarcodeId: "2", Price: decimal.RequireFromString("1800")}).Error)
+	r.Require().NoError(r.dbConn.Create(&[]entity.StockMovement{
+		{BarcodeId: "1", Quantity: 3, Type: constant.MovementReceipt, StoreId: constant.DefaultStoreId},
+		{BarcodeId: "2", Quantity: 2, Type: constant.MovementReceipt, StoreId: constant.DefaultStoreId},
+	}).Error)
+
+	var valuations []dto.StockValuation
+	err := r.repository.StreamStockValuationRepository(context.Background(), constant.DefaultStoreId, func(valuation dto.StockValuation) error {
+		valuations = append(valuations, valuation)
+		return nil
+	})
+
+	r.Require().NoError(err)
+	r.Require().Len(valuations, 2)
+	r.Equal("4501.5", valuations[0].Value.String())
+	r.Equal("1800", valuations[1].UnitPrice.String())
+	r.Equal("3600", valuations[1].Value.String())
+}
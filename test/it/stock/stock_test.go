@@ -0,0 +1,90 @@
+package stock_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/database"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// stockTestSuite follows stock through several repositories against Postgres,
+// where batches and movements have to stay in step.
+type stockTestSuite struct {
+	suite.Suite
+	dbConn          *gorm.DB
+	migrator        *database.Migrator
+	stockRepository repository.StockRepository
+	countRepository repository.StockCountRepository
+}
+
+func TestStockTestSuite(t *testing.T) {
+	suite.Run(t, &stockTestSuite{})
+}
+
+func (s *stockTestSuite) SetupSuite() {
+	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=Asia/Jakarta",
+		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASS"), os.Getenv("DB_NAME"), os.Getenv("DB_PORT"))
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  dsn,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	s.Require().NoError(err)
+
+	s.dbConn = db
+	s.migrator, err = database.NewMigrator(db, database.MigrationFiles)
+	s.Require().NoError(err)
+	s.stockRepository = repository.NewStockRepository(db, config.Default())
+	s.countRepository = repository.NewStockCountRepository(db, config.Default())
+}
+
+func (s *stockTestSuite) SetupTest() {
+	s.Require().NoError(s.migrator.Up(0))
+}
+
+func (s *stockTestSuite) TearDownTest() {
+	s.NoError(s.migrator.To(0))
+}
+
+func (s *stockTestSuite) currentStock(barcodeId string) int64 {
+	var stock int64
+	s.Require().NoError(s.dbConn.Model(&entity.StockMovement{}).Where("store_id = ? AND barcode_id = ?", constant.DefaultStoreId, barcodeId).
+		Select("COALESCE(SUM(quantity), 0)").Scan(&stock).Error)
+	return stock
+}
+
+// TestCountThenWriteOff checks a shortage found by a stock count leaves the
+// batch, so writing the batch off afterwards can't take the stock below zero.
+func (s *stockTestSuite) TestCountThenWriteOff() {
+	ctx := context.Background()
+	s.Require().NoError(s.dbConn.Create(&entity.Product{BarcodeId: "1", Title: "title-1", Price: decimal.NewFromInt(1000)}).Error)
+	batch := entity.StockBatch{BarcodeId: "1", BatchNumber: "LOT-1", ReceivedQuantity: 10, RemainingQuantity: 10, StoreId: constant.DefaultStoreId}
+	s.Require().NoError(s.stockRepository.ReceiveStockRepository(ctx, &batch, "receipt-1"))
+
+	session := entity.StockCountSession{Status: constant.StockCountOpen, StartedAt: time.Now(), StoreId: constant.DefaultStoreId}
+	s.Require().NoError(s.countRepository.CreateSessionRepository(ctx, &session))
+	s.Require().NoError(s.countRepository.UpsertCountItemsRepository(ctx, session.ID, []entity.StockCountItem{
+		{BarcodeId: "1", DeviceId: "device-1", CountedBy: "cashier", CountedQuantity: 7},
+	}))
+	_, err := s.countRepository.ApproveSessionRepository(ctx, session.ID, "supervisor", false)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.dbConn.First(&batch, batch.ID).Error)
+	s.Equal(int64(7), batch.RemainingQuantity)
+	s.Equal(int64(7), s.currentStock("1"))
+
+	_, err = s.stockRepository.WriteOffBatchRepository(ctx, batch.ID, "expired")
+
+	s.Require().NoError(err)
+	s.Equal(int64(0), s.currentStock("1"))
+}
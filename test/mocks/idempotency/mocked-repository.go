@@ -0,0 +1,27 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"tiga-putra-cashier-be/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) ReserveIdempotencyKeyRepository(ctx context.Context, key, requestHash string, ttl time.Duration) (entity.IdempotencyKey, bool, error) {
+	args := m.Called(key, requestHash, ttl)
+	return args.Get(0).(entity.IdempotencyKey), args.Bool(1), args.Error(2)
+}
+func (m *MockIdempotencyRepository) SaveIdempotentResponseRepository(ctx context.Context, key string, statusCode int, headers http.Header, body []byte) error {
+	args := m.Called(key, statusCode, headers, body)
+	return args.Error(0)
+}
+func (m *MockIdempotencyRepository) ReleaseIdempotencyKeyRepository(ctx context.Context, key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
@@ -0,0 +1,17 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, alerts []dto.LowStockAlert) error {
+	args := m.Called(ctx, alerts)
+	return args.Error(0)
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProductImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductImageRepository) RetrieveProductImagesRepository(ctx context.Context, barcodeId string) ([]entity.ProductImage, error) {
+	args := m.Called(barcodeId)
+	images, _ := args.Get(0).([]entity.ProductImage)
+	return images, args.Error(1)
+}
+func (m *MockProductImageRepository) CreateProductImageRepository(ctx context.Context, image *entity.ProductImage) error {
+	args := m.Called(image)
+	return args.Error(0)
+}
+func (m *MockProductImageRepository) ReorderProductImagesRepository(ctx context.Context, barcodeId string, imageIds []uint) ([]entity.ProductImage, error) {
+	args := m.Called(barcodeId, imageIds)
+	images, _ := args.Get(0).([]entity.ProductImage)
+	return images, args.Error(1)
+}
+func (m *MockProductImageRepository) SetPrimaryProductImageRepository(ctx context.Context, barcodeId string, imageId uint) error {
+	args := m.Called(barcodeId, imageId)
+	return args.Error(0)
+}
+func (m *MockProductImageRepository) DeleteProductImageRepository(ctx context.Context, barcodeId string, imageId uint) (entity.ProductImage, error) {
+	args := m.Called(barcodeId, imageId)
+	return args.Get(0).(entity.ProductImage), args.Error(1)
+}
+func (m *MockProductImageRepository) RetrieveReferencedImagesRepository(ctx context.Context) ([]string, error) {
+	args := m.Called()
+	images, _ := args.Get(0).([]string)
+	return images, args.Error(1)
+}
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"context"
+	"mime/multipart"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProductImageService struct {
+	mock.Mock
+}
+
+func (m *MockProductImageService) GetProductImagesService(ctx context.Context, barcodeId string) ([]dto.ProductImageResponse, error) {
+	args := m.Called(barcodeId)
+	images, _ := args.Get(0).([]dto.ProductImageResponse)
+	return images, args.Error(1)
+}
+func (m *MockProductImageService) AddProductImageService(ctx context.Context, barcodeId string, image *multipart.FileHeader) (dto.ProductImageResponse, error) {
+	args := m.Called(barcodeId, image)
+	return args.Get(0).(dto.ProductImageResponse), args.Error(1)
+}
+func (m *MockProductImageService) ReorderProductImagesService(ctx context.Context, barcodeId string, imageIds []uint) ([]dto.ProductImageResponse, error) {
+	args := m.Called(barcodeId, imageIds)
+	images, _ := args.Get(0).([]dto.ProductImageResponse)
+	return images, args.Error(1)
+}
+func (m *MockProductImageService) SetPrimaryProductImageService(ctx context.Context, barcodeId string, imageId uint) error {
+	args := m.Called(barcodeId, imageId)
+	return args.Error(0)
+}
+func (m *MockProductImageService) DeleteProductImageService(ctx context.Context, barcodeId string, imageId uint) error {
+	args := m.Called(barcodeId, imageId)
+	return args.Error(0)
+}
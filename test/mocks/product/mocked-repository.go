@@ -1,9 +1,14 @@
 package test
 
 import (
+	"context"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	"tiga-putra-cashier-be/utils"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -11,39 +16,99 @@ type MockProductRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductRepository) CountProductsRepository() (uint16, error) {
-	args := m.Called()
-	return args.Get(0).(uint16), args.Error(1)
+func (m *MockProductRepository) CountProductsRepository(ctx context.Context, query *dto.ProductListQuery) (int64, error) {
+	args := m.Called(query)
+	return args.Get(0).(int64), args.Error(1)
 }
-func (m *MockProductRepository) RetrieveProductsRepository(limit, offset uint16) ([]entity.Product, error) {
-	args := m.Called(limit, offset)
+func (m *MockProductRepository) RetrieveProductsRepository(ctx context.Context, query *dto.ProductListQuery, limit, offset int) ([]entity.Product, error) {
+	args := m.Called(query, limit, offset)
 	return args.Get(0).([]entity.Product), args.Error(1)
 }
-func (m *MockProductRepository) RetrieveProductByBarcodeId(barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
+func (m *MockProductRepository) RetrieveProductsAfterRepository(ctx context.Context, query *dto.ProductListQuery, after *utils.Cursor, limit int) ([]entity.Product, string, error) {
+	args := m.Called(query, after, limit)
+	return args.Get(0).([]entity.Product), args.String(1), args.Error(2)
+}
+func (m *MockProductRepository) RetrieveProductByBarcodeId(ctx context.Context, barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
 	args := m.Called(barcodeId)
 	return args.Get(0).(dto.ProductWithoutTimeStamp), args.Bool(1)
 }
-func (m *MockProductRepository) RetrieveProductForSearch(req *dto.SearchProductQuery) ([]entity.Product, error) {
+func (m *MockProductRepository) CountProductsForSearchRepository(ctx context.Context, req *dto.SearchProductQuery) (int64, error) {
 	args := m.Called(req)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockProductRepository) RetrieveProductForSearch(ctx context.Context, req *dto.SearchProductQuery, limit, offset int) ([]entity.Product, error) {
+	args := m.Called(req, limit, offset)
 	return args.Get(0).([]entity.Product), args.Error(1)
 }
-func (m *MockProductRepository) RetrieveDeletedProductByBarcodeId(barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
+func (m *MockProductRepository) RetrieveProductSuggestionsRepository(ctx context.Context, term string, limit int) ([]dto.ProductSuggestion, error) {
+	args := m.Called(term, limit)
+	return args.Get(0).([]dto.ProductSuggestion), args.Error(1)
+}
+func (m *MockProductRepository) RetrieveDeletedProductByBarcodeId(ctx context.Context, barcodeId *string) (dto.ProductWithoutTimeStamp, bool) {
 	args := m.Called(barcodeId)
 	return args.Get(0).(dto.ProductWithoutTimeStamp), args.Bool(1)
 }
-func (m *MockProductRepository) CreateProductRepository(product *entity.Product) error {
+func (m *MockProductRepository) CreateProductRepository(ctx context.Context, product *entity.Product) error {
 	args := m.Called(product)
 	return args.Error(0)
 }
-func (m *MockProductRepository) UpdateProductRepository(barcodeId *string, product *map[string]interface{}) error {
-	args := m.Called(barcodeId, product)
+func (m *MockProductRepository) UpdateProductRepository(ctx context.Context, barcodeId *string, product *map[string]interface{}, version *uint) error {
+	args := m.Called(barcodeId, product, version)
 	return args.Error(0)
 }
-func (m *MockProductRepository) UpdateDeletedProductRepository(barcodeId *string) error {
-	args := m.Called(barcodeId)
+func (m *MockProductRepository) UpdateDeletedProductRepository(ctx context.Context, barcodeId *string, image string) error {
+	args := m.Called(barcodeId, image)
 	return args.Error(0)
 }
-func (m *MockProductRepository) DeleteProductRepository(barcodeId *string) error {
-	args := m.Called(barcodeId)
+func (m *MockProductRepository) DeleteProductRepository(ctx context.Context, barcodeId *string, version *uint) ([]string, error) {
+	args := m.Called(barcodeId, version)
+	images, _ := args.Get(0).([]string)
+	return images, args.Error(1)
+}
+func (m *MockProductRepository) RetrieveStorePriceOverridesRepository(ctx context.Context, storeId uint, barcodeIds []string) (map[string]decimal.Decimal, error) {
+	args := m.Called(storeId, barcodeIds)
+	return args.Get(0).(map[string]decimal.Decimal), args.Error(1)
+}
+func (m *MockProductRepository) StreamProductsRepository(ctx context.Context, includeDeleted bool, fn func(entity.Product) error) error {
+	args := m.Called(includeDeleted, fn)
+	for _, product := range args.Get(0).([]entity.Product) {
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+func (m *MockProductRepository) RetrieveProductsByBarcodeIdsRepository(ctx context.Context, barcodeIds []string) (map[string]entity.Product, error) {
+	args := m.Called(barcodeIds)
+	return args.Get(0).(map[string]entity.Product), args.Error(1)
+}
+func (m *MockProductRepository) UpsertProductsRepository(ctx context.Context, products []entity.Product, columns []string) error {
+	args := m.Called(products, columns)
 	return args.Error(0)
 }
+func (m *MockProductRepository) RetrieveProductsByFilterRepository(ctx context.Context, filter dto.ProductFilter) ([]entity.Product, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]entity.Product), args.Error(1)
+}
+func (m *MockProductRepository) UpdateProductPricesRepository(ctx context.Context, filter dto.ProductFilter, adjust func(entity.Product) (decimal.Decimal, error)) ([]dto.PriceChange, error) {
+	args := m.Called(filter, adjust)
+	var changes []dto.PriceChange
+	for _, product := range args.Get(0).([]entity.Product) {
+		price, err := adjust(product)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, dto.PriceChange{BarcodeId: product.BarcodeId, Title: product.Title, OldPrice: product.Price, NewPrice: price})
+	}
+	return changes, args.Error(1)
+}
+func (m *MockProductRepository) RetrievePricesAtRepository(ctx context.Context, storeId uint, barcodeIds []string, at time.Time) (map[string]dto.ProductPriceAt, error) {
+	args := m.Called(storeId, barcodeIds, at)
+	return args.Get(0).(map[string]dto.ProductPriceAt), args.Error(1)
+}
+
+// WithTx runs fn against the mock itself, so the expectations set on it cover
+// the calls made inside the transaction.
+func (m *MockProductRepository) WithTx(ctx context.Context, fn func(repo repository.ProductRepository) error) error {
+	return fn(m)
+}
@@ -1,7 +1,10 @@
 package test
 
 import (
+	"context"
+	"io"
 	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,27 +13,51 @@ type MockProductService struct {
 	mock.Mock
 }
 
-func (m *MockProductService) GetProductService(page *uint16) (dto.AllProductsWithPagination, error) {
-	args := m.Called(page)
+func (m *MockProductService) GetProductService(ctx context.Context, req *dto.ProductListQuery) (dto.AllProductsWithPagination, error) {
+	args := m.Called(req)
 	return args.Get(0).(dto.AllProductsWithPagination), args.Error(1)
 }
-func (m *MockProductService) GetProductDetailService(barcodeId *string) (dto.ProductWithoutTimeStamp, error) {
-	args := m.Called(barcodeId)
+func (m *MockProductService) GetProductsByCursorService(ctx context.Context, req *dto.ProductListQuery) (dto.ProductsWithCursor, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.ProductsWithCursor), args.Error(1)
+}
+func (m *MockProductService) GetProductDetailService(ctx context.Context, barcodeId *string, storeId uint) (dto.ProductWithoutTimeStamp, error) {
+	args := m.Called(barcodeId, storeId)
 	return args.Get(0).(dto.ProductWithoutTimeStamp), args.Error(1)
 }
-func (m *MockProductService) SearchProductService(req *dto.SearchProductQuery) ([]dto.ProductWithoutTimeStamp, error) {
+func (m *MockProductService) SearchProductService(ctx context.Context, req *dto.SearchProductQuery) (dto.AllProductsWithPagination, error) {
 	args := m.Called(req)
-	return args.Get(0).([]dto.ProductWithoutTimeStamp), args.Error(1)
+	return args.Get(0).(dto.AllProductsWithPagination), args.Error(1)
+}
+func (m *MockProductService) AutocompleteProductService(ctx context.Context, term string) ([]dto.ProductSuggestion, error) {
+	args := m.Called(term)
+	return args.Get(0).([]dto.ProductSuggestion), args.Error(1)
 }
-func (m *MockProductService) CreateProductService(product dto.AddProductRequest) error {
+func (m *MockProductService) CreateProductService(ctx context.Context, product dto.AddProductRequest) error {
 	args := m.Called(product)
 	return args.Error(0)
 }
-func (m *MockProductService) UpdateProductService(barcodeId string, product dto.UpdateProductRequest) error {
-	args := m.Called(barcodeId, product)
+func (m *MockProductService) UpdateProductService(ctx context.Context, barcodeId string, product dto.UpdateProductRequest, version *uint) error {
+	args := m.Called(barcodeId, product, version)
+	return args.Error(0)
+}
+func (m *MockProductService) DeleteProductService(ctx context.Context, barcodeId *string, version *uint) error {
+	args := m.Called(barcodeId, version)
 	return args.Error(0)
 }
-func (m *MockProductService) DeleteProductService(barcodeId *string) error {
-	args := m.Called(barcodeId)
+func (m *MockProductService) ExportProductsService(ctx context.Context, includeDeleted bool, w utils.ExportWriter) ([]string, error) {
+	args := m.Called(includeDeleted, w)
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockProductService) ExportProductArchiveService(ctx context.Context, req dto.ExportProductQuery, w io.Writer) error {
+	args := m.Called(req, w)
 	return args.Error(0)
 }
+func (m *MockProductService) ImportProductsService(ctx context.Context, req dto.ImportProductRequest) (dto.ImportProductResult, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.ImportProductResult), args.Error(1)
+}
+func (m *MockProductService) AdjustPricesService(ctx context.Context, req dto.PriceAdjustmentRequest) (dto.PriceAdjustmentResult, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.PriceAdjustmentResult), args.Error(1)
+}
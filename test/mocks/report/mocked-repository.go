@@ -0,0 +1,46 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) RetrieveSalesByPeriodRepository(ctx context.Context, filter dto.ReportQuery, period string) ([]dto.SalesPeriodTotal, error) {
+	args := m.Called(filter, period)
+	return args.Get(0).([]dto.SalesPeriodTotal), args.Error(1)
+}
+func (m *MockReportRepository) RetrieveSalesByHourRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.HourlySales, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]dto.HourlySales), args.Error(1)
+}
+func (m *MockReportRepository) RetrieveSalesByCashierRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.CashierSales, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]dto.CashierSales), args.Error(1)
+}
+func (m *MockReportRepository) RetrieveSalesByPaymentMethodRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.PaymentMethodSales, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]dto.PaymentMethodSales), args.Error(1)
+}
+func (m *MockReportRepository) RetrieveSalesByCategoryRepository(ctx context.Context, filter dto.ReportQuery) ([]dto.CategorySales, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]dto.CategorySales), args.Error(1)
+}
+func (m *MockReportRepository) RetrieveTopProductsRepository(ctx context.Context, filter dto.ReportQuery, rankBy string, limit uint16) ([]dto.ProductSales, error) {
+	args := m.Called(filter, rankBy, limit)
+	return args.Get(0).([]dto.ProductSales), args.Error(1)
+}
+func (m *MockReportRepository) StreamStockValuationRepository(ctx context.Context, storeId uint, fn func(dto.StockValuation) error) error {
+	args := m.Called(storeId, fn)
+	for _, valuation := range args.Get(0).([]dto.StockValuation) {
+		if err := fn(valuation); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) GetSalesSummaryService(ctx context.Context, req dto.SalesSummaryQuery) ([]dto.SalesPeriodTotal, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.SalesPeriodTotal), args.Error(1)
+}
+func (m *MockReportService) GetHourlySalesService(ctx context.Context, req dto.ReportQuery) ([]dto.HourlySales, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.HourlySales), args.Error(1)
+}
+func (m *MockReportService) GetCashierSalesService(ctx context.Context, req dto.ReportQuery) ([]dto.CashierSales, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.CashierSales), args.Error(1)
+}
+func (m *MockReportService) GetPaymentMethodSalesService(ctx context.Context, req dto.ReportQuery) ([]dto.PaymentMethodSales, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.PaymentMethodSales), args.Error(1)
+}
+func (m *MockReportService) GetCategorySalesService(ctx context.Context, req dto.ReportQuery) ([]dto.CategorySales, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.CategorySales), args.Error(1)
+}
+func (m *MockReportService) GetTopProductsService(ctx context.Context, req dto.TopProductsQuery) ([]dto.ProductSales, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.ProductSales), args.Error(1)
+}
+func (m *MockReportService) GetStockValuationService(ctx context.Context, storeId uint) ([]dto.StockValuation, error) {
+	args := m.Called(storeId)
+	return args.Get(0).([]dto.StockValuation), args.Error(1)
+}
+func (m *MockReportService) ExportSalesSummaryService(ctx context.Context, req dto.SalesSummaryQuery, w utils.ExportWriter) error {
+	args := m.Called(req, w)
+	return args.Error(0)
+}
+func (m *MockReportService) ExportHourlySalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	args := m.Called(req, w)
+	return args.Error(0)
+}
+func (m *MockReportService) ExportCashierSalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	args := m.Called(req, w)
+	return args.Error(0)
+}
+func (m *MockReportService) ExportPaymentMethodSalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	args := m.Called(req, w)
+	return args.Error(0)
+}
+func (m *MockReportService) ExportCategorySalesService(ctx context.Context, req dto.ReportQuery, w utils.ExportWriter) error {
+	args := m.Called(req, w)
+	return args.Error(0)
+}
+func (m *MockReportService) ExportTopProductsService(ctx context.Context, req dto.TopProductsQuery, w utils.ExportWriter) error {
+	args := m.Called(req, w)
+	return args.Error(0)
+}
+func (m *MockReportService) ExportStockValuationService(ctx context.Context, storeId uint, w utils.ExportWriter) error {
+	args := m.Called(storeId, w)
+	return args.Error(0)
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStockCountRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockCountRepository) RetrieveOpenSessionRepository(ctx context.Context, storeId uint) (entity.StockCountSession, bool) {
+	args := m.Called(storeId)
+	return args.Get(0).(entity.StockCountSession), args.Bool(1)
+}
+func (m *MockStockCountRepository) RetrieveSessionByIdRepository(ctx context.Context, sessionId uint) (entity.StockCountSession, bool) {
+	args := m.Called(sessionId)
+	return args.Get(0).(entity.StockCountSession), args.Bool(1)
+}
+func (m *MockStockCountRepository) CreateSessionRepository(ctx context.Context, session *entity.StockCountSession) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+func (m *MockStockCountRepository) RetrieveExistingBarcodesRepository(ctx context.Context, barcodeIds []string) ([]string, error) {
+	args := m.Called(barcodeIds)
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockStockCountRepository) UpsertCountItemsRepository(ctx context.Context, sessionId uint, items []entity.StockCountItem) error {
+	args := m.Called(sessionId, items)
+	return args.Error(0)
+}
+func (m *MockStockCountRepository) RetrieveVariancesRepository(ctx context.Context, session entity.StockCountSession) ([]dto.StockVariance, error) {
+	args := m.Called(session)
+	return args.Get(0).([]dto.StockVariance), args.Error(1)
+}
+func (m *MockStockCountRepository) RetrieveUncountedProductsRepository(ctx context.Context, session entity.StockCountSession) ([]dto.UncountedProduct, error) {
+	args := m.Called(session)
+	return args.Get(0).([]dto.UncountedProduct), args.Error(1)
+}
+func (m *MockStockCountRepository) ApproveSessionRepository(ctx context.Context, sessionId uint, approvedBy string, includeUncounted bool) ([]dto.StockVariance, error) {
+	args := m.Called(sessionId, approvedBy, includeUncounted)
+	return args.Get(0).([]dto.StockVariance), args.Error(1)
+}
+
+type MockStockRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockRepository) RetrieveLowStockProductsRepository(ctx context.Context, storeId uint) ([]dto.LowStockProduct, error) {
+	args := m.Called(storeId)
+	return args.Get(0).([]dto.LowStockProduct), args.Error(1)
+}
+func (m *MockStockRepository) RetrieveReorderCandidatesRepository(ctx context.Context, storeId uint, salesSince time.Time) ([]dto.ReorderCandidate, error) {
+	args := m.Called(storeId, salesSince)
+	return args.Get(0).([]dto.ReorderCandidate), args.Error(1)
+}
+func (m *MockStockRepository) ReceiveStockRepository(ctx context.Context, batch *entity.StockBatch, reference string) error {
+	args := m.Called(batch, reference)
+	return args.Error(0)
+}
+func (m *MockStockRepository) RetrieveExpiringBatchesRepository(ctx context.Context, storeId uint, withinDays uint16) ([]dto.ExpiringBatch, error) {
+	args := m.Called(storeId, withinDays)
+	return args.Get(0).([]dto.ExpiringBatch), args.Error(1)
+}
+func (m *MockStockRepository) WriteOffBatchRepository(ctx context.Context, batchId uint, note string) (entity.StockBatch, error) {
+	args := m.Called(batchId, note)
+	return args.Get(0).(entity.StockBatch), args.Error(1)
+}
+func (m *MockStockRepository) RetrieveStockLevelsRepository(ctx context.Context, barcodeId string) ([]dto.StoreStockLevel, error) {
+	args := m.Called(barcodeId)
+	return args.Get(0).([]dto.StoreStockLevel), args.Error(1)
+}
+
+type MockStockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockTransferRepository) CreateTransferRepository(ctx context.Context, transfer *entity.StockTransfer, quantities map[string]int64) error {
+	args := m.Called(transfer, quantities)
+	return args.Error(0)
+}
+func (m *MockStockTransferRepository) RetrieveTransfersRepository(ctx context.Context, status string, storeId uint) ([]entity.StockTransfer, error) {
+	args := m.Called(status, storeId)
+	return args.Get(0).([]entity.StockTransfer), args.Error(1)
+}
+func (m *MockStockTransferRepository) RetrieveTransferByIdRepository(ctx context.Context, transferId uint) (entity.StockTransfer, bool) {
+	args := m.Called(transferId)
+	return args.Get(0).(entity.StockTransfer), args.Bool(1)
+}
+func (m *MockStockTransferRepository) ReceiveTransferRepository(ctx context.Context, transferId uint, receivedBy string) (entity.StockTransfer, error) {
+	args := m.Called(transferId, receivedBy)
+	return args.Get(0).(entity.StockTransfer), args.Error(1)
+}
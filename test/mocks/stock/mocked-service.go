@@ -0,0 +1,83 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStockCountService struct {
+	mock.Mock
+}
+
+func (m *MockStockCountService) StartStockCountService(ctx context.Context, req dto.StartStockCountRequest) (dto.StockCountSessionResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.StockCountSessionResponse), args.Error(1)
+}
+func (m *MockStockCountService) SubmitStockCountService(ctx context.Context, sessionId uint, req dto.SubmitStockCountRequest) error {
+	args := m.Called(sessionId, req)
+	return args.Error(0)
+}
+func (m *MockStockCountService) GetStockCountReportService(ctx context.Context, sessionId uint) (dto.StockCountReport, error) {
+	args := m.Called(sessionId)
+	return args.Get(0).(dto.StockCountReport), args.Error(1)
+}
+func (m *MockStockCountService) GetUncountedProductsService(ctx context.Context, sessionId uint) ([]dto.UncountedProduct, error) {
+	args := m.Called(sessionId)
+	return args.Get(0).([]dto.UncountedProduct), args.Error(1)
+}
+func (m *MockStockCountService) ApproveStockCountService(ctx context.Context, sessionId uint, req dto.ApproveStockCountRequest) ([]dto.StockVariance, error) {
+	args := m.Called(sessionId, req)
+	return args.Get(0).([]dto.StockVariance), args.Error(1)
+}
+
+type MockStockService struct {
+	mock.Mock
+}
+
+func (m *MockStockService) GetLowStockProductsService(ctx context.Context, storeId uint) ([]dto.LowStockProduct, error) {
+	args := m.Called(storeId)
+	return args.Get(0).([]dto.LowStockProduct), args.Error(1)
+}
+func (m *MockStockService) GetReorderSuggestionService(ctx context.Context, req dto.ReorderSuggestionQuery) (dto.ReorderSuggestion, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.ReorderSuggestion), args.Error(1)
+}
+func (m *MockStockService) ReceiveStockService(ctx context.Context, req dto.ReceiveStockRequest) (dto.StockBatch, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.StockBatch), args.Error(1)
+}
+func (m *MockStockService) GetExpiringBatchesService(ctx context.Context, req dto.ExpiringBatchQuery) ([]dto.ExpiringBatch, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.ExpiringBatch), args.Error(1)
+}
+func (m *MockStockService) WriteOffBatchService(ctx context.Context, batchId uint, req dto.WriteOffBatchRequest) (dto.StockBatch, error) {
+	args := m.Called(batchId, req)
+	return args.Get(0).(dto.StockBatch), args.Error(1)
+}
+func (m *MockStockService) GetStockLevelsService(ctx context.Context, barcodeId string) ([]dto.StoreStockLevel, error) {
+	args := m.Called(barcodeId)
+	return args.Get(0).([]dto.StoreStockLevel), args.Error(1)
+}
+
+type MockStockTransferService struct {
+	mock.Mock
+}
+
+func (m *MockStockTransferService) CreateStockTransferService(ctx context.Context, req dto.CreateStockTransferRequest) (dto.StockTransferResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.StockTransferResponse), args.Error(1)
+}
+func (m *MockStockTransferService) GetStockTransfersService(ctx context.Context, req dto.StockTransferQuery) ([]dto.StockTransferResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.StockTransferResponse), args.Error(1)
+}
+func (m *MockStockTransferService) GetStockTransferDetailService(ctx context.Context, transferId uint) (dto.StockTransferResponse, error) {
+	args := m.Called(transferId)
+	return args.Get(0).(dto.StockTransferResponse), args.Error(1)
+}
+func (m *MockStockTransferService) ReceiveStockTransferService(ctx context.Context, transferId uint, req dto.ReceiveStockTransferRequest) (dto.StockTransferResponse, error) {
+	args := m.Called(transferId, req)
+	return args.Get(0).(dto.StockTransferResponse), args.Error(1)
+}
@@ -0,0 +1,46 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStoreRepository struct {
+	mock.Mock
+}
+
+func (m *MockStoreRepository) RetrieveStoresRepository(ctx context.Context) ([]entity.Store, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.Store), args.Error(1)
+}
+func (m *MockStoreRepository) RetrieveStoreByIdRepository(ctx context.Context, storeId uint) (entity.Store, bool) {
+	args := m.Called(storeId)
+	return args.Get(0).(entity.Store), args.Bool(1)
+}
+func (m *MockStoreRepository) RetrieveStoreByCodeRepository(ctx context.Context, code string) (entity.Store, bool) {
+	args := m.Called(code)
+	return args.Get(0).(entity.Store), args.Bool(1)
+}
+func (m *MockStoreRepository) CreateStoreRepository(ctx context.Context, store *entity.Store) error {
+	args := m.Called(store)
+	return args.Error(0)
+}
+func (m *MockStoreRepository) UpdateStoreRepository(ctx context.Context, storeId uint, store *map[string]interface{}) error {
+	args := m.Called(storeId, store)
+	return args.Error(0)
+}
+func (m *MockStoreRepository) RetrieveStorePricesRepository(ctx context.Context, storeId uint) ([]dto.StorePrice, error) {
+	args := m.Called(storeId)
+	return args.Get(0).([]dto.StorePrice), args.Error(1)
+}
+func (m *MockStoreRepository) UpsertStorePriceRepository(ctx context.Context, price *entity.StorePrice) error {
+	args := m.Called(price)
+	return args.Error(0)
+}
+func (m *MockStoreRepository) DeleteStorePriceRepository(ctx context.Context, storeId uint, barcodeId string) error {
+	args := m.Called(storeId, barcodeId)
+	return args.Error(0)
+}
@@ -0,0 +1,37 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStoreService struct {
+	mock.Mock
+}
+
+func (m *MockStoreService) GetStoresService(ctx context.Context) ([]dto.Store, error) {
+	args := m.Called()
+	return args.Get(0).([]dto.Store), args.Error(1)
+}
+func (m *MockStoreService) CreateStoreService(ctx context.Context, req dto.AddStoreRequest) (dto.Store, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.Store), args.Error(1)
+}
+func (m *MockStoreService) UpdateStoreService(ctx context.Context, storeId uint, req dto.UpdateStoreRequest) error {
+	args := m.Called(storeId, req)
+	return args.Error(0)
+}
+func (m *MockStoreService) GetStorePricesService(ctx context.Context, storeId uint) ([]dto.StorePrice, error) {
+	args := m.Called(storeId)
+	return args.Get(0).([]dto.StorePrice), args.Error(1)
+}
+func (m *MockStoreService) SetStorePriceService(ctx context.Context, storeId uint, barcodeId string, req dto.SetStorePriceRequest) error {
+	args := m.Called(storeId, barcodeId, req)
+	return args.Error(0)
+}
+func (m *MockStoreService) DeleteStorePriceService(ctx context.Context, storeId uint, barcodeId string) error {
+	args := m.Called(storeId, barcodeId)
+	return args.Error(0)
+}
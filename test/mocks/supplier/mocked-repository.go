@@ -0,0 +1,29 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSupplierRepository struct {
+	mock.Mock
+}
+
+func (m *MockSupplierRepository) RetrieveSuppliersRepository(ctx context.Context) ([]entity.Supplier, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.Supplier), args.Error(1)
+}
+func (m *MockSupplierRepository) RetrieveSupplierByIdRepository(ctx context.Context, supplierId uint) (entity.Supplier, bool) {
+	args := m.Called(supplierId)
+	return args.Get(0).(entity.Supplier), args.Bool(1)
+}
+func (m *MockSupplierRepository) CreateSupplierRepository(ctx context.Context, supplier *entity.Supplier) error {
+	args := m.Called(supplier)
+	return args.Error(0)
+}
+func (m *MockSupplierRepository) UpdateSupplierRepository(ctx context.Context, supplierId uint, supplier *map[string]interface{}) error {
+	args := m.Called(supplierId, supplier)
+	return args.Error(0)
+}
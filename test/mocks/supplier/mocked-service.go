@@ -0,0 +1,25 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSupplierService struct {
+	mock.Mock
+}
+
+func (m *MockSupplierService) GetSuppliersService(ctx context.Context) ([]dto.Supplier, error) {
+	args := m.Called()
+	return args.Get(0).([]dto.Supplier), args.Error(1)
+}
+func (m *MockSupplierService) CreateSupplierService(ctx context.Context, req dto.AddSupplierRequest) (dto.Supplier, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.Supplier), args.Error(1)
+}
+func (m *MockSupplierService) UpdateSupplierService(ctx context.Context, supplierId uint, req dto.UpdateSupplierRequest) error {
+	args := m.Called(supplierId, req)
+	return args.Error(0)
+}
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSyncRepository struct {
+	mock.Mock
+}
+
+func (m *MockSyncRepository) RetrieveProductChangesRepository(ctx context.Context, after *utils.Cursor, upTo time.Time, limit int) ([]entity.Product, *utils.Cursor, error) {
+	args := m.Called(after, upTo, limit)
+	var last *utils.Cursor
+	if args.Get(1) != nil {
+		last = args.Get(1).(*utils.Cursor)
+	}
+	return args.Get(0).([]entity.Product), last, args.Error(2)
+}
@@ -0,0 +1,17 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSyncService struct {
+	mock.Mock
+}
+
+func (m *MockSyncService) SyncProductsService(ctx context.Context, since string) (dto.ProductSyncResponse, error) {
+	args := m.Called(since)
+	return args.Get(0).(dto.ProductSyncResponse), args.Error(1)
+}
@@ -0,0 +1,34 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) CreateTransactionRepository(ctx context.Context, transaction *entity.Transaction, allowExpired bool) error {
+	args := m.Called(transaction, allowExpired)
+	return args.Error(0)
+}
+func (m *MockTransactionRepository) RetrieveTransactionByIdRepository(ctx context.Context, transactionId uint) (entity.Transaction, bool) {
+	args := m.Called(transactionId)
+	return args.Get(0).(entity.Transaction), args.Bool(1)
+}
+func (m *MockTransactionRepository) CreateOfflineTransactionRepository(ctx context.Context, transaction *entity.Transaction, conflicts []entity.SaleConflict) ([]entity.SaleConflict, error) {
+	args := m.Called(transaction, conflicts)
+	return args.Get(0).([]entity.SaleConflict), args.Error(1)
+}
+func (m *MockTransactionRepository) RetrieveSaleConflictsRepository(ctx context.Context, query dto.SaleConflictQuery) ([]entity.SaleConflict, error) {
+	args := m.Called(query)
+	return args.Get(0).([]entity.SaleConflict), args.Error(1)
+}
+func (m *MockTransactionRepository) ResolveSaleConflictRepository(ctx context.Context, conflictId uint, resolvedBy string) (entity.SaleConflict, error) {
+	args := m.Called(conflictId, resolvedBy)
+	return args.Get(0).(entity.SaleConflict), args.Error(1)
+}
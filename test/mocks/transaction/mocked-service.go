@@ -0,0 +1,33 @@
+package test
+
+import (
+	"context"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransactionService struct {
+	mock.Mock
+}
+
+func (m *MockTransactionService) CreateTransactionService(ctx context.Context, req dto.CreateTransactionRequest) (dto.TransactionResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).(dto.TransactionResponse), args.Error(1)
+}
+func (m *MockTransactionService) GetTransactionDetailService(ctx context.Context, transactionId uint) (dto.TransactionResponse, error) {
+	args := m.Called(transactionId)
+	return args.Get(0).(dto.TransactionResponse), args.Error(1)
+}
+func (m *MockTransactionService) UploadOfflineSalesService(ctx context.Context, req dto.UploadOfflineSalesRequest) ([]dto.OfflineSaleResult, error) {
+	args := m.Called(req)
+	return args.Get(0).([]dto.OfflineSaleResult), args.Error(1)
+}
+func (m *MockTransactionService) GetSaleConflictsService(ctx context.Context, query dto.SaleConflictQuery) ([]dto.SaleConflictResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]dto.SaleConflictResponse), args.Error(1)
+}
+func (m *MockTransactionService) ResolveSaleConflictService(ctx context.Context, conflictId uint, req dto.ResolveSaleConflictRequest) (dto.SaleConflictResponse, error) {
+	args := m.Called(conflictId, req)
+	return args.Get(0).(dto.SaleConflictResponse), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"context"
+	"io"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	args := m.Called(key, content, size, contentType)
+	return args.Error(0)
+}
+func (m *MockStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(key)
+	file, _ := args.Get(0).(io.ReadCloser)
+	return file, args.Error(1)
+}
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+func (m *MockStorage) SignedURL(ctx context.Context, key string) (string, error) {
+	args := m.Called(key)
+	return args.String(0), args.Error(1)
+}
+func (m *MockStorage) List(ctx context.Context, prefix string) ([]utils.StoredFile, error) {
+	args := m.Called(prefix)
+	files, _ := args.Get(0).([]utils.StoredFile)
+	return files, args.Error(1)
+}
@@ -1,6 +1,8 @@
 package test
 
 import (
+	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/stretchr/testify/mock"
@@ -10,7 +12,7 @@ type MockFileManagement struct {
 	mock.Mock
 }
 
-func (m *MockFileManagement) UploadFile(file *multipart.FileHeader, filename, path string) error {
+func (m *MockFileManagement) UploadFile(ctx context.Context, file *multipart.FileHeader, filename, path string) error {
 	args := m.Called(file, filename, path)
 	return args.Error(0)
 }
@@ -18,7 +20,12 @@ func (m *MockFileManagement) GetFileNameExtension(filename string) string {
 	args := m.Called(filename)
 	return args.String(0)
 }
-func (m *MockFileManagement) DeleteFile(pathFile string) error {
+func (m *MockFileManagement) OpenFile(ctx context.Context, pathFile string) (io.ReadCloser, error) {
+	args := m.Called(pathFile)
+	file, _ := args.Get(0).(io.ReadCloser)
+	return file, args.Error(1)
+}
+func (m *MockFileManagement) DeleteFile(ctx context.Context, pathFile string) error {
 	args := m.Called(pathFile)
 	return args.Error(0)
 }
@@ -27,3 +34,23 @@ func (m *MockFileManagement) GenerateNewFileName(ext string) string {
 	args := m.Called(ext)
 	return args.String(0)
 }
+func (m *MockFileManagement) SaveFile(ctx context.Context, content io.Reader, filename, path string) error {
+	args := m.Called(content, filename, path)
+	return args.Error(0)
+}
+func (m *MockFileManagement) DownloadFile(ctx context.Context, url string) ([]byte, error) {
+	args := m.Called(url)
+	return args.Get(0).([]byte), args.Error(1)
+}
+func (m *MockFileManagement) UploadImage(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	args := m.Called(file)
+	return args.String(0), args.Error(1)
+}
+func (m *MockFileManagement) SaveImage(ctx context.Context, content io.Reader) (string, error) {
+	args := m.Called(content)
+	return args.String(0), args.Error(1)
+}
+func (m *MockFileManagement) DeleteImage(ctx context.Context, filename string) error {
+	args := m.Called(filename)
+	return args.Error(0)
+}
@@ -0,0 +1,163 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inTempDir runs the test where neither config.yaml nor .env exist and clears
+// the variables the tests rely on.
+func inTempDir(t *testing.T) string {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, name := range []string{"APP_ENV", "CONFIG_FILE", "SERVER_ADDR", "DB_USER", "DB_HOST", "DB_NAME", "DB_PORT", "DB_QUERY_TIMEOUT", "STORAGE_DRIVER"} {
+		t.Setenv(name, "")
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, name, content string) {
+	require.NoError(t, os.WriteFile(name, []byte(content), 0600))
+}
+
+func setDatabaseEnv(t *testing.T) {
+	t.Setenv("DB_USER", "cashier")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_NAME", "cashier")
+	t.Setenv("DB_PORT", "5432")
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	inTempDir(t)
+	setDatabaseEnv(t)
+
+	cfg, err := config.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, constant.DefaultServerAddr, cfg.Server.Addr)
+	assert.Equal(t, []string{"*"}, cfg.Server.CORSAllowOrigins)
+	assert.Equal(t, constant.DefaultQueryTimeout, cfg.Database.Timeouts.Query)
+	assert.Equal(t, constant.DefaultImageDir, cfg.Storage.ImageDir)
+	assert.Equal(t, int64(constant.DefaultMaxUploadSize), cfg.Storage.MaxUploadSize)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	inTempDir(t)
+	writeFile(t, "config.yaml", `
+server:
+  addr: ":9000"
+  cors_allow_origins: ["https://pos.example.com"]
+database:
+  user: yaml
+  host: yaml
+  name: cashier
+  port: "5432"
+  timeouts:
+    query: 5s
+    report: 1m
+`)
+	writeFile(t, ".env", "DB_HOST=dotenv\nDB_USER=dotenv\nDB_QUERY_TIMEOUT=7s\n")
+	t.Setenv("DB_HOST", "env")
+
+	cfg, err := config.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, ":9000", cfg.Server.Addr)
+	assert.Equal(t, []string{"https://pos.example.com"}, cfg.Server.CORSAllowOrigins)
+	assert.Equal(t, "env", cfg.Database.Host)
+	assert.Equal(t, "dotenv", cfg.Database.User)
+	assert.Equal(t, 7*time.Second, cfg.Database.Timeouts.Query)
+	assert.Equal(t, time.Minute, cfg.Database.Timeouts.Report)
+	assert.Equal(t, constant.DefaultTxTimeout, cfg.Database.Timeouts.Tx)
+}
+
+func TestLoad_ConfigFileFromEnv(t *testing.T) {
+	dir := inTempDir(t)
+	setDatabaseEnv(t)
+	writeFile(t, "other.yaml", "storage:\n  image_dir: images\n  max_upload_size: 1024\n")
+	t.Setenv("CONFIG_FILE", filepath.Join(dir, "other.yaml"))
+
+	cfg, err := config.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "images", cfg.Storage.ImageDir)
+	assert.Equal(t, int64(1024), cfg.Storage.MaxUploadSize)
+}
+
+func TestLoad_SkipsDotEnvInTest(t *testing.T) {
+	inTempDir(t)
+	setDatabaseEnv(t)
+	writeFile(t, ".env", "SERVER_ADDR=:9000\n")
+	t.Setenv("APP_ENV", constant.AppEnvTest)
+
+	cfg, err := config.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, constant.DefaultServerAddr, cfg.Server.Addr)
+}
+
+func TestLoad_ListsEveryProblem(t *testing.T) {
+	inTempDir(t)
+	t.Setenv("DB_USER", "cashier")
+	t.Setenv("DB_NAME", "cashier")
+	t.Setenv("DB_PORT", "postgres")
+	t.Setenv("DB_QUERY_TIMEOUT", "soon")
+	t.Setenv("STORAGE_DRIVER", constant.StorageDriverS3)
+
+	_, err := config.Load()
+
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []string{
+		`DB_QUERY_TIMEOUT: "soon" is not a duration like "30s" or "24h"`,
+		"DB_HOST is required",
+		`DB_PORT must be a port number, got "postgres"`,
+		"S3_ENDPOINT is required",
+		"S3_ACCESS_KEY is required",
+		"S3_SECRET_KEY is required",
+		"S3_BUCKET is required",
+	}, validationErr.Problems)
+	assert.Contains(t, err.Error(), "invalid configuration:\n  - DB_QUERY_TIMEOUT")
+}
+
+func TestLoad_UnknownYAMLKey(t *testing.T) {
+	inTempDir(t)
+	setDatabaseEnv(t)
+	writeFile(t, "config.yaml", "server:\n  adr: \":9000\"\n")
+
+	_, err := config.Load()
+
+	assert.ErrorContains(t, err, "field adr not found")
+}
+
+func TestLoad_MissingConfigFile(t *testing.T) {
+	inTempDir(t)
+	setDatabaseEnv(t)
+	t.Setenv("CONFIG_FILE", "missing.yaml")
+
+	_, err := config.Load()
+
+	assert.ErrorContains(t, err, "CONFIG_FILE")
+}
+
+func TestLoad_ExampleFile(t *testing.T) {
+	example, err := filepath.Abs("../../../config.example.yaml")
+	require.NoError(t, err)
+	inTempDir(t)
+	t.Setenv("CONFIG_FILE", example)
+
+	cfg, err := config.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, config.Default().Database.Timeouts, cfg.Database.Timeouts)
+	assert.Equal(t, config.Default().Storage.MaxUploadSize, cfg.Storage.MaxUploadSize)
+	assert.Equal(t, config.Default().Idempotency, cfg.Idempotency)
+}
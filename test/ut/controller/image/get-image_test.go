@@ -0,0 +1,84 @@
+package controller_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newImageContext(w *httptest.ResponseRecorder, filename string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/assets/image/"+filename, nil)
+	ctx.Params = gin.Params{{Key: "filename", Value: filename}}
+	return ctx
+}
+
+func TestGetImage_Redirect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockStorage := new(test.MockStorage)
+	mockStorage.On("SignedURL", "assets/image/1.jpg").Return("http://minio:9000/cashier/assets/image/1.jpg?X-Amz-Signature=abc", nil)
+
+	w := httptest.NewRecorder()
+	ctx := newImageContext(w, "1.jpg")
+	controller.NewImageController(mockStorage, config.Default()).GetImage(ctx)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "http://minio:9000/cashier/assets/image/1.jpg?X-Amz-Signature=abc", w.Header().Get("Location"))
+	mockStorage.AssertExpectations(t)
+}
+
+func TestGetImage_Stream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockStorage := new(test.MockStorage)
+	mockStorage.On("SignedURL", "assets/image/1.jpg").Return("", nil)
+	mockStorage.On("Get", "assets/image/1.jpg").Return(io.NopCloser(strings.NewReader("jpeg")), nil)
+
+	w := httptest.NewRecorder()
+	ctx := newImageContext(w, "1.jpg")
+	controller.NewImageController(mockStorage, config.Default()).GetImage(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Equal(t, "jpeg", w.Body.String())
+	mockStorage.AssertExpectations(t)
+}
+
+func TestGetImage_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockStorage := new(test.MockStorage)
+	mockStorage.On("SignedURL", "assets/image/gone.jpg").Return("", nil)
+	mockStorage.On("Get", "assets/image/gone.jpg").Return(nil, fs.ErrNotExist)
+
+	w := httptest.NewRecorder()
+	ctx := newImageContext(w, "../gone.jpg")
+	controller.NewImageController(mockStorage, config.Default()).GetImage(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrImageNotFound.Error())
+	mockStorage.AssertExpectations(t)
+}
+
+func TestGetImage_ISE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockStorage := new(test.MockStorage)
+	mockStorage.On("SignedURL", "assets/image/1.jpg").Return("", errors.New("ISE"))
+
+	w := httptest.NewRecorder()
+	ctx := newImageContext(w, "1.jpg")
+	controller.NewImageController(mockStorage, config.Default()).GetImage(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrISEImage.Error())
+	mockStorage.AssertExpectations(t)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/dto"
 	test "tiga-putra-cashier-be/test/mocks/product"
@@ -50,7 +51,7 @@ func TestAddProduct_Success(t *testing.T) {
 			req.Image.Filename == "test.jpg"
 	})).Return(nil)
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.AddProduct(ctx)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -84,7 +85,7 @@ func TestAddProduct_BadRequestMissing(t *testing.T) {
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = request
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.AddProduct(ctx)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -126,7 +127,7 @@ func TestAddProduct_BadRequestOverSize(t *testing.T) {
 			req.Image.Filename == "test.jpg"
 	})).Return(dto.ErrLimitSizeExceeded)
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.AddProduct(ctx)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -168,7 +169,7 @@ func TestAddProduct_BadRequestWrongExtension(t *testing.T) {
 			req.Image.Filename == "test.webp"
 	})).Return(dto.ErrWrongFileExtension)
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.AddProduct(ctx)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -210,7 +211,7 @@ func TestAddProduct_Conflict(t *testing.T) {
 			req.Image.Filename == "test.jpg"
 	})).Return(dto.ErrProductExist)
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.AddProduct(ctx)
 
 	assert.Equal(t, http.StatusConflict, w.Code)
@@ -252,7 +253,7 @@ func TestAddProduct_ISE(t *testing.T) {
 			req.Image.Filename == "test.jpg"
 	})).Return(errors.New("ISE"))
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.AddProduct(ctx)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -0,0 +1,77 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/product"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjustPrices_Preview(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	body := `{"category":"Snack","type":"percentage","value":"10","round_to":"500","preview":true}`
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/product/price-adjustment", bytes.NewBufferString(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	mockService.On("AdjustPricesService", dto.PriceAdjustmentRequest{
+		ProductFilter: dto.ProductFilter{Category: "Snack"},
+		Type:          "percentage",
+		Value:         decimal.NewFromInt(10),
+		RoundTo:       decimal.NewFromInt(500),
+		Preview:       true,
+	}).Return(dto.PriceAdjustmentResult{Preview: true, Total: 1, Changed: 1}, nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.AdjustPrices(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_PREVIEW_PRICE_ADJUSTMENT)
+	mockService.AssertExpectations(t)
+}
+
+func TestAdjustPrices_BadType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/product/price-adjustment", bytes.NewBufferString(`{"category":"Snack","type":"double","value":"2"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.AdjustPrices(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdjustPrices_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/product/price-adjustment", bytes.NewBufferString(`{"barcode_ids":["9"],"type":"fixed","value":"500"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	mockService.On("AdjustPricesService", dto.PriceAdjustmentRequest{
+		ProductFilter: dto.ProductFilter{BarcodeIds: []string{"9"}},
+		Type:          "fixed",
+		Value:         decimal.NewFromInt(500),
+	}).Return(dto.PriceAdjustmentResult{}, dto.ErrProductsNotFound)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.AdjustPrices(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
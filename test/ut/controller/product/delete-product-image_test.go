@@ -0,0 +1,83 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/product"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteProductImage_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	mockService.On("DeleteProductImageService", "1", uint(2)).Return(nil)
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1/images/2", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}, {Key: "image_id", Value: "2"}}
+	pic.DeleteProductImage(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_DELETE_PRODUCT_IMAGE)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteProductImage_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1/images/abc", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}, {Key: "image_id", Value: "abc"}}
+	pic.DeleteProductImage(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrBadrequest.Error())
+}
+
+func TestDeleteProductImage_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	mockService.On("DeleteProductImageService", "1", uint(2)).Return(dto.ErrProductImageNotFound)
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1/images/2", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}, {Key: "image_id", Value: "2"}}
+	pic.DeleteProductImage(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrProductImageNotFound.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteProductImage_ISE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	mockService.On("DeleteProductImageService", "1", uint(2)).Return(errors.New("ISE"))
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1/images/2", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}, {Key: "image_id", Value: "2"}}
+	pic.DeleteProductImage(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/dto"
 	test "tiga-putra-cashier-be/test/mocks/product"
@@ -18,8 +19,8 @@ func TestDeleteProduct_Success(t *testing.T) {
 	mockService := new(test.MockProductService)
 
 	barcodeId := "1"
-	mockService.On("DeleteProductService", &barcodeId).Return(nil)
-	pc := controller.NewProductController(mockService)
+	mockService.On("DeleteProductService", &barcodeId, (*uint)(nil)).Return(nil)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -37,7 +38,7 @@ func TestDeleteProduct_BadRequest(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(test.MockProductService)
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -54,8 +55,8 @@ func TestDeleteProduct_NotFound(t *testing.T) {
 	mockService := new(test.MockProductService)
 
 	barcodeId := "1"
-	mockService.On("DeleteProductService", &barcodeId).Return(dto.ErrProductDoesntExist)
-	pc := controller.NewProductController(mockService)
+	mockService.On("DeleteProductService", &barcodeId, (*uint)(nil)).Return(dto.ErrProductDoesntExist)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -74,8 +75,8 @@ func TestDeleteProduct_ISE(t *testing.T) {
 	mockService := new(test.MockProductService)
 
 	barcodeId := "1"
-	mockService.On("DeleteProductService", &barcodeId).Return(errors.New("ISE"))
-	pc := controller.NewProductController(mockService)
+	mockService.On("DeleteProductService", &barcodeId, (*uint)(nil)).Return(errors.New("ISE"))
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodDelete, "/v1/product/1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -0,0 +1,89 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/product"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportProduct_DefaultCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/product/export", nil)
+
+	mockService.On("ExportProductsService", false, mock.Anything).Run(func(args mock.Arguments) {
+		writer := args.Get(1).(utils.ExportWriter)
+		_ = writer.WriteHeader("barcode_id", "title")
+		_ = writer.WriteRow("1", "Indomie")
+	}).Return([]string{}, nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ExportProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="products.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "barcode_id,title\n1,Indomie\n", w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestExportProduct_BadFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/product/export?format=docx", nil)
+
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ExportProduct(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExportProduct_ISE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/product/export?format=xlsx", nil)
+
+	mockService.On("ExportProductsService", false, mock.Anything).Return([]string{}, dto.ErrISEProducts)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ExportProduct(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), dto.ErrISEProducts.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestExportProduct_WithImages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/product/export?format=xlsx&include_deleted=true&with_images=true", nil)
+
+	mockService.On("ExportProductArchiveService", dto.ExportProductQuery{Format: "xlsx", IncludeDeleted: true, WithImages: true}, mock.Anything).Return(nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ExportProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="products.zip"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	mockService.AssertExpectations(t)
+}
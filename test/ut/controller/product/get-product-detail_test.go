@@ -1,10 +1,13 @@
 package controller_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/dto"
 	test "tiga-putra-cashier-be/test/mocks/product"
@@ -26,9 +29,10 @@ func TestGetProductDetail_Success(t *testing.T) {
 		Title:       "title-1",
 		Price:       decimal.NewFromInt(1000),
 		Description: "description-1",
+		Version:     4,
 	}
-	mockService.On("GetProductDetailService", &barcodeId).Return(product, nil)
-	pc := controller.NewProductController(mockService)
+	mockService.On("GetProductDetailService", &barcodeId, uint(0)).Return(product, nil)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product/1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -39,6 +43,7 @@ func TestGetProductDetail_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "200")
 	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_PRODUCT_DETAIL)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
 
 	var actualResponse struct {
 		Data dto.ProductWithoutTimeStamp `json:"data"`
@@ -53,7 +58,7 @@ func TestGetProductDetail_Success(t *testing.T) {
 func TestGetProductDetail_BadRequest(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(test.MockProductService)
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -68,10 +73,10 @@ func TestGetProductDetail_BadRequest(t *testing.T) {
 func TestGetProductDetail_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(test.MockProductService)
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 
 	barcodeId := "1"
-	mockService.On("GetProductDetailService", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, dto.ErrProductDoesntExist)
+	mockService.On("GetProductDetailService", &barcodeId, uint(0)).Return(dto.ProductWithoutTimeStamp{}, dto.ErrProductDoesntExist)
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product/1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -82,5 +87,63 @@ func TestGetProductDetail_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 	assert.Contains(t, w.Body.String(), "404")
 	assert.Contains(t, w.Body.String(), dto.ErrProductDoesntExist.Error())
+	assert.Empty(t, w.Header().Get("ETag"))
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProductDetail_InternalServerError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+	pc := controller.NewProductController(mockService, config.Default())
+
+	barcodeId := "1"
+	mockService.On("GetProductDetailService", &barcodeId, uint(0)).Return(dto.ProductWithoutTimeStamp{}, dto.ErrISEProducts)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product/1", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pc.GetProductDetail(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrISEProducts.Error())
+	assert.NotContains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_PRODUCT_DETAIL)
+	assert.Empty(t, w.Header().Get("ETag"))
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProductDetail_Canceled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+	pc := controller.NewProductController(mockService, config.Default())
+
+	barcodeId := "1"
+	mockService.On("GetProductDetailService", &barcodeId, uint(0)).Return(dto.ProductWithoutTimeStamp{}, context.Canceled)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/product/1", nil)
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pc.GetProductDetail(ctx)
+
+	assert.Equal(t, constant.StatusClientClosedRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrRequestCanceled.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProductDetail_Timeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+	pc := controller.NewProductController(mockService, config.Default())
+
+	barcodeId := "1"
+	mockService.On("GetProductDetailService", &barcodeId, uint(0)).Return(dto.ProductWithoutTimeStamp{}, context.DeadlineExceeded)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/product/1", nil)
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pc.GetProductDetail(ctx)
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrRequestTimeout.Error())
 	mockService.AssertExpectations(t)
 }
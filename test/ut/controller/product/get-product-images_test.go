@@ -0,0 +1,51 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/product"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProductImages_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	images := []dto.ProductImageResponse{{Id: 1, Image: "a_original.jpg", Primary: true}}
+	mockService.On("GetProductImagesService", "1").Return(images, nil)
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product/1/images", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pic.GetProductImages(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_PRODUCT_IMAGES)
+	assert.Contains(t, w.Body.String(), "a_original.jpg")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProductImages_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	mockService.On("GetProductImagesService", "1").Return(nil, dto.ErrProductDoesntExist)
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product/1/images", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pic.GetProductImages(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrProductDoesntExist.Error())
+	mockService.AssertExpectations(t)
+}
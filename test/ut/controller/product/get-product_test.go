@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/dto"
 	test "tiga-putra-cashier-be/test/mocks/product"
@@ -20,7 +21,7 @@ func TestGetProduct_Success(t *testing.T) {
 
 	mockService := new(test.MockProductService)
 
-	var page uint16 = 1
+	query := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 1}}
 	var products []dto.ProductWithoutTimeStamp = []dto.ProductWithoutTimeStamp{
 		{
 			BarcodeId:   "1",
@@ -52,8 +53,8 @@ func TestGetProduct_Success(t *testing.T) {
 		Data dto.AllProductsWithPagination `json:"data"`
 	}
 
-	mockService.On("GetProductService", &page).Return(expectedProducts, nil)
-	pc := controller.NewProductController(mockService)
+	mockService.On("GetProductService", &query).Return(expectedProducts, nil)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product?page=1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -73,13 +74,13 @@ func TestGetProduct_Success(t *testing.T) {
 func TestGetProduct_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(test.MockProductService)
-	var page uint16 = 1
-	mockService.On("GetProductService", &page).Return(dto.AllProductsWithPagination{}, dto.ErrProductsNotFound)
+	query := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 1}}
+	mockService.On("GetProductService", &query).Return(dto.AllProductsWithPagination{}, dto.ErrProductsNotFound)
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product?page=1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = req
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.GetProduct(ctx)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
@@ -91,8 +92,8 @@ func TestGetProduct_BadRequest(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockService := new(test.MockProductService)
-	pc := controller.NewProductController(mockService)
-	req, _ := http.NewRequest(http.MethodGet, "/v1/product", nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product?page=-1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = req
@@ -106,13 +107,13 @@ func TestGetProduct_BadRequest(t *testing.T) {
 func TestGetProduct_InternalServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(test.MockProductService)
-	var page uint16 = 1
-	mockService.On("GetProductService", &page).Return(dto.AllProductsWithPagination{}, dto.ErrISEProducts)
+	query := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 1}}
+	mockService.On("GetProductService", &query).Return(dto.AllProductsWithPagination{}, dto.ErrISEProducts)
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product?page=1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = req
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.GetProduct(ctx)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -120,3 +121,91 @@ func TestGetProduct_InternalServerError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), dto.ErrISEProducts.Error())
 	mockService.AssertExpectations(t)
 }
+
+func TestGetProduct_SortAndFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	minPrice := decimal.NewFromInt(1000)
+	maxPrice := decimal.NewFromInt(5000)
+	hasImage := false
+	query := dto.ProductListQuery{
+		PaginationRequest: dto.PaginationRequest{Page: 2, PageSize: 50},
+		Sort:              "stock",
+		Order:             "desc",
+		MinPrice:          &minPrice,
+		MaxPrice:          &maxPrice,
+		Category:          "Snack",
+		HasImage:          &hasImage,
+		StoreId:           2,
+	}
+	mockService.On("GetProductService", &query).Return(dto.AllProductsWithPagination{}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product?page=2&page_size=50&sort=stock&order=desc&min_price=1000&max_price=5000&category=Snack&has_image=false&store_id=2", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.GetProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProduct_BadPageSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	pc := controller.NewProductController(mockService, config.Default())
+	for _, url := range []string{
+		"/v1/product?page=1&page_size=500",
+		"/v1/product?page=1&sort=barcode",
+		"/v1/product?page=1&min_price=5000&max_price=1000",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = req
+		pc.GetProduct(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+}
+
+func TestGetProduct_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	query := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{PageSize: 20}, Sort: "price", Cursor: "eyJpIjoxfQ"}
+	mockService.On("GetProductsByCursorService", &query).Return(dto.ProductsWithCursor{
+		Products: []dto.ProductWithoutTimeStamp{{BarcodeId: "1"}},
+		Cursor:   dto.CursorResponse{NextCursor: "next", HasMore: true, PageSize: 20},
+	}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product?page_size=20&sort=price&cursor=eyJpIjoxfQ", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.GetProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"next_cursor":"next"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProduct_InvalidCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	query := dto.ProductListQuery{Cursor: "garbage"}
+	mockService.On("GetProductsByCursorService", &query).Return(dto.ProductsWithCursor{}, dto.ErrInvalidCursor)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product?cursor=garbage", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.GetProduct(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrInvalidCursor.Error())
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,106 @@
+package controller_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/product"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func importRequest(dryRun bool) *http.Request {
+	reqBody := &bytes.Buffer{}
+	formWriter := multipart.NewWriter(reqBody)
+	if dryRun {
+		_ = formWriter.WriteField("dry_run", "true")
+	}
+	fileWriter, _ := formWriter.CreateFormFile("file", "products.csv")
+	_, _ = fileWriter.Write([]byte("barcode_id,title,price,description\n1,Indomie,3500,Mie goreng\n"))
+	formWriter.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/product/import", reqBody)
+	request.Header.Set("Content-Type", formWriter.FormDataContentType())
+	return request
+}
+
+func TestImportProduct_DryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = importRequest(true)
+
+	mockService.On("ImportProductsService", mock.MatchedBy(func(req dto.ImportProductRequest) bool {
+		return req.DryRun && req.File.Filename == "products.csv" && req.Images == nil
+	})).Return(dto.ImportProductResult{DryRun: true, Total: 1, Created: 1}, nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ImportProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_VALIDATE_PRODUCTS)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportProduct_MissingFile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	reqBody := &bytes.Buffer{}
+	formWriter := multipart.NewWriter(reqBody)
+	_ = formWriter.WriteField("dry_run", "true")
+	formWriter.Close()
+	request := httptest.NewRequest(http.MethodPost, "/v1/product/import", reqBody)
+	request.Header.Set("Content-Type", formWriter.FormDataContentType())
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ImportProduct(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestImportProduct_InvalidHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = importRequest(false)
+
+	mockService.On("ImportProductsService", mock.Anything).Return(dto.ImportProductResult{}, dto.ErrInvalidImportHeader)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ImportProduct(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrInvalidImportHeader.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestImportProduct_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = importRequest(false)
+
+	mockService.On("ImportProductsService", mock.Anything).Return(dto.ImportProductResult{Total: 1, Updated: 1}, nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.ImportProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_IMPORT_PRODUCTS)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,71 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/product"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorderProductImages_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	images := []dto.ProductImageResponse{{Id: 2, Position: 0}, {Id: 1, Position: 1, Primary: true}}
+	mockService.On("ReorderProductImagesService", "1", []uint{2, 1}).Return(images, nil)
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/product/1/images/order", strings.NewReader(`{"image_ids":[2,1]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pic.ReorderProductImages(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_REORDER_PRODUCT_IMAGES)
+	mockService.AssertExpectations(t)
+}
+
+func TestReorderProductImages_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/product/1/images/order", strings.NewReader(`{"image_ids":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pic.ReorderProductImages(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrBadrequest.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestReorderProductImages_InvalidOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductImageService)
+
+	mockService.On("ReorderProductImagesService", "1", []uint{1, 1}).Return(nil, dto.ErrInvalidImageOrder)
+	pic := controller.NewProductImageController(mockService)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/product/1/images/order", strings.NewReader(`{"image_ids":[1,1]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+	pic.ReorderProductImages(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrInvalidImageOrder.Error())
+	mockService.AssertExpectations(t)
+}
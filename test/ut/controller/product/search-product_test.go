@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/dto"
 	test "tiga-putra-cashier-be/test/mocks/product"
@@ -41,10 +42,10 @@ func TestSearchProduct_Success(t *testing.T) {
 		},
 	}
 	var actualResponse struct {
-		Data []dto.ProductWithoutTimeStamp `json:"data"`
+		Data dto.AllProductsWithPagination `json:"data"`
 	}
-	mockService.On("SearchProductService", &reqQuery).Return(products, nil)
-	pc := controller.NewProductController(mockService)
+	mockService.On("SearchProductService", &reqQuery).Return(dto.AllProductsWithPagination{Products: products}, nil)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product/search?title=title-1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -57,7 +58,7 @@ func TestSearchProduct_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "200")
 	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_SEARCH_PRODUCTS)
-	assert.Equal(t, products, actualResponse.Data)
+	assert.Equal(t, products, actualResponse.Data.Products)
 	mockService.AssertExpectations(t)
 }
 
@@ -65,7 +66,7 @@ func TestSearchProduct_BadRequestNoQuery(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(test.MockProductService)
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product/search", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -77,7 +78,6 @@ func TestSearchProduct_BadRequestNoQuery(t *testing.T) {
 	assert.Contains(t, w.Body.String(), dto.ErrBadrequest.Error())
 }
 
-
 func TestSearchProduct_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(test.MockProductService)
@@ -87,8 +87,8 @@ func TestSearchProduct_NotFound(t *testing.T) {
 		Title: &title,
 	}
 
-	mockService.On("SearchProductService", &reqQuery).Return([]dto.ProductWithoutTimeStamp{}, dto.ErrProductsNotFound)
-	pc := controller.NewProductController(mockService)
+	mockService.On("SearchProductService", &reqQuery).Return(dto.AllProductsWithPagination{}, dto.ErrProductsNotFound)
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product/search?title=title-1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -110,8 +110,8 @@ func TestSearchProduct_ISE(t *testing.T) {
 		Title: &title,
 	}
 
-	mockService.On("SearchProductService", &reqQuery).Return([]dto.ProductWithoutTimeStamp{}, errors.New("ISE"))
-	pc := controller.NewProductController(mockService)
+	mockService.On("SearchProductService", &reqQuery).Return(dto.AllProductsWithPagination{}, errors.New("ISE"))
+	pc := controller.NewProductController(mockService, config.Default())
 	req, _ := http.NewRequest(http.MethodGet, "/v1/product/search?title=title-1", nil)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -123,3 +123,52 @@ func TestSearchProduct_ISE(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "ISE")
 	mockService.AssertExpectations(t)
 }
+
+func TestSearchProduct_QueryWithPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	reqQuery := dto.SearchProductQuery{Query: "indomi", Page: 2}
+	mockService.On("SearchProductService", &reqQuery).Return(dto.AllProductsWithPagination{}, nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product/search?q=indomi&page=2", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	pc.SearchProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAutocompleteProduct_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	mockService.On("AutocompleteProductService", "ind").Return([]dto.ProductSuggestion{{BarcodeId: "1", Title: "Indomie"}}, nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product/autocomplete?q=ind", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	pc.AutocompleteProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_AUTOCOMPLETE)
+	assert.Contains(t, w.Body.String(), "Indomie")
+	mockService.AssertExpectations(t)
+}
+
+func TestAutocompleteProduct_MissingQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	pc := controller.NewProductController(mockService, config.Default())
+	req, _ := http.NewRequest(http.MethodGet, "/v1/product/autocomplete", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	pc.AutocompleteProduct(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
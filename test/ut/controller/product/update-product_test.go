@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/controller"
 	"tiga-putra-cashier-be/dto"
 	test "tiga-putra-cashier-be/test/mocks/product"
@@ -36,8 +37,8 @@ func TestUpdateProduct_Success(t *testing.T) {
 	titleExpected := "title-2"
 	mockService.On("UpdateProductService", "1", mock.MatchedBy(func(req dto.UpdateProductRequest) bool {
 		return *req.Title == titleExpected
-	})).Return(nil)
-	pc := controller.NewProductController(mockService)
+	}), (*uint)(nil)).Return(nil)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.UpdateProduct(ctx)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -63,7 +64,7 @@ func TestUpdateProduct_BadRequestURI(t *testing.T) {
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = request
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.UpdateProduct(ctx)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -89,9 +90,9 @@ func TestUpdateProduct_BadRequestBody(t *testing.T) {
 	ctx.Request = request
 	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
 
-	mockService.On("UpdateProductService", "1", dto.UpdateProductRequest{}).Return(dto.ErrNoChangesRequest)
+	mockService.On("UpdateProductService", "1", dto.UpdateProductRequest{}, (*uint)(nil)).Return(dto.ErrNoChangesRequest)
 
-	pc := controller.NewProductController(mockService)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.UpdateProduct(ctx)
 
 	assert.Equal(t, http.StatusNotModified, w.Code)
@@ -118,8 +119,8 @@ func TestUpdateProduct_NotFound(t *testing.T) {
 	titleExpected := "title-2"
 	mockService.On("UpdateProductService", "1", mock.MatchedBy(func(req dto.UpdateProductRequest) bool {
 		return *req.Title == titleExpected
-	})).Return(dto.ErrProductDoesntExist)
-	pc := controller.NewProductController(mockService)
+	}), (*uint)(nil)).Return(dto.ErrProductDoesntExist)
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.UpdateProduct(ctx)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
@@ -149,8 +150,8 @@ func TestUpdateProduct_ISE(t *testing.T) {
 	titleExpected := "title-2"
 	mockService.On("UpdateProductService", "1", mock.MatchedBy(func(req dto.UpdateProductRequest) bool {
 		return *req.Title == titleExpected
-	})).Return(errors.New("ISE"))
-	pc := controller.NewProductController(mockService)
+	}), (*uint)(nil)).Return(errors.New("ISE"))
+	pc := controller.NewProductController(mockService, config.Default())
 	pc.UpdateProduct(ctx)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -159,3 +160,111 @@ func TestUpdateProduct_ISE(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestUpdateProduct_IfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	reqBody := &bytes.Buffer{}
+	formWriter := multipart.NewWriter(reqBody)
+	_ = formWriter.WriteField("title", "title-2")
+	formWriter.Close()
+
+	request := httptest.NewRequest(http.MethodPatch, "/v1/product/1", reqBody)
+	request.Header.Set("Content-Type", formWriter.FormDataContentType())
+	request.Header.Set("If-Match", `"3"`)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+
+	mockService.On("UpdateProductService", "1", mock.Anything, mock.MatchedBy(func(version *uint) bool {
+		return version != nil && *version == 3
+	})).Return(nil)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.UpdateProduct(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateProduct_VersionMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	request := httptest.NewRequest(http.MethodPatch, "/v1/product/1", nil)
+	request.Header.Set("If-Match", `"3"`)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+
+	mockService.On("UpdateProductService", "1", mock.Anything, mock.Anything).Return(dto.ErrVersionMismatch)
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.UpdateProduct(ctx)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrVersionMismatch.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateProduct_InvalidIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	request := httptest.NewRequest(http.MethodPatch, "/v1/product/1", nil)
+	request.Header.Set("If-Match", "not-an-etag")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.UpdateProduct(ctx)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockService.AssertNotCalled(t, "UpdateProductService")
+}
+
+func TestUpdateProduct_WeakIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockProductService)
+
+	request := httptest.NewRequest(http.MethodPatch, "/v1/product/1", nil)
+	request.Header.Set("If-Match", `W/"3"`)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+
+	pc := controller.NewProductController(mockService, config.Default())
+	pc.UpdateProduct(ctx)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockService.AssertNotCalled(t, "UpdateProductService")
+}
+
+func TestUpdateProduct_IfMatchRequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Default()
+	cfg.App.RequireIfMatch = true
+	mockService := new(test.MockProductService)
+
+	request := httptest.NewRequest(http.MethodPatch, "/v1/product/1", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+
+	pc := controller.NewProductController(mockService, cfg)
+	pc.UpdateProduct(ctx)
+
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrIfMatchRequired.Error())
+	mockService.AssertNotCalled(t, "UpdateProductService")
+}
@@ -0,0 +1,210 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/report"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSalesSummary_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/sales?from=2025-01-01&to=2025-01-31&period=week&store_id=2", nil)
+
+	mockService.On("GetSalesSummaryService", dto.SalesSummaryQuery{
+		ReportQuery: dto.ReportQuery{From: "2025-01-01", To: "2025-01-31", StoreId: 2},
+		Period:      "week",
+	}).Return([]dto.SalesPeriodTotal{{Period: "2024-12-30", Transactions: 2, Revenue: decimal.NewFromInt(30000)}}, nil)
+	rc := controller.NewReportController(mockService)
+	rc.GetSalesSummary(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "2024-12-30")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetSalesSummary_BadPeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/sales?from=2025-01-01&to=2025-01-31&period=year", nil)
+
+	rc := controller.NewReportController(mockService)
+	rc.GetSalesSummary(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetHourlySales_MissingRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/sales/hourly?from=2025-01-01", nil)
+
+	rc := controller.NewReportController(mockService)
+	rc.GetHourlySales(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetPaymentMethodSales_InvalidRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/sales/payment-method?from=2025-02-01&to=2025-01-01", nil)
+
+	mockService.On("GetPaymentMethodSalesService", dto.ReportQuery{From: "2025-02-01", To: "2025-01-01"}).Return([]dto.PaymentMethodSales{}, dto.ErrInvalidReportRange)
+	rc := controller.NewReportController(mockService)
+	rc.GetPaymentMethodSales(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetTopProducts_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/product/top?from=2025-01-01&to=2025-01-31&by=quantity&limit=5", nil)
+
+	mockService.On("GetTopProductsService", dto.TopProductsQuery{
+		ReportQuery: dto.ReportQuery{From: "2025-01-01", To: "2025-01-31"},
+		By:          "quantity",
+		Limit:       5,
+	}).Return([]dto.ProductSales{{BarcodeId: "1", Title: "Indomie", Quantity: 40}}, nil)
+	rc := controller.NewReportController(mockService)
+	rc.GetTopProducts(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_TOP_PRODUCTS)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetCategorySales_ISE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/sales/category?from=2025-01-01&to=2025-01-31", nil)
+
+	mockService.On("GetCategorySalesService", dto.ReportQuery{From: "2025-01-01", To: "2025-01-31"}).Return([]dto.CategorySales{}, dto.ErrISEReport)
+	rc := controller.NewReportController(mockService)
+	rc.GetCategorySales(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetSalesSummary_ExportCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/sales?from=2025-01-01&to=2025-01-31&format=csv", nil)
+
+	mockService.On("ExportSalesSummaryService", dto.SalesSummaryQuery{
+		ReportQuery: dto.ReportQuery{From: "2025-01-01", To: "2025-01-31"},
+	}, mock.Anything).Run(func(args mock.Arguments) {
+		writer := args.Get(1).(utils.ExportWriter)
+		_ = writer.WriteHeader("Period", "Transactions")
+		_ = writer.WriteRow("2025-01-01", int64(3))
+	}).Return(nil)
+	rc := controller.NewReportController(mockService)
+	rc.GetSalesSummary(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="sales-summary_2025-01-01_2025-01-31.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "Period,Transactions\n2025-01-01,3\n", w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestGetTopProducts_ExportInvalidRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/product/top?from=2025-02-01&to=2025-01-01&format=pdf", nil)
+
+	mockService.On("ExportTopProductsService", dto.TopProductsQuery{
+		ReportQuery: dto.ReportQuery{From: "2025-02-01", To: "2025-01-01"},
+	}, mock.Anything).Return(dto.ErrInvalidReportRange)
+	rc := controller.NewReportController(mockService)
+	rc.GetTopProducts(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), dto.ErrInvalidReportRange.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestGetCashierSales_BadFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/sales/cashier?from=2025-01-01&to=2025-01-31&format=docx", nil)
+
+	rc := controller.NewReportController(mockService)
+	rc.GetCashierSales(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetStockValuation_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/stock/valuation?store_id=2", nil)
+
+	mockService.On("GetStockValuationService", uint(2)).Return([]dto.StockValuation{{StoreId: 2, BarcodeId: "1", Quantity: 10, Value: decimal.NewFromInt(35000)}}, nil)
+	rc := controller.NewReportController(mockService)
+	rc.GetStockValuation(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_STOCK_VALUATION)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStockValuation_ExportXLSX(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockReportService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/report/stock/valuation?format=xlsx", nil)
+
+	mockService.On("ExportStockValuationService", uint(0), mock.Anything).Return(nil)
+	rc := controller.NewReportController(mockService)
+	rc.GetStockValuation(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="stock-valuation.xlsx"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+	mockService.AssertExpectations(t)
+}
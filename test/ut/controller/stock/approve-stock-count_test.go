@@ -0,0 +1,79 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproveStockCount_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count/1/approve", bytes.NewBufferString(`{"approved_by":"supervisor"}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	mockService.On("ApproveStockCountService", uint(1), dto.ApproveStockCountRequest{ApprovedBy: "supervisor"}).
+		Return([]dto.StockVariance{{BarcodeId: "1", Variance: 2}}, nil)
+	scc := controller.NewStockCountController(mockService)
+	scc.ApproveStockCount(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_APPROVE_STOCK_COUNT)
+	mockService.AssertExpectations(t)
+}
+
+func TestApproveStockCount_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count/1/approve", bytes.NewBufferString(`{}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	scc := controller.NewStockCountController(mockService)
+	scc.ApproveStockCount(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestApproveStockCount_ErrorMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[error]int{
+		dto.ErrStockCountNotFound: http.StatusNotFound,
+		dto.ErrStockCountNotOpen:  http.StatusConflict,
+		dto.ErrUncountedProducts:  http.StatusConflict,
+		dto.ErrISEStockCount:      http.StatusInternalServerError,
+	}
+	for serviceErr, status := range cases {
+		mockService := new(test.MockStockCountService)
+		request := httptest.NewRequest(http.MethodPost, "/v1/stock-count/1/approve", bytes.NewBufferString(`{"approved_by":"supervisor"}`))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = request
+		ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+		mockService.On("ApproveStockCountService", uint(1), dto.ApproveStockCountRequest{ApprovedBy: "supervisor"}).
+			Return([]dto.StockVariance{}, serviceErr)
+		scc := controller.NewStockCountController(mockService)
+		scc.ApproveStockCount(ctx)
+
+		assert.Equal(t, status, w.Code)
+		assert.Contains(t, w.Body.String(), serviceErr.Error())
+	}
+}
@@ -0,0 +1,92 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStockCountReport_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock-count/1", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	mockService.On("GetStockCountReportService", uint(1)).Return(dto.StockCountReport{
+		Session:   dto.StockCountSessionResponse{Id: 1},
+		Variances: []dto.StockVariance{{BarcodeId: "1", Variance: -2}},
+		Uncounted: []dto.UncountedProduct{{BarcodeId: "2"}},
+	}, nil)
+	scc := controller.NewStockCountController(mockService)
+	scc.GetStockCountReport(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_STOCK_COUNT_REPORT)
+	assert.Contains(t, w.Body.String(), `"variance":-2`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStockCountReport_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock-count/1", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	mockService.On("GetStockCountReportService", uint(1)).Return(dto.StockCountReport{}, dto.ErrStockCountNotFound)
+	scc := controller.NewStockCountController(mockService)
+	scc.GetStockCountReport(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetUncountedProducts_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock-count/1/uncounted", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	mockService.On("GetUncountedProductsService", uint(1)).Return([]dto.UncountedProduct{{BarcodeId: "2", Title: "Product B"}}, nil)
+	scc := controller.NewStockCountController(mockService)
+	scc.GetUncountedProducts(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Product B")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetUncountedProducts_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock-count/1/uncounted", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	mockService.On("GetUncountedProductsService", uint(1)).Return([]dto.UncountedProduct{}, dto.ErrStockCountNotFound)
+	scc := controller.NewStockCountController(mockService)
+	scc.GetUncountedProducts(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
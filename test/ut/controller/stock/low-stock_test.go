@@ -0,0 +1,99 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLowStockProducts_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock/low", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("GetLowStockProductsService", uint(0)).Return([]dto.LowStockProduct{{BarcodeId: "1", CurrentStock: 1, MinStock: 5}}, nil)
+	sc := controller.NewStockController(mockService)
+	sc.GetLowStockProducts(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_LOW_STOCK_PRODUCTS)
+	assert.Contains(t, w.Body.String(), `"min_stock":5`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetLowStockProducts_ISE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock/low", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("GetLowStockProductsService", uint(0)).Return([]dto.LowStockProduct{}, dto.ErrISEStock)
+	sc := controller.NewStockController(mockService)
+	sc.GetLowStockProducts(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetReorderSuggestion_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock/reorder-suggestion?window_days=14", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("GetReorderSuggestionService", dto.ReorderSuggestionQuery{WindowDays: 14}).Return(dto.ReorderSuggestion{WindowDays: 14}, nil)
+	sc := controller.NewStockController(mockService)
+	sc.GetReorderSuggestion(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"window_days":14`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetReorderSuggestion_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock/reorder-suggestion?window_days=400", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	sc := controller.NewStockController(mockService)
+	sc.GetReorderSuggestion(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetReorderSuggestion_ISE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock/reorder-suggestion", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("GetReorderSuggestionService", dto.ReorderSuggestionQuery{WindowDays: 0}).Return(dto.ReorderSuggestion{}, dto.ErrISEStock)
+	sc := controller.NewStockController(mockService)
+	sc.GetReorderSuggestion(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,75 @@
+package controller_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartStockCount_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count", bytes.NewBufferString(`{"note":"monthly"}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("StartStockCountService", dto.StartStockCountRequest{Note: "monthly"}).
+		Return(dto.StockCountSessionResponse{Id: 1, Status: constant.StockCountOpen, Note: "monthly"}, nil)
+	scc := controller.NewStockCountController(mockService)
+	scc.StartStockCount(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_START_STOCK_COUNT)
+	assert.Contains(t, w.Body.String(), `"status":"open"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestStartStockCount_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("StartStockCountService", dto.StartStockCountRequest{}).
+		Return(dto.StockCountSessionResponse{}, dto.ErrStockCountAlreadyOpen)
+	scc := controller.NewStockCountController(mockService)
+	scc.StartStockCount(ctx)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrStockCountAlreadyOpen.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestStartStockCount_ISE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("StartStockCountService", dto.StartStockCountRequest{}).
+		Return(dto.StockCountSessionResponse{}, errors.New("ISE"))
+	scc := controller.NewStockCountController(mockService)
+	scc.StartStockCount(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "ISE")
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,134 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiveStock_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock/receipt", bytes.NewBufferString(`{"barcode_id":"1","expiry_date":"2026-12-01","quantity":10}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("ReceiveStockService", dto.ReceiveStockRequest{BarcodeId: "1", ExpiryDate: "2026-12-01", Quantity: 10}).
+		Return(dto.StockBatch{Id: 4, BarcodeId: "1", ReceivedQuantity: 10, RemainingQuantity: 10}, nil)
+	sc := controller.NewStockController(mockService)
+	sc.ReceiveStock(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_RECEIVE_STOCK)
+	mockService.AssertExpectations(t)
+}
+
+func TestReceiveStock_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock/receipt", bytes.NewBufferString(`{"barcode_id":"1","quantity":0}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	sc := controller.NewStockController(mockService)
+	sc.ReceiveStock(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReceiveStock_ErrorMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[error]int{
+		dto.ErrInvalidExpiryDate:  http.StatusBadRequest,
+		dto.ErrProductDoesntExist: http.StatusNotFound,
+		dto.ErrISEStock:           http.StatusInternalServerError,
+	}
+	for serviceErr, code := range cases {
+		mockService := new(test.MockStockService)
+		request := httptest.NewRequest(http.MethodPost, "/v1/stock/receipt", bytes.NewBufferString(`{"barcode_id":"1","quantity":10}`))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = request
+
+		mockService.On("ReceiveStockService", dto.ReceiveStockRequest{BarcodeId: "1", Quantity: 10}).Return(dto.StockBatch{}, serviceErr)
+		sc := controller.NewStockController(mockService)
+		sc.ReceiveStock(ctx)
+
+		assert.Equal(t, code, w.Code)
+	}
+}
+
+func TestGetExpiringBatches_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/stock/batch/expiring?days=14", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("GetExpiringBatchesService", dto.ExpiringBatchQuery{Days: 14}).Return([]dto.ExpiringBatch{{BatchId: 4, Expired: true}}, nil)
+	sc := controller.NewStockController(mockService)
+	sc.GetExpiringBatches(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"expired":true`)
+	mockService.AssertExpectations(t)
+}
+
+func TestWriteOffBatch_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock/batch/4/write-off", bytes.NewBufferString(`{"note":"expired"}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "batch_id", Value: "4"}}
+
+	mockService.On("WriteOffBatchService", uint(4), dto.WriteOffBatchRequest{Note: "expired"}).Return(dto.StockBatch{Id: 4}, nil)
+	sc := controller.NewStockController(mockService)
+	sc.WriteOffBatch(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_WRITE_OFF_BATCH)
+	mockService.AssertExpectations(t)
+}
+
+func TestWriteOffBatch_ErrorMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[error]int{
+		dto.ErrBatchNotFound: http.StatusNotFound,
+		dto.ErrBatchEmpty:    http.StatusConflict,
+		dto.ErrISEStock:      http.StatusInternalServerError,
+	}
+	for serviceErr, code := range cases {
+		mockService := new(test.MockStockService)
+		request := httptest.NewRequest(http.MethodPost, "/v1/stock/batch/4/write-off", nil)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = request
+		ctx.Params = gin.Params{{Key: "batch_id", Value: "4"}}
+
+		mockService.On("WriteOffBatchService", uint(4), dto.WriteOffBatchRequest{}).Return(dto.StockBatch{}, serviceErr)
+		sc := controller.NewStockController(mockService)
+		sc.WriteOffBatch(ctx)
+
+		assert.Equal(t, code, w.Code)
+	}
+}
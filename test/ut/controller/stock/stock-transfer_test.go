@@ -0,0 +1,115 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateStockTransfer_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockTransferService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-transfer", bytes.NewBufferString(`{"from_store_id":1,"to_store_id":2,"created_by":"staff-1","items":[{"barcode_id":"1","quantity":5}]}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("CreateStockTransferService", dto.CreateStockTransferRequest{
+		FromStoreId: 1,
+		ToStoreId:   2,
+		CreatedBy:   "staff-1",
+		Items:       []dto.StockTransferItemRequest{{BarcodeId: "1", Quantity: 5}},
+	}).Return(dto.StockTransferResponse{Id: 3, Status: constant.TransferInTransit}, nil)
+	sc := controller.NewStockTransferController(mockService)
+	sc.CreateStockTransfer(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_CREATE_STOCK_TRANSFER)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateStockTransfer_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockTransferService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-transfer", bytes.NewBufferString(`{"from_store_id":1,"to_store_id":2,"created_by":"staff-1","items":[]}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	sc := controller.NewStockTransferController(mockService)
+	sc.CreateStockTransfer(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateStockTransfer_InsufficientStock(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockTransferService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-transfer", bytes.NewBufferString(`{"from_store_id":1,"to_store_id":2,"created_by":"staff-1","items":[{"barcode_id":"1","quantity":500}]}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("CreateStockTransferService", dto.CreateStockTransferRequest{
+		FromStoreId: 1,
+		ToStoreId:   2,
+		CreatedBy:   "staff-1",
+		Items:       []dto.StockTransferItemRequest{{BarcodeId: "1", Quantity: 500}},
+	}).Return(dto.StockTransferResponse{}, dto.ErrInsufficientStock)
+	sc := controller.NewStockTransferController(mockService)
+	sc.CreateStockTransfer(ctx)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestReceiveStockTransfer_NotInTransit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockTransferService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-transfer/3/receive", bytes.NewBufferString(`{"received_by":"staff-2"}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "transfer_id", Value: "3"}}
+
+	mockService.On("ReceiveStockTransferService", uint(3), dto.ReceiveStockTransferRequest{ReceivedBy: "staff-2"}).Return(dto.StockTransferResponse{}, dto.ErrTransferNotInTransit)
+	sc := controller.NewStockTransferController(mockService)
+	sc.ReceiveStockTransfer(ctx)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStockLevels_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/stock/level/1", nil)
+	ctx.Params = gin.Params{{Key: "barcode_id", Value: "1"}}
+
+	mockService.On("GetStockLevelsService", "1").Return([]dto.StoreStockLevel{{StoreId: 2, StoreName: "Branch", CurrentStock: 4, InTransit: 6}}, nil)
+	sc := controller.NewStockController(mockService)
+	sc.GetStockLevels(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"in_transit":6`)
+	mockService.AssertExpectations(t)
+}
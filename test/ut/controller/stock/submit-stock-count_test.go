@@ -0,0 +1,105 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitStockCount_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	body := `{"device_id":"till-1","counted_by":"staff-1","items":[{"barcode_id":"1","quantity":3}]}`
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count/1/count", bytes.NewBufferString(body))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	mockService.On("SubmitStockCountService", uint(1), dto.SubmitStockCountRequest{
+		DeviceId:  "till-1",
+		CountedBy: "staff-1",
+		Items:     []dto.StockCountItemRequest{{BarcodeId: "1", Quantity: 3}},
+	}).Return(nil)
+	scc := controller.NewStockCountController(mockService)
+	scc.SubmitStockCount(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_SUBMIT_STOCK_COUNT)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubmitStockCount_BadRequestBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	body := `{"device_id":"till-1","counted_by":"staff-1","items":[{"barcode_id":"1","quantity":-3}]}`
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count/1/count", bytes.NewBufferString(body))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+	scc := controller.NewStockCountController(mockService)
+	scc.SubmitStockCount(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrBadrequest.Error())
+}
+
+func TestSubmitStockCount_BadRequestURI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStockCountService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/stock-count/abc/count", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "session_id", Value: "abc"}}
+
+	scc := controller.NewStockCountController(mockService)
+	scc.SubmitStockCount(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSubmitStockCount_ErrorMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[error]int{
+		dto.ErrUnknownBarcode:     http.StatusBadRequest,
+		dto.ErrStockCountNotFound: http.StatusNotFound,
+		dto.ErrStockCountNotOpen:  http.StatusConflict,
+		dto.ErrISEStockCount:      http.StatusInternalServerError,
+	}
+	for serviceErr, status := range cases {
+		mockService := new(test.MockStockCountService)
+		body := `{"device_id":"till-1","counted_by":"staff-1","items":[{"barcode_id":"1","quantity":3}]}`
+		request := httptest.NewRequest(http.MethodPost, "/v1/stock-count/1/count", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = request
+		ctx.Params = gin.Params{{Key: "session_id", Value: "1"}}
+
+		mockService.On("SubmitStockCountService", uint(1), dto.SubmitStockCountRequest{
+			DeviceId:  "till-1",
+			CountedBy: "staff-1",
+			Items:     []dto.StockCountItemRequest{{BarcodeId: "1", Quantity: 3}},
+		}).Return(serviceErr)
+		scc := controller.NewStockCountController(mockService)
+		scc.SubmitStockCount(ctx)
+
+		assert.Equal(t, status, w.Code)
+		assert.Contains(t, w.Body.String(), serviceErr.Error())
+	}
+}
@@ -0,0 +1,158 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/store"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStores_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/store", nil)
+
+	mockService.On("GetStoresService").Return([]dto.Store{{Id: 1, Code: "MAIN", Name: "Main Store"}}, nil)
+	sc := controller.NewStoreController(mockService)
+	sc.GetStores(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Main Store")
+	mockService.AssertExpectations(t)
+}
+
+func TestAddStore_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/store", bytes.NewBufferString(`{"code":"MAIN","name":"Main Store"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	mockService.On("CreateStoreService", dto.AddStoreRequest{Code: "MAIN", Name: "Main Store"}).Return(dto.Store{}, dto.ErrStoreExist)
+	sc := controller.NewStoreController(mockService)
+	sc.AddStore(ctx)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddStore_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/store", bytes.NewBufferString(`{"name":"Main Store"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	sc := controller.NewStoreController(mockService)
+	sc.AddStore(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSetStorePrice_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/store/2/price/1", bytes.NewBufferString(`{"price":"5500"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "store_id", Value: "2"}, {Key: "barcode_id", Value: "1"}}
+
+	mockService.On("SetStorePriceService", uint(2), "1", mock.MatchedBy(func(req dto.SetStorePriceRequest) bool {
+		return req.Price.Equal(decimal.NewFromInt(5500))
+	})).Return(nil)
+	sc := controller.NewStoreController(mockService)
+	sc.SetStorePrice(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_SET_STORE_PRICE)
+	mockService.AssertExpectations(t)
+}
+
+func TestSetStorePrice_StoreNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/store/9/price/1", bytes.NewBufferString(`{"price":"5500"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "store_id", Value: "9"}, {Key: "barcode_id", Value: "1"}}
+
+	mockService.On("SetStorePriceService", uint(9), "1", mock.Anything).Return(dto.ErrStoreDoesntExist)
+	sc := controller.NewStoreController(mockService)
+	sc.SetStorePrice(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteStorePrice_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/store/2/price/1", nil)
+	ctx.Params = gin.Params{{Key: "store_id", Value: "2"}, {Key: "barcode_id", Value: "1"}}
+
+	mockService.On("DeleteStorePriceService", uint(2), "1").Return(dto.ErrStorePriceNotFound)
+	sc := controller.NewStoreController(mockService)
+	sc.DeleteStorePrice(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStores_Canceled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	requestCtx, cancel := context.WithCancel(t.Context())
+	cancel()
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/store", nil).WithContext(requestCtx)
+
+	mockService.On("GetStoresService").Return([]dto.Store{}, dto.ErrISEStore)
+	sc := controller.NewStoreController(mockService)
+	sc.GetStores(ctx)
+
+	assert.Equal(t, constant.StatusClientClosedRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrRequestCanceled.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStores_Timeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockStoreService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/store", nil)
+
+	mockService.On("GetStoresService").Return([]dto.Store{}, context.DeadlineExceeded)
+	sc := controller.NewStoreController(mockService)
+	sc.GetStores(ctx)
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,119 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/supplier"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSuppliers_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockSupplierService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/supplier", nil)
+
+	mockService.On("GetSuppliersService").Return([]dto.Supplier{{Id: 1, Name: "Wings"}}, nil)
+	sc := controller.NewSupplierController(mockService)
+	sc.GetSuppliers(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Wings")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetSuppliers_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockSupplierService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/supplier", nil)
+
+	mockService.On("GetSuppliersService").Return([]dto.Supplier{}, dto.ErrSuppliersNotFound)
+	sc := controller.NewSupplierController(mockService)
+	sc.GetSuppliers(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddSupplier_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockSupplierService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/supplier", bytes.NewBufferString(`{"name":"Wings","lead_time_days":3}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	mockService.On("CreateSupplierService", dto.AddSupplierRequest{Name: "Wings", LeadTimeDays: 3}).Return(dto.Supplier{Id: 1, Name: "Wings", LeadTimeDays: 3}, nil)
+	sc := controller.NewSupplierController(mockService)
+	sc.AddSupplier(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_ADD_SUPPLIER)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddSupplier_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockSupplierService)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/supplier", bytes.NewBufferString(`{"lead_time_days":3}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	sc := controller.NewSupplierController(mockService)
+	sc.AddSupplier(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateSupplier_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockSupplierService)
+
+	name := "Indofood"
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPatch, "/v1/supplier/1", bytes.NewBufferString(`{"name":"Indofood"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "supplier_id", Value: "1"}}
+
+	mockService.On("UpdateSupplierService", uint(1), dto.UpdateSupplierRequest{Name: &name}).Return(dto.ErrSupplierDoesntExist)
+	sc := controller.NewSupplierController(mockService)
+	sc.UpdateSupplier(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateSupplier_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockSupplierService)
+
+	name := "Indofood"
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPatch, "/v1/supplier/1", bytes.NewBufferString(`{"name":"Indofood"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "supplier_id", Value: "1"}}
+
+	mockService.On("UpdateSupplierService", uint(1), dto.UpdateSupplierRequest{Name: &name}).Return(nil)
+	sc := controller.NewSupplierController(mockService)
+	sc.UpdateSupplier(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_UPDATE_SUPPLIER)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,82 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncProducts_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(test.MockSyncService)
+	expected := dto.ProductSyncResponse{
+		Products:  []dto.ProductWithoutTimeStamp{{BarcodeId: "1", Title: "Product 1", Price: decimal.NewFromInt(1000)}},
+		Deleted:   []string{"2"},
+		NextToken: "next",
+		HasMore:   true,
+	}
+	mockService.On("SyncProductsService", "token").Return(expected, nil)
+	sc := controller.NewSyncController(mockService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/sync/products?since=token", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	sc.SyncProducts(ctx)
+
+	var actualResponse struct {
+		Data dto.ProductSyncResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &actualResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_SYNC_PRODUCTS)
+	assert.Equal(t, expected, actualResponse.Data)
+	mockService.AssertExpectations(t)
+}
+
+func TestSyncProducts_InvalidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(test.MockSyncService)
+	mockService.On("SyncProductsService", "bad").Return(dto.ProductSyncResponse{}, dto.ErrInvalidSyncToken)
+	sc := controller.NewSyncController(mockService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/sync/products?since=bad", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	sc.SyncProducts(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrInvalidSyncToken.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestSyncProducts_Error(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(test.MockSyncService)
+	mockService.On("SyncProductsService", "").Return(dto.ProductSyncResponse{}, dto.ErrISESync)
+	sc := controller.NewSyncController(mockService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/sync/products", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	sc.SyncProducts(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+}
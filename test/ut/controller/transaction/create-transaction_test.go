@@ -0,0 +1,100 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/transaction"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const createTransactionBody = `{"cashier":"cashier-1","payment_method":"cash","items":[{"barcode_id":"1","quantity":2}]}`
+
+var createTransactionRequest = dto.CreateTransactionRequest{
+	Cashier:       "cashier-1",
+	PaymentMethod: "cash",
+	Items:         []dto.TransactionItemRequest{{BarcodeId: "1", Quantity: 2}},
+}
+
+func TestCreateTransaction_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockTransactionService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/transaction", bytes.NewBufferString(createTransactionBody))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("CreateTransactionService", createTransactionRequest).Return(dto.TransactionResponse{Id: 1}, nil)
+	tc := controller.NewTransactionController(mockService)
+	tc.CreateTransaction(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_CREATE_TRANSACTION)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateTransaction_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockTransactionService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/transaction", bytes.NewBufferString(`{"cashier":"cashier-1","payment_method":"cash","items":[]}`))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	tc := controller.NewTransactionController(mockService)
+	tc.CreateTransaction(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateTransaction_ErrorMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[error]int{
+		dto.ErrOverrideApproverRequired: http.StatusBadRequest,
+		dto.ErrProductDoesntExist:       http.StatusNotFound,
+		dto.ErrInsufficientStock:        http.StatusConflict,
+		dto.ErrExpiredBatch:             http.StatusConflict,
+		dto.ErrToCreateTransaction:      http.StatusInternalServerError,
+	}
+	for serviceErr, code := range cases {
+		mockService := new(test.MockTransactionService)
+		request := httptest.NewRequest(http.MethodPost, "/v1/transaction", bytes.NewBufferString(createTransactionBody))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = request
+
+		mockService.On("CreateTransactionService", createTransactionRequest).Return(dto.TransactionResponse{}, serviceErr)
+		tc := controller.NewTransactionController(mockService)
+		tc.CreateTransaction(ctx)
+
+		assert.Equal(t, code, w.Code)
+	}
+}
+
+func TestGetTransactionDetail_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockTransactionService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/transaction/1", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "transaction_id", Value: "1"}}
+
+	mockService.On("GetTransactionDetailService", uint(1)).Return(dto.TransactionResponse{}, dto.ErrTransactionNotFound)
+	tc := controller.NewTransactionController(mockService)
+	tc.GetTransactionDetail(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,125 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/controller"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/transaction"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const offlineSalesBody = `{"sales":[{"client_id":"6f1c2a8e-3d4b-4c5d-9e6f-7a8b9c0d1e2f","cashier":"cashier-1","payment_method":"cash","sold_at":"2026-03-01T08:00:00Z","items":[{"barcode_id":"1","quantity":2,"unit_price":"5000"}]}]}`
+
+func TestUploadOfflineSales_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockTransactionService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/transaction/offline", bytes.NewBufferString(offlineSalesBody))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("UploadOfflineSalesService", mock.MatchedBy(func(req dto.UploadOfflineSalesRequest) bool {
+		return len(req.Sales) == 1 && req.Sales[0].Items[0].UnitPrice.String() == "5000"
+	})).Return([]dto.OfflineSaleResult{
+		{ClientId: "6f1c2a8e-3d4b-4c5d-9e6f-7a8b9c0d1e2f", Status: constant.OfflineSaleDuplicate, TransactionId: 3},
+	}, nil)
+	tc := controller.NewTransactionController(mockService)
+	tc.UploadOfflineSales(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_UPLOAD_OFFLINE_SALES)
+	assert.Contains(t, w.Body.String(), `"status":"duplicate"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestUploadOfflineSales_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, body := range []string{
+		`{"sales":[]}`,
+		`{"sales":[{"client_id":"not-a-uuid","cashier":"cashier-1","payment_method":"cash","sold_at":"2026-03-01T08:00:00Z","items":[{"barcode_id":"1","quantity":2,"unit_price":"5000"}]}]}`,
+		`{"sales":[{"client_id":"6f1c2a8e-3d4b-4c5d-9e6f-7a8b9c0d1e2f","cashier":"cashier-1","payment_method":"cash","items":[{"barcode_id":"1","quantity":2,"unit_price":"5000"}]}]}`,
+		`{"sales":[{"client_id":"6f1c2a8e-3d4b-4c5d-9e6f-7a8b9c0d1e2f","cashier":"cashier-1","payment_method":"cash","sold_at":"2026-03-01T08:00:00Z","items":[{"barcode_id":"1","quantity":2}]}]}`,
+	} {
+		mockService := new(test.MockTransactionService)
+		request := httptest.NewRequest(http.MethodPost, "/v1/transaction/offline", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = request
+
+		tc := controller.NewTransactionController(mockService)
+		tc.UploadOfflineSales(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		mockService.AssertNotCalled(t, "UploadOfflineSalesService", mock.Anything)
+	}
+}
+
+func TestUploadOfflineSales_StoreNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockTransactionService)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/transaction/offline", bytes.NewBufferString(offlineSalesBody))
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("UploadOfflineSalesService", mock.Anything).Return([]dto.OfflineSaleResult(nil), dto.ErrStoreDoesntExist)
+	tc := controller.NewTransactionController(mockService)
+	tc.UploadOfflineSales(ctx)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestResolveSaleConflict_ErrorMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for err, status := range map[error]int{
+		dto.ErrSaleConflictNotFound: http.StatusNotFound,
+		dto.ErrSaleConflictResolved: http.StatusConflict,
+		dto.ErrISESaleConflicts:     http.StatusInternalServerError,
+	} {
+		mockService := new(test.MockTransactionService)
+		request := httptest.NewRequest(http.MethodPost, "/v1/transaction/conflicts/3/resolve", bytes.NewBufferString(`{"resolved_by":"supervisor-1"}`))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = request
+		ctx.Params = gin.Params{{Key: "conflict_id", Value: "3"}}
+
+		mockService.On("ResolveSaleConflictService", uint(3), dto.ResolveSaleConflictRequest{ResolvedBy: "supervisor-1"}).Return(dto.SaleConflictResponse{}, err)
+		tc := controller.NewTransactionController(mockService)
+		tc.ResolveSaleConflict(ctx)
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestGetSaleConflicts_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(test.MockTransactionService)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/transaction/conflicts?store_id=2", nil)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = request
+
+	mockService.On("GetSaleConflictsService", dto.SaleConflictQuery{StoreId: 2}).Return([]dto.SaleConflictResponse{{Id: 1, Type: constant.ConflictInsufficientStock}}, nil)
+	tc := controller.NewTransactionController(mockService)
+	tc.GetSaleConflicts(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), dto.MESSAGE_SUCCESS_GET_SALE_CONFLICTS)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,265 @@
+package database_test
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/database"
+	"tiga-putra-cashier-be/dto"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var migrationFiles = fstest.MapFS{
+	"0001_create_items.up.sql":   {Data: []byte("CREATE TABLE items (id bigint)")},
+	"0001_create_items.down.sql": {Data: []byte("DROP TABLE items")},
+	"0002_seed_items.up.sql":     {Data: []byte("INSERT INTO items VALUES (1)")},
+	"0002_seed_items.down.sql":   {Data: []byte("-- nothing to undo\n")},
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...uint) {
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS schema_migrations`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass('schema_migrations') IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	rows := sqlmock.NewRows([]string{"version", "name", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, "migration", time.Now())
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations" ORDER BY version`)).
+		WillReturnRows(rows)
+}
+
+func expectLock(mock sqlmock.Sqlmock, version uint, count int) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1)`)).
+		WithArgs(constant.MigrationLockId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "schema_migrations" WHERE version = $1`)).
+		WithArgs(version).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func expectUp(mock sqlmock.Sqlmock, version uint, name, statement string) {
+	expectLock(mock, version, 0)
+	mock.ExpectExec(regexp.QuoteMeta(statement)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "schema_migrations" ("name","applied_at","version") VALUES ($1,$2,$3) RETURNING "version"`)).
+		WithArgs(name, sqlmock.AnyArg(), version).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+	mock.ExpectCommit()
+}
+
+func expectDown(mock sqlmock.Sqlmock, version uint, statement string) {
+	expectLock(mock, version, 1)
+	if statement != "" {
+		mock.ExpectExec(regexp.QuoteMeta(statement)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "schema_migrations" WHERE version = $1`)).
+		WithArgs(version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestMigrationFiles(t *testing.T) {
+	migrations, err := database.LoadMigrations(database.MigrationFiles)
+
+	require.NoError(t, err)
+	require.Len(t, migrations, 7)
+	for i, migration := range migrations {
+		assert.Equal(t, uint(i+1), migration.Version)
+	}
+	// The indexes only help queries that use these expressions verbatim.
+	assert.Contains(t, migrations[1].Up, constant.ProductSearchDocument)
+	assert.Contains(t, migrations[1].Up, constant.ProductChangedAt)
+	// Databases from before the migrations only have the first product columns.
+	for _, column := range []string{"category", "min_stock", "reorder_quantity", "supplier_id", "version"} {
+		assert.Contains(t, migrations[0].Up, `ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "`+column+`"`)
+	}
+	assert.Contains(t, migrations[4].Up, "ALTER TABLE products ALTER COLUMN price TYPE numeric(14,2)")
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	for name, files := range map[string]fstest.MapFS{
+		"missing down": {"0001_items.up.sql": {Data: []byte("SELECT 1")}},
+		"bad name":     {"items.up.sql": {Data: []byte("SELECT 1")}},
+		"same version": {
+			"0001_items.up.sql":    {Data: []byte("SELECT 1")},
+			"0001_items.down.sql":  {Data: []byte("SELECT 1")},
+			"0001_orders.up.sql":   {Data: []byte("SELECT 1")},
+			"0001_orders.down.sql": {Data: []byte("SELECT 1")},
+		},
+	} {
+		_, err := database.LoadMigrations(files)
+		assert.ErrorIs(t, err, dto.ErrInvalidMigrationFile, name)
+	}
+}
+
+func TestMigrateUp_AppliesPending(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	expectApplied(mock, 1)
+	expectUp(mock, 2, "seed_items", "INSERT INTO items VALUES (1)")
+
+	assert.NoError(t, migrator.Up(0))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateUp_Steps(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	expectApplied(mock)
+	expectUp(mock, 1, "create_items", "CREATE TABLE items (id bigint)")
+
+	assert.NoError(t, migrator.Up(1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateUp_SkipsWhatAnotherInstanceApplied(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	expectApplied(mock, 1)
+	expectLock(mock, 2, 1)
+	mock.ExpectCommit()
+
+	assert.NoError(t, migrator.Up(0))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateUp_RollsBackFailure(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	expectApplied(mock)
+	expectLock(mock, 1, 0)
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE items (id bigint)")).
+		WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+
+	err = migrator.Up(0)
+
+	assert.EqualError(t, err, "migration 0001_create_items up: syntax error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateDown_RevertsLast(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	// The down of 0002 is only a comment, so nothing runs but the delete.
+	expectApplied(mock, 1, 2)
+	expectDown(mock, 2, "")
+
+	assert.NoError(t, migrator.Down(1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateDown_InvalidSteps(t *testing.T) {
+	db, _ := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.Down(0), dto.ErrInvalidMigrationSteps)
+}
+
+func TestMigrateDown_MissingFile(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	expectApplied(mock, 1, 2, 3)
+
+	assert.ErrorIs(t, migrator.Down(1), dto.ErrMigrationFileMissing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateTo(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	expectApplied(mock, 1, 2)
+	expectDown(mock, 2, "")
+	expectDown(mock, 1, "DROP TABLE items")
+
+	assert.NoError(t, migrator.To(0))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateTo_UnknownVersion(t *testing.T) {
+	db, _ := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.To(5), dto.ErrMigrationNotFound)
+}
+
+func TestMigrationCheck_Pending(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass('schema_migrations') IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	err = migrator.Check()
+
+	assert.ErrorIs(t, err, dto.ErrPendingMigrations)
+	assert.True(t, strings.HasSuffix(err.Error(), "0001_create_items, 0002_seed_items pending"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrationCheck_UpToDate(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass('schema_migrations') IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations" ORDER BY version`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).
+			AddRow(1, "create_items", time.Now()).
+			AddRow(2, "seed_items", time.Now()))
+
+	assert.NoError(t, migrator.Check())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrationStatus(t *testing.T) {
+	db, mock := test.MockDB(t)
+	migrator, err := database.NewMigrator(db, migrationFiles)
+	require.NoError(t, err)
+
+	appliedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass('schema_migrations') IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations" ORDER BY version`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).
+			AddRow(1, "create_items", appliedAt).
+			AddRow(7, "from_newer_app", appliedAt))
+
+	status, err := migrator.Status()
+
+	require.NoError(t, err)
+	assert.Equal(t, []dto.MigrationStatus{
+		{Version: 1, Name: "create_items", AppliedAt: &appliedAt},
+		{Version: 2, Name: "seed_items"},
+		{Version: 7, Name: "from_newer_app", AppliedAt: &appliedAt, Missing: true},
+	}, status)
+}
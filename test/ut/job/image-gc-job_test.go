@@ -0,0 +1,93 @@
+package job_test
+
+import (
+	"errors"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/job"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func imageGCFiles() []utils.StoredFile {
+	old := time.Now().Add(-48 * time.Hour)
+	return []utils.StoredFile{
+		{Key: "assets/image/a_original.jpg", ModTime: old},
+		{Key: "assets/image/a_medium.jpg", ModTime: old},
+		{Key: "assets/image/a_thumbnail.jpg", ModTime: old},
+		{Key: "assets/image/legacy.jpg", ModTime: old},
+		{Key: "assets/image/b_original.jpg", ModTime: old},
+		{Key: "assets/image/b_thumbnail.jpg", ModTime: old},
+		{Key: "assets/image/c_original.jpg", ModTime: time.Now().Add(-time.Hour)},
+	}
+}
+
+func TestImageGCJob_DeletesOrphansPastGracePeriod(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedStorage := new(testUtils.MockStorage)
+	imageGCJob := job.NewImageGCJob(mockedRepo, mockedStorage, config.Default())
+
+	mockedStorage.On("List", "assets/image/").Return(imageGCFiles(), nil)
+	mockedRepo.On("RetrieveReferencedImagesRepository").Return([]string{"a_original.jpg", "legacy.jpg"}, nil)
+	mockedStorage.On("Delete", "assets/image/b_original.jpg").Return(nil)
+	mockedStorage.On("Delete", "assets/image/b_thumbnail.jpg").Return(errors.New("ISE"))
+
+	report, err := imageGCJob.Collect(t.Context(), 24*time.Hour, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Scanned)
+	assert.Equal(t, []string{"assets/image/b_original.jpg", "assets/image/b_thumbnail.jpg"}, report.Orphans)
+	assert.Equal(t, []string{"assets/image/c_original.jpg"}, report.Pending)
+	assert.Equal(t, 1, report.Deleted)
+	assert.Equal(t, 1, report.Failed)
+	mockedRepo.AssertExpectations(t)
+	mockedStorage.AssertExpectations(t)
+}
+
+func TestImageGCJob_DryRun(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedStorage := new(testUtils.MockStorage)
+	imageGCJob := job.NewImageGCJob(mockedRepo, mockedStorage, config.Default())
+
+	mockedStorage.On("List", "assets/image/").Return(imageGCFiles(), nil)
+	mockedRepo.On("RetrieveReferencedImagesRepository").Return([]string{"a_original.jpg"}, nil)
+
+	report, err := imageGCJob.Collect(t.Context(), 0, true)
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Len(t, report.Orphans, 4)
+	assert.Empty(t, report.Pending)
+	assert.Zero(t, report.Deleted)
+	mockedStorage.AssertNotCalled(t, "Delete", "assets/image/legacy.jpg")
+}
+
+func TestImageGCJob_ReferencesFailed(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedStorage := new(testUtils.MockStorage)
+	imageGCJob := job.NewImageGCJob(mockedRepo, mockedStorage, config.Default())
+
+	mockedStorage.On("List", "assets/image/").Return(imageGCFiles(), nil)
+	mockedRepo.On("RetrieveReferencedImagesRepository").Return(nil, errors.New("ISE"))
+
+	_, err := imageGCJob.Collect(t.Context(), 24*time.Hour, false)
+
+	assert.EqualError(t, err, "ISE")
+	mockedStorage.AssertNotCalled(t, "Delete", "assets/image/b_original.jpg")
+}
+
+func TestImageGCJob_GracePeriod(t *testing.T) {
+	imageGCJob := job.NewImageGCJob(nil, nil, config.Default())
+	assert.Equal(t, 24*time.Hour, imageGCJob.GracePeriod())
+	assert.Zero(t, imageGCJob.Interval())
+
+	cfg := config.Default()
+	cfg.Jobs.ImageGC.GracePeriod = 2 * time.Hour
+	imageGCJob = job.NewImageGCJob(nil, nil, cfg)
+	assert.Equal(t, 2*time.Hour, imageGCJob.GracePeriod())
+}
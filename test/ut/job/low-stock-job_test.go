@@ -0,0 +1,82 @@
+package job_test
+
+import (
+	"errors"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/job"
+	testJob "tiga-putra-cashier-be/test/mocks/job"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLowStockJob_AlertsOnlyWhenCrossing(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	mockedNotifier := new(testJob.MockNotifier)
+	lowStockJob := job.NewLowStockJob(mockedRepo, mockedNotifier, config.Default())
+
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return([]dto.LowStockProduct{
+		{BarcodeId: "1", Title: "Indomie", CurrentStock: 2, MinStock: 5},
+	}, nil).Once()
+	mockedNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(alerts []dto.LowStockAlert) bool {
+		return len(alerts) == 1 && alerts[0].BarcodeId == "1"
+	})).Return(nil).Once()
+	assert.NoError(t, lowStockJob.Check(t.Context()))
+
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return([]dto.LowStockProduct{
+		{BarcodeId: "1", Title: "Indomie", CurrentStock: 1, MinStock: 5},
+		{BarcodeId: "2", Title: "Teh Botol", CurrentStock: 0, MinStock: 3},
+	}, nil).Once()
+	mockedNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(alerts []dto.LowStockAlert) bool {
+		return len(alerts) == 1 && alerts[0].BarcodeId == "2"
+	})).Return(nil).Once()
+	assert.NoError(t, lowStockJob.Check(t.Context()))
+
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return([]dto.LowStockProduct{
+		{BarcodeId: "2", Title: "Teh Botol", CurrentStock: 0, MinStock: 3},
+	}, nil).Once()
+	assert.NoError(t, lowStockJob.Check(t.Context()))
+
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return([]dto.LowStockProduct{
+		{BarcodeId: "1", Title: "Indomie", CurrentStock: 4, MinStock: 5},
+		{BarcodeId: "2", Title: "Teh Botol", CurrentStock: 0, MinStock: 3},
+	}, nil).Once()
+	mockedNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(alerts []dto.LowStockAlert) bool {
+		return len(alerts) == 1 && alerts[0].BarcodeId == "1"
+	})).Return(nil).Once()
+	assert.NoError(t, lowStockJob.Check(t.Context()))
+
+	mockedRepo.AssertExpectations(t)
+	mockedNotifier.AssertExpectations(t)
+}
+
+func TestLowStockJob_RetryWhenNotifyFails(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	mockedNotifier := new(testJob.MockNotifier)
+	lowStockJob := job.NewLowStockJob(mockedRepo, mockedNotifier, config.Default())
+
+	products := []dto.LowStockProduct{{BarcodeId: "1", Title: "Indomie", CurrentStock: 2, MinStock: 5}}
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return(products, nil).Twice()
+	mockedNotifier.On("Notify", mock.Anything, mock.Anything).Return(errors.New("webhook down")).Once()
+	mockedNotifier.On("Notify", mock.Anything, mock.Anything).Return(nil).Once()
+
+	assert.Error(t, lowStockJob.Check(t.Context()))
+	assert.NoError(t, lowStockJob.Check(t.Context()))
+
+	mockedRepo.AssertExpectations(t)
+	mockedNotifier.AssertExpectations(t)
+}
+
+func TestLowStockJob_RepositoryError(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	mockedNotifier := new(testJob.MockNotifier)
+	lowStockJob := job.NewLowStockJob(mockedRepo, mockedNotifier, config.Default())
+
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return([]dto.LowStockProduct{}, dto.ErrISEStock)
+
+	assert.Equal(t, dto.ErrISEStock, lowStockJob.Check(t.Context()))
+	mockedNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
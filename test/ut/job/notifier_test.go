@@ -0,0 +1,50 @@
+package job_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/job"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier_Success(t *testing.T) {
+	var received struct {
+		Alerts []dto.LowStockAlert `json:"alerts"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := job.NewWebhookNotifier(server.URL)
+	err := notifier.Notify(t.Context(), []dto.LowStockAlert{{BarcodeId: "1", CurrentStock: 2, MinStock: 5}})
+
+	assert.NoError(t, err)
+	assert.Len(t, received.Alerts, 1)
+	assert.Equal(t, "1", received.Alerts[0].BarcodeId)
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := job.NewWebhookNotifier(server.URL)
+	err := notifier.Notify(t.Context(), []dto.LowStockAlert{{BarcodeId: "1"}})
+
+	assert.Error(t, err)
+}
+
+func TestNewNotifier_FallbackToLog(t *testing.T) {
+	notifier := job.NewNotifier(config.Default())
+
+	assert.NoError(t, notifier.Notify(t.Context(), []dto.LowStockAlert{{BarcodeId: "1"}}))
+}
@@ -0,0 +1,272 @@
+package middleware_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/middleware"
+	test "tiga-putra-cashier-be/test/mocks/idempotency"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRouter(mockRepo *test.MockIdempotencyRepository, status int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.NewIdempotencyMiddleware(mockRepo, config.Default()).Handle)
+	handler := func(ctx *gin.Context) {
+		*calls++
+		ctx.JSON(status, gin.H{"call": *calls})
+	}
+	r.POST("/v1/transaction", handler)
+	r.GET("/v1/transaction", handler)
+	return r
+}
+
+func post(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/v1/transaction", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		request.Header.Set(constant.IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, request)
+	return w
+}
+
+func TestIdempotency_FirstRequestStored(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusOK, &calls)
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.AnythingOfType("string"), constant.DefaultIdempotencyKeyTTL).Return(entity.IdempotencyKey{}, true, nil)
+	mockRepo.On("SaveIdempotentResponseRepository", "key-1", http.StatusOK, http.Header{"Content-Type": {"application/json; charset=utf-8"}}, []byte(`{"call":1}`)).Return(nil)
+
+	w := post(r, "key-1", `{"cashier":"cashier-1"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, w.Header().Get(constant.IdempotentReplayedHeader))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIdempotency_RetryReplayed(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusOK, &calls)
+
+	var hash string
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.AnythingOfType("string"), constant.DefaultIdempotencyKeyTTL).Return(entity.IdempotencyKey{}, true, nil).Once().
+		Run(func(args mock.Arguments) { hash = args.String(1) })
+	mockRepo.On("SaveIdempotentResponseRepository", "key-1", http.StatusOK, mock.Anything, mock.Anything).Return(nil)
+	post(r, "key-1", `{"cashier":"cashier-1"}`)
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", hash, constant.DefaultIdempotencyKeyTTL).Return(entity.IdempotencyKey{
+		Key: "key-1", RequestHash: hash, StatusCode: http.StatusOK, Headers: http.Header{"Content-Type": {"application/json; charset=utf-8"}}, Body: []byte(`{"call":1}`),
+	}, false, nil).Once()
+	w := post(r, "key-1", `{"cashier":"cashier-1"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"call":1}`, w.Body.String())
+	assert.Equal(t, "true", w.Header().Get(constant.IdempotentReplayedHeader))
+	assert.Equal(t, 1, calls)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIdempotency_KeyReusedWithDifferentBody(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusOK, &calls)
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.Anything, mock.Anything).Return(entity.IdempotencyKey{
+		Key: "key-1", RequestHash: "other", StatusCode: http.StatusOK,
+	}, false, nil)
+
+	w := post(r, "key-1", `{"cashier":"cashier-2"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrIdempotencyKeyReused.Error())
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusOK, &calls)
+
+	var hash string
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.AnythingOfType("string"), mock.Anything).Return(entity.IdempotencyKey{}, true, nil).Once().
+		Run(func(args mock.Arguments) { hash = args.String(1) })
+	mockRepo.On("SaveIdempotentResponseRepository", "key-1", http.StatusOK, mock.Anything, mock.Anything).Return(nil)
+	post(r, "key-1", `{}`)
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", hash, mock.Anything).Return(entity.IdempotencyKey{Key: "key-1", RequestHash: hash}, false, nil).Once()
+	w := post(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrIdempotencyKeyInProgress.Error())
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusInternalServerError, &calls)
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.Anything, mock.Anything).Return(entity.IdempotencyKey{}, true, nil)
+	mockRepo.On("ReleaseIdempotencyKeyRepository", "key-1").Return(nil)
+
+	w := post(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SaveIdempotentResponseRepository", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_CanceledRequestReleasesKey(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, constant.StatusClientClosedRequest, &calls)
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.Anything, mock.Anything).Return(entity.IdempotencyKey{}, true, nil)
+	mockRepo.On("ReleaseIdempotencyKeyRepository", "key-1").Return(nil)
+
+	w := post(r, "key-1", `{}`)
+
+	assert.Equal(t, constant.StatusClientClosedRequest, w.Code)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SaveIdempotentResponseRepository", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_Skipped(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusOK, &calls)
+
+	post(r, "", `{}`)
+	request := httptest.NewRequest(http.MethodGet, "/v1/transaction", nil)
+	request.Header.Set(constant.IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.Equal(t, 2, calls)
+	mockRepo.AssertNotCalled(t, "ReserveIdempotencyKeyRepository", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusOK, &calls)
+
+	w := post(r, strings.Repeat("k", constant.MaxIdempotencyKeyLength+1), `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_MultipartIgnoresBoundary(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(mockRepo, http.StatusOK, &calls)
+
+	var hashes []string
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.Anything, mock.Anything).Return(entity.IdempotencyKey{}, true, nil).
+		Run(func(args mock.Arguments) { hashes = append(hashes, args.String(1)) })
+	mockRepo.On("SaveIdempotentResponseRepository", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	for _, boundary := range []string{"first-boundary", "second-boundary"} {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		writer.SetBoundary(boundary)
+		writer.WriteField("barcode_id", "1")
+		part, _ := writer.CreateFormFile("image", "milk.png")
+		part.Write([]byte("png"))
+		writer.Close()
+
+		request := httptest.NewRequest(http.MethodPost, "/v1/transaction", &body)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+		request.Header.Set(constant.IdempotencyKeyHeader, "key-1")
+		r.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	assert.Len(t, hashes, 2)
+	assert.Equal(t, hashes[0], hashes[1])
+}
+
+func TestIdempotency_TTLFromConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Idempotency.KeyTTL = 2 * time.Hour
+	mockRepo := new(test.MockIdempotencyRepository)
+	r := gin.New()
+	r.Use(middleware.NewIdempotencyMiddleware(mockRepo, cfg).Handle)
+	r.POST("/v1/transaction", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.Anything, 2*time.Hour).Return(entity.IdempotencyKey{}, true, nil)
+	mockRepo.On("SaveIdempotentResponseRepository", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	post(r, "key-1", `{}`)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	cfg := config.Default()
+	cfg.Idempotency.MaxBodySize = 8
+	mockRepo := new(test.MockIdempotencyRepository)
+	calls := 0
+	r := gin.New()
+	r.Use(middleware.NewIdempotencyMiddleware(mockRepo, cfg).Handle)
+	r.POST("/v1/transaction", func(ctx *gin.Context) { calls++ })
+
+	w := post(r, "key-1", `{"cashier":"cashier-1"}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), dto.ErrIdempotentBodyTooLarge.Error())
+	assert.Equal(t, 0, calls)
+	mockRepo.AssertNotCalled(t, "ReserveIdempotencyKeyRepository", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_ReplaysHeaders(t *testing.T) {
+	mockRepo := new(test.MockIdempotencyRepository)
+	r := gin.New()
+	r.Use(middleware.NewIdempotencyMiddleware(mockRepo, config.Default()).Handle)
+	r.POST("/v1/transaction", func(ctx *gin.Context) {
+		ctx.Header("ETag", `"1"`)
+		ctx.Header("Location", "/v1/transaction/9")
+		ctx.Header("X-Request-Id", "abc")
+		ctx.JSON(http.StatusCreated, gin.H{"id": 9})
+	})
+
+	var hash string
+	var saved http.Header
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", mock.AnythingOfType("string"), mock.Anything).Return(entity.IdempotencyKey{}, true, nil).Once().
+		Run(func(args mock.Arguments) { hash = args.String(1) })
+	mockRepo.On("SaveIdempotentResponseRepository", "key-1", http.StatusCreated, mock.Anything, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) { saved = args.Get(2).(http.Header) })
+	post(r, "key-1", `{}`)
+
+	assert.Equal(t, http.Header{
+		"Content-Type": {"application/json; charset=utf-8"},
+		"Etag":         {`"1"`},
+		"Location":     {"/v1/transaction/9"},
+	}, saved)
+
+	mockRepo.On("ReserveIdempotencyKeyRepository", "key-1", hash, mock.Anything).Return(entity.IdempotencyKey{
+		Key: "key-1", RequestHash: hash, StatusCode: http.StatusCreated, Headers: saved, Body: []byte(`{"id":9}`),
+	}, false, nil).Once()
+	w := post(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.Equal(t, "/v1/transaction/9", w.Header().Get("Location"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("X-Request-Id"))
+}
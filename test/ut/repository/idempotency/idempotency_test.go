@@ -0,0 +1,104 @@
+package repository_test
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func expectPurge(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "idempotency_keys" WHERE expires_at < $1 OR (key = $2 AND status_code = 0 AND created_at < $3)`)).
+		WithArgs(utils.AnyTime{}, "key-1", utils.AnyTime{}).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestReserveIdempotencyKey_New(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewIdempotencyRepository(db, config.Default())
+
+	expectPurge(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys" ("key","request_hash","status_code","headers","body","created_at","expires_at") VALUES ($1,$2,$3,$4,$5,$6,$7) ON CONFLICT DO NOTHING`)).
+		WithArgs("key-1", "hash", 0, nil, sqlmock.AnyArg(), utils.AnyTime{}, utils.AnyTime{}).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	record, reserved, err := repo.ReserveIdempotencyKeyRepository(t.Context(), "key-1", "hash", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Equal(t, "hash", record.RequestHash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReserveIdempotencyKey_Taken(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewIdempotencyRepository(db, config.Default())
+
+	expectPurge(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "idempotency_keys" WHERE key = $1 AND "idempotency_keys"."key" = $2 ORDER BY "idempotency_keys"."key" LIMIT $3`)).
+		WithArgs("key-1", "key-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "request_hash", "status_code", "body"}).AddRow("key-1", "hash", 200, []byte(`{}`)))
+	mock.ExpectCommit()
+
+	record, reserved, err := repo.ReserveIdempotencyKeyRepository(t.Context(), "key-1", "hash", time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, 200, record.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReserveIdempotencyKey_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewIdempotencyRepository(db, config.Default())
+
+	expectPurge(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`)).
+		WillReturnError(errors.New("error"))
+	mock.ExpectRollback()
+
+	_, _, err := repo.ReserveIdempotencyKeyRepository(t.Context(), "key-1", "hash", time.Hour)
+	assert.Equal(t, dto.ErrISEIdempotency, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveIdempotentResponse_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewIdempotencyRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "idempotency_keys" SET "status_code"=$1,"headers"=$2,"body"=$3 WHERE key = $4`)).
+		WithArgs(201, `{"Content-Type":["application/json"],"Location":["/v1/transaction/1"]}`, []byte(`{}`), "key-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SaveIdempotentResponseRepository(t.Context(), "key-1", 201, http.Header{"Content-Type": {"application/json"}, "Location": {"/v1/transaction/1"}}, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReleaseIdempotencyKey_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewIdempotencyRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "idempotency_keys" WHERE key = $1`)).
+		WithArgs("key-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.ReleaseIdempotencyKeyRepository(t.Context(), "key-1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,23 +4,25 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCountProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" WHERE "products"."deleted_at" IS NULL`)).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
-	count, err := repo.CountProductsRepository()
+	count, err := repo.CountProductsRepository(t.Context(), &dto.ProductListQuery{})
 	assert.NoError(t, err)
-	assert.Equal(t, uint16(count), uint16(10))
+	assert.Equal(t, int64(10), count)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -28,12 +30,30 @@ func TestCountProduct_Success(t *testing.T) {
 func TestCountProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" WHERE "products"."deleted_at" IS NULL`)).
 		WillReturnError(errors.New("ISE"))
-	_, err := repo.CountProductsRepository()
+	_, err := repo.CountProductsRepository(t.Context(), &dto.ProductListQuery{})
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrISEProducts.Error())
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestCountProduct_Filtered(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" WHERE products.price >= $1 AND products.price <= $2 AND products.category = $3 AND products.image <> '' AND "products"."deleted_at" IS NULL`)).
+		WithArgs(decimal.NewFromInt(1000), decimal.NewFromInt(5000), "Snack").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(70000))
+
+	minPrice := decimal.NewFromInt(1000)
+	maxPrice := decimal.NewFromInt(5000)
+	hasImage := true
+	count, err := repo.CountProductsRepository(t.Context(), &dto.ProductListQuery{MinPrice: &minPrice, MaxPrice: &maxPrice, Category: "Snack", HasImage: &hasImage})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(70000), count)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,127 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var productImageColumns = []string{"id", "created_at", "updated_at", "barcode_id", "image", "position", "is_primary"}
+
+func TestCreateProductImage_FirstBecomesPrimary(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	image := entity.ProductImage{BarcodeId: "1", Image: "a_original.jpg"}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE barcode_id = $1 ORDER BY position, id`)).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(productImageColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "product_images" ("created_at","updated_at","barcode_id","image","position","is_primary") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`)).
+		WithArgs(utils.AnyTime{}, utils.AnyTime{}, "1", "a_original.jpg", 0, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "image"=$1,"version"=version + 1,"updated_at"=$2 WHERE barcode_id = $3 AND "products"."deleted_at" IS NULL`)).
+		WithArgs("a_original.jpg", utils.AnyTime{}, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateProductImageRepository(t.Context(), &image)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), image.ID)
+	assert.True(t, image.IsPrimary)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProductImage_AppendsAfterLast(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	image := entity.ProductImage{BarcodeId: "1", Image: "c_original.jpg"}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE barcode_id = $1 ORDER BY position, id`)).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(productImageColumns).
+			AddRow(1, nil, nil, "1", "a_original.jpg", 0, true).
+			AddRow(2, nil, nil, "1", "b_original.jpg", 3, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "product_images"`)).
+		WithArgs(utils.AnyTime{}, utils.AnyTime{}, "1", "c_original.jpg", 4, false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectCommit()
+
+	err := repo.CreateProductImageRepository(t.Context(), &image)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, image.Position)
+	assert.False(t, image.IsPrimary)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProductImage_TooMany(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	rows := sqlmock.NewRows(productImageColumns)
+	for i := 1; i <= 10; i++ {
+		rows.AddRow(i, nil, nil, "1", "a_original.jpg", i, i == 1)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images"`)).
+		WillReturnRows(rows)
+	mock.ExpectRollback()
+
+	err := repo.CreateProductImageRepository(t.Context(), &entity.ProductImage{BarcodeId: "1", Image: "k_original.jpg"})
+
+	assert.Equal(t, dto.ErrTooManyProductImages, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProductImage_ProductNotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	err := repo.CreateProductImageRepository(t.Context(), &entity.ProductImage{BarcodeId: "1", Image: "a_original.jpg"})
+
+	assert.Equal(t, dto.ErrProductDoesntExist, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProductImage_ISE(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images"`)).
+		WillReturnRows(sqlmock.NewRows(productImageColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "product_images"`)).
+		WillReturnError(errors.New("ISE"))
+	mock.ExpectRollback()
+
+	err := repo.CreateProductImageRepository(t.Context(), &entity.ProductImage{BarcodeId: "1", Image: "a_original.jpg"})
+
+	assert.Equal(t, dto.ErrISEProductImages, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
 	"tiga-putra-cashier-be/repository"
@@ -17,19 +18,20 @@ import (
 func TestCreateProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 	prod := &entity.Product{
 		BarcodeId:   "1",
 		Title:       "title-1",
 		Image:       "img-1",
 		Price:       decimal.NewFromInt32(1000),
 		Description: "desc1",
+		Category:    "snack",
 	}
 	mock.ExpectBegin()
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		`INSERT INTO "products" ("created_at","updated_at","deleted_at","barcode_id","image","title","price","description")
-	VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+		`INSERT INTO "products" ("created_at","updated_at","deleted_at","barcode_id","image","title","price","description","category","min_stock","reorder_quantity","supplier_id","version")
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING "id"`)).
 		WithArgs(
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
@@ -39,11 +41,22 @@ func TestCreateProduct_Success(t *testing.T) {
 			prod.Title,
 			prod.Price,
 			prod.Description,
+			prod.Category,
+			prod.MinStock,
+			prod.ReorderQuantity,
+			nil,
+			1,
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
+	mock.ExpectExec(regexp.QuoteMeta(`WITH updated AS (`)).
+		WithArgs(prod.BarcodeId, prod.BarcodeId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO price_histories (created_at, updated_at, barcode_id, price)`)).
+		WithArgs(prod.BarcodeId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
-	err := repo.CreateProductRepository(prod)
+	err := repo.CreateProductRepository(t.Context(), prod)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -51,19 +64,20 @@ func TestCreateProduct_Success(t *testing.T) {
 func TestCreateProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 	prod := &entity.Product{
 		BarcodeId:   "1",
 		Title:       "title-1",
 		Image:       "img-1",
 		Price:       decimal.NewFromInt32(1000),
 		Description: "desc1",
+		Category:    "snack",
 	}
 	mock.ExpectBegin()
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		`INSERT INTO "products" ("created_at","updated_at","deleted_at","barcode_id","image","title","price","description")
-	VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+		`INSERT INTO "products" ("created_at","updated_at","deleted_at","barcode_id","image","title","price","description","category","min_stock","reorder_quantity","supplier_id","version")
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING "id"`)).
 		WithArgs(
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
@@ -73,10 +87,15 @@ func TestCreateProduct_Error(t *testing.T) {
 			prod.Title,
 			prod.Price,
 			prod.Description,
+			prod.Category,
+			prod.MinStock,
+			prod.ReorderQuantity,
+			nil,
+			1,
 		).
 		WillReturnError(errors.New("error"))
 	mock.ExpectRollback()
-	err := repo.CreateProductRepository(prod)
+	err := repo.CreateProductRepository(t.Context(), prod)
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrToAddProduct)
 	assert.NoError(t, mock.ExpectationsWereMet())
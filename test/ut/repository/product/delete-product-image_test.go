@@ -0,0 +1,86 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteProductImage_PromotesNext(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE id = $1 AND barcode_id = $2 ORDER BY "product_images"."id" LIMIT $3`)).
+		WithArgs(1, "1", 1).
+		WillReturnRows(sqlmock.NewRows(productImageColumns).AddRow(1, nil, nil, "1", "a_original.jpg", 0, true))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "product_images" WHERE "product_images"."id" = $1`)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE barcode_id = $1 ORDER BY position, id,"product_images"."id" LIMIT $2`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows(productImageColumns).AddRow(2, nil, nil, "1", "b_original.jpg", 1, false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "product_images" SET "is_primary"=$1,"updated_at"=$2 WHERE "id" = $3`)).
+		WithArgs(true, utils.AnyTime{}, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "image"=$1,"version"=version + 1,"updated_at"=$2 WHERE barcode_id = $3`)).
+		WithArgs("b_original.jpg", utils.AnyTime{}, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	image, err := repo.DeleteProductImageRepository(t.Context(), "1", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a_original.jpg", image.Image)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteProductImage_LastClearsProductImage(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE id = $1 AND barcode_id = $2`)).
+		WillReturnRows(sqlmock.NewRows(productImageColumns).AddRow(1, nil, nil, "1", "a_original.jpg", 0, true))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "product_images"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE barcode_id = $1`)).
+		WillReturnRows(sqlmock.NewRows(productImageColumns))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "image"=$1`)).
+		WithArgs("", utils.AnyTime{}, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err := repo.DeleteProductImageRepository(t.Context(), "1", 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteProductImage_NotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE id = $1 AND barcode_id = $2`)).
+		WillReturnRows(sqlmock.NewRows(productImageColumns))
+	mock.ExpectRollback()
+
+	_, err := repo.DeleteProductImageRepository(t.Context(), "1", 1)
+
+	assert.Equal(t, dto.ErrProductImageNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
 	"tiga-putra-cashier-be/utils"
@@ -14,7 +15,7 @@ import (
 
 func TestDeleteProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 
 	barcodeId := "1"
 
@@ -25,17 +26,27 @@ func TestDeleteProduct_Success(t *testing.T) {
 			"1",
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "image" FROM "product_images" WHERE barcode_id = $1`)).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"image"}).AddRow("a_original.jpg").AddRow("b_original.jpg"))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "product_images" WHERE barcode_id = $1`)).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "image"=$1,"updated_at"=$2 WHERE barcode_id = $3`)).
+		WithArgs("", utils.AnyTime{}, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := repo.DeleteProductRepository(&barcodeId)
+	images, err := repo.DeleteProductRepository(t.Context(), &barcodeId, nil)
 
 	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_original.jpg", "b_original.jpg"}, images)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestDeleteProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 
 	barcodeId := "1"
 
@@ -48,7 +59,7 @@ func TestDeleteProduct_Error(t *testing.T) {
 		WillReturnError(errors.New("ISE"))
 	mock.ExpectRollback()
 
-	err := repo.DeleteProductRepository(&barcodeId)
+	_, err := repo.DeleteProductRepository(t.Context(), &barcodeId, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorderProductImages_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE barcode_id = $1 ORDER BY position, id`)).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(productImageColumns).
+			AddRow(1, nil, nil, "1", "a_original.jpg", 0, true).
+			AddRow(2, nil, nil, "1", "b_original.jpg", 1, false).
+			AddRow(3, nil, nil, "1", "c_original.jpg", 2, false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "product_images" SET "position"=$1,"updated_at"=$2 WHERE "id" = $3`)).
+		WithArgs(0, utils.AnyTime{}, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "product_images" SET "position"=$1,"updated_at"=$2 WHERE "id" = $3`)).
+		WithArgs(2, utils.AnyTime{}, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	images, err := repo.ReorderProductImagesRepository(t.Context(), "1", []uint{3, 2, 1})
+
+	assert.NoError(t, err)
+	assert.Len(t, images, 3)
+	for position, id := range []uint{3, 2, 1} {
+		assert.Equal(t, id, images[position].ID)
+		assert.Equal(t, position, images[position].Position)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReorderProductImages_Invalid(t *testing.T) {
+	for name, imageIds := range map[string][]uint{
+		"missing":   {2},
+		"duplicate": {1, 1},
+		"unknown":   {1, 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.MockDB(t)
+			repo := repository.NewProductImageRepository(db, config.Default())
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images"`)).
+				WillReturnRows(sqlmock.NewRows(productImageColumns).
+					AddRow(1, nil, nil, "1", "a_original.jpg", 0, true).
+					AddRow(2, nil, nil, "1", "b_original.jpg", 1, false))
+			mock.ExpectRollback()
+
+			_, err := repo.ReorderProductImagesRepository(t.Context(), "1", imageIds)
+
+			assert.Equal(t, dto.ErrInvalidImageOrder, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
 
@@ -14,15 +15,15 @@ import (
 func TestRetrieveDeletedProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description" FROM "products" WHERE barcode_id = $1 AND deleted_at IS NOT NULL ORDER BY "products"."id" LIMIT $2`)).
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description","products"."category","products"."min_stock","products"."reorder_quantity","products"."supplier_id","products"."version" FROM "products" WHERE barcode_id = $1 AND deleted_at IS NOT NULL ORDER BY "products"."id" LIMIT $2`)).
 		WithArgs("1", 1).
 		WillReturnRows(sqlmock.NewRows([]string{
 			"barcode_id", "title", "image", "price", "description"}).
 			AddRow("1", "Product A", "img-1", 1000, "desc-1"))
 
 	barcodeId := "1"
-	products, ok := repo.RetrieveDeletedProductByBarcodeId(&barcodeId)
+	products, ok := repo.RetrieveDeletedProductByBarcodeId(t.Context(), &barcodeId)
 	assert.True(t, true, ok)
 	assert.Equal(t, products.BarcodeId, "1")
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -31,13 +32,13 @@ func TestRetrieveDeletedProduct_Success(t *testing.T) {
 func TestRetrieveDeletedProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description" FROM "products" WHERE barcode_id = $1 AND deleted_at IS NOT NULL ORDER BY "products"."id" LIMIT $2`)).
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description","products"."category","products"."min_stock","products"."reorder_quantity","products"."supplier_id","products"."version" FROM "products" WHERE barcode_id = $1 AND deleted_at IS NOT NULL ORDER BY "products"."id" LIMIT $2`)).
 		WithArgs("1", 1).
 		WillReturnError(errors.New("ISE"))
 
 	barcodeId := "1"
-	_, ok := repo.RetrieveDeletedProductByBarcodeId(&barcodeId)
+	_, ok := repo.RetrieveDeletedProductByBarcodeId(t.Context(), &barcodeId)
 	assert.False(t, false, ok)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
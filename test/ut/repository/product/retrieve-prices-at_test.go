@@ -0,0 +1,32 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrievePricesAt_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	soldAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`store_price_histories.store_id = $1`)).
+		WithArgs(2, soldAt, soldAt, "1", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "deleted", "price"}).
+			AddRow("1", "Indomie", false, "3500").
+			AddRow("2", "Aqua", true, "4000"))
+
+	prices, err := repo.RetrievePricesAtRepository(t.Context(), 2, []string{"1", "2"}, soldAt)
+	assert.NoError(t, err)
+	assert.True(t, prices["1"].Price.Equal(decimal.NewFromInt(3500)))
+	assert.True(t, prices["2"].Deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
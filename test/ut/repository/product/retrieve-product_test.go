@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
 
@@ -14,15 +15,15 @@ import (
 func TestRetrieveProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description" FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2`)).
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description","products"."category","products"."min_stock","products"."reorder_quantity","products"."supplier_id","products"."version" FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2`)).
 		WithArgs("1", 1).
 		WillReturnRows(sqlmock.NewRows([]string{
 			"barcode_id", "title", "image", "price", "description"}).
 			AddRow("1", "Product A", "img-1", 1000, "desc-1"))
 
 	barcodeId := "1"
-	products, ok := repo.RetrieveProductByBarcodeId(&barcodeId)
+	products, ok := repo.RetrieveProductByBarcodeId(t.Context(), &barcodeId)
 	assert.True(t, true, ok)
 	assert.Equal(t, products.BarcodeId, "1")
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -31,13 +32,13 @@ func TestRetrieveProduct_Success(t *testing.T) {
 func TestRetrieveProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description" FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2`)).
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "products"."barcode_id","products"."image","products"."title","products"."price","products"."description","products"."category","products"."min_stock","products"."reorder_quantity","products"."supplier_id","products"."version" FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2`)).
 		WithArgs("1", 1).
 		WillReturnError(errors.New("record not found"))
 
 	barcodeId := "1"
-	_, ok := repo.RetrieveProductByBarcodeId(&barcodeId)
+	_, ok := repo.RetrieveProductByBarcodeId(t.Context(), &barcodeId)
 	assert.Equal(t, false, ok)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -3,9 +3,11 @@ package repository_test
 import (
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
+	"tiga-putra-cashier-be/utils"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -15,15 +17,15 @@ import (
 func TestRetrieveProducts_Sucess(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."deleted_at" IS NULL LIMIT $1`)).
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.* FROM "products" WHERE "products"."deleted_at" IS NULL ORDER BY products.id LIMIT $1`)).
 		WithArgs(12).
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "created_at", "updated_at", "deleted_at", "barcode_id", "title", "image", "price", "description"}).
 			AddRow(1, time.Now(), time.Now(), nil, "1", "Product A", "img-1", 1000, "desc-1").
 			AddRow(2, time.Now(), time.Now(), nil, "2", "Product B", "img-2", 2000, "desc-2"))
 
-	products, err := repo.RetrieveProductsRepository(12, 0)
+	products, err := repo.RetrieveProductsRepository(t.Context(), &dto.ProductListQuery{}, 12, 0)
 	assert.NoError(t, err)
 	assert.Len(t, products, 2)
 
@@ -33,15 +35,96 @@ func TestRetrieveProducts_Sucess(t *testing.T) {
 func TestRetrieveProducts_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."deleted_at" IS NULL LIMIT $1`)).
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.* FROM "products" WHERE "products"."deleted_at" IS NULL ORDER BY products.id LIMIT $1`)).
 		WithArgs(12).
 		WillReturnError(db.Error)
 
-	products, err := repo.RetrieveProductsRepository(12, 0)
+	products, err := repo.RetrieveProductsRepository(t.Context(), &dto.ProductListQuery{}, 12, 0)
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrISEProducts.Error())
 	assert.Len(t, products, 0)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestRetrieveProducts_SortByStock(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.* FROM "products" LEFT JOIN (SELECT barcode_id, SUM(quantity) AS current_stock FROM stock_movements WHERE deleted_at IS NULL AND ($1 = 0 OR store_id = $2) GROUP BY barcode_id) s ON s.barcode_id = products.barcode_id WHERE products.image = '' AND "products"."deleted_at" IS NULL ORDER BY COALESCE(s.current_stock, 0) DESC, products.barcode_id LIMIT $3 OFFSET $4`)).
+		WithArgs(2, 2, 50, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "barcode_id"}).AddRow(1, "1"))
+
+	hasImage := false
+	products, err := repo.RetrieveProductsRepository(t.Context(), &dto.ProductListQuery{Sort: "stock", Order: "desc", HasImage: &hasImage, StoreId: 2}, 50, 100)
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProducts_SortByTitle(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.* FROM "products" WHERE "products"."deleted_at" IS NULL ORDER BY products.title ASC, products.barcode_id LIMIT $1`)).
+		WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "barcode_id"}))
+
+	_, err := repo.RetrieveProductsRepository(t.Context(), &dto.ProductListQuery{Sort: "title"}, 12, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductsAfter_FirstPage(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.* FROM "products" WHERE "products"."deleted_at" IS NULL ORDER BY products.id ASC LIMIT $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "barcode_id"}).AddRow(1, "1").AddRow(2, "2").AddRow(3, "3"))
+
+	products, next, err := repo.RetrieveProductsAfterRepository(t.Context(), &dto.ProductListQuery{}, nil, 2)
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	cursor, err := utils.DecodeCursor(next)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.Cursor{Id: 2}, cursor)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductsAfter_SortByStockDesc(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.*, COALESCE(s.current_stock, 0) AS current_stock FROM "products" LEFT JOIN (SELECT barcode_id, SUM(quantity) AS current_stock FROM stock_movements WHERE deleted_at IS NULL AND ($1 = 0 OR store_id = $2) GROUP BY barcode_id) s ON s.barcode_id = products.barcode_id WHERE products.category = $3 AND (COALESCE(s.current_stock, 0), products.id) < ($4, $5) AND "products"."deleted_at" IS NULL ORDER BY COALESCE(s.current_stock, 0) DESC,products.id DESC LIMIT $6`)).
+		WithArgs(0, 0, "Snack", "40", 9, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "barcode_id", "current_stock"}).AddRow(4, "4", 40).AddRow(2, "2", 12).AddRow(8, "8", 12))
+
+	query := &dto.ProductListQuery{Sort: "stock", Order: "desc", Category: "Snack"}
+	products, next, err := repo.RetrieveProductsAfterRepository(t.Context(), query, &utils.Cursor{Sort: "stock", Order: "desc", Value: "40", Id: 9}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", products[1].BarcodeId)
+	cursor, _ := utils.DecodeCursor(next)
+	assert.Equal(t, utils.Cursor{Sort: "stock", Order: "desc", Value: "12", Id: 2}, cursor)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductsAfter_LastPage(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE (products.title, products.id) > ($1, $2) AND "products"."deleted_at" IS NULL ORDER BY products.title ASC,products.id ASC LIMIT $3`)).
+		WithArgs("Indomie", 3, 13).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(5, "Teh Botol"))
+
+	products, next, err := repo.RetrieveProductsAfterRepository(t.Context(), &dto.ProductListQuery{Sort: "title"}, &utils.Cursor{Sort: "title", Value: "Indomie", Id: 3}, 12)
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Empty(t, next)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
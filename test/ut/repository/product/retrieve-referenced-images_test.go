@@ -0,0 +1,40 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrieveReferencedImages_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT image FROM products WHERE deleted_at IS NULL AND image <> ''`)).
+		WillReturnRows(sqlmock.NewRows([]string{"image"}).AddRow("a_original.jpg").AddRow("legacy.jpg"))
+
+	images, err := repo.RetrieveReferencedImagesRepository(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_original.jpg", "legacy.jpg"}, images)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveReferencedImages_ISE(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductImageRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT image FROM products`)).
+		WillReturnError(errors.New("ISE"))
+
+	_, err := repo.RetrieveReferencedImagesRepository(t.Context())
+
+	assert.Equal(t, dto.ErrISEProductImages, err)
+}
@@ -1,8 +1,10 @@
 package repository_test
 
 import (
+	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
@@ -15,39 +17,76 @@ import (
 func TestSearchProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE title ILIKE $1 AND barcode_id = $2 AND "products"."deleted_at" IS NULL`)).
-		WithArgs("%product-1%", "1").
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE (to_tsvector('simple', title || ' ' || description) @@ to_tsquery('simple', $1) OR $2 <% title OR barcode_id LIKE $3) AND "products"."deleted_at" IS NULL ORDER BY (barcode_id LIKE $4) DESC, ts_rank(to_tsvector('simple', title || ' ' || description), to_tsquery('simple', $5)) + word_similarity($6, title) DESC, barcode_id LIMIT $7 OFFSET $8`)).
+		WithArgs("indomi:* & 100:*", "Indomi 100%", `Indomi 100\%%`, `Indomi 100\%%`, "indomi:* & 100:*", "Indomi 100%", 12, 12).
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "created_at", "updated_at", "deleted_at", "barcode_id", "title", "image", "price", "description"}).
-			AddRow(1, time.Now(), time.Now(), nil, "1", "product-1", "img-1", 1000, "desc-1"))
+			AddRow(1, time.Now(), time.Now(), nil, "1", "Indomie 100g", "img-1", 1000, "desc-1"))
 
-	productName := "product-1"
-	barcodeId := "1"
-	search := dto.SearchProductQuery{
-		Title:     &productName,
-		BarcodeId: &barcodeId,
-	}
-	products, err := repo.RetrieveProductForSearch(&search)
+	products, err := repo.RetrieveProductForSearch(t.Context(), &dto.SearchProductQuery{Query: " Indomi 100% "}, 12, 12)
 	assert.NoError(t, err)
 	assert.Len(t, products, 1)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestSearchProduct_BarcodePrefix(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE barcode_id LIKE $1 AND "products"."deleted_at" IS NULL ORDER BY barcode_id LIMIT $2`)).
+		WithArgs("899%", 12).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id"}).AddRow("8991").AddRow("8992"))
+
+	barcodeId := "899"
+	products, err := repo.RetrieveProductForSearch(t.Context(), &dto.SearchProductQuery{BarcodeId: &barcodeId}, 12, 0)
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestSearchProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
 
-	repo := repository.NewProductRepository(db)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE title ILIKE $1 AND "products"."deleted_at" IS NULL`)).
-		WithArgs("%product-1%").
-		WillReturnError(db.Error)
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE (to_tsvector('simple', title || ' ' || description) @@ to_tsquery('simple', $1) OR $2 <% title OR barcode_id LIKE $3)`)).
+		WillReturnError(errors.New("ISE"))
 
 	productName := "product-1"
 	search := dto.SearchProductQuery{
 		Title: &productName,
 	}
-	_, err := repo.RetrieveProductForSearch(&search)
+	_, err := repo.RetrieveProductForSearch(t.Context(), &search, 12, 0)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestCountProductsForSearch_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" WHERE (to_tsvector('simple', title || ' ' || description) @@ to_tsquery('simple', $1) OR $2 <% title OR barcode_id LIKE $3) AND barcode_id LIKE $4 AND "products"."deleted_at" IS NULL`)).
+		WithArgs("aqua:*", "aqua", "aqua%", "89%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	barcodeId := "89"
+	total, err := repo.CountProductsForSearchRepository(t.Context(), &dto.SearchProductQuery{Query: "aqua", BarcodeId: &barcodeId})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductSuggestions_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "barcode_id","title" FROM "products" WHERE (to_tsvector('simple', title || ' ' || description) @@ to_tsquery('simple', $1) OR $2 <% title OR barcode_id LIKE $3) AND "products"."deleted_at" IS NULL ORDER BY (barcode_id LIKE $4) DESC`)).
+		WithArgs("ind:*", "ind", "ind%", "ind%", "ind:*", "ind", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title"}).AddRow("1", "Indomie").AddRow("2", "Indomilk"))
+
+	suggestions, err := repo.RetrieveProductSuggestionsRepository(t.Context(), "ind", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ProductSuggestion{{BarcodeId: "1", Title: "Indomie"}, {BarcodeId: "2", Title: "Indomilk"}}, suggestions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,67 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamProducts_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."deleted_at" IS NULL ORDER BY barcode_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "created_at", "updated_at", "deleted_at", "barcode_id", "title", "image", "price", "description", "category"}).
+			AddRow(1, time.Now(), time.Now(), nil, "1", "Product A", "img-1", 1000, "desc-1", "Snack").
+			AddRow(2, time.Now(), time.Now(), nil, "2", "Product B", "img-2", 2000, "desc-2", ""))
+
+	var barcodeIds []string
+	err := repo.StreamProductsRepository(t.Context(), false, func(product entity.Product) error {
+		barcodeIds = append(barcodeIds, product.BarcodeId)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, barcodeIds)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamProducts_IncludeDeleted(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" ORDER BY barcode_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at", "barcode_id"}).
+			AddRow(1, nil, "1").
+			AddRow(2, time.Now(), "2"))
+
+	var deleted []bool
+	err := repo.StreamProductsRepository(t.Context(), true, func(product entity.Product) error {
+		deleted = append(deleted, product.DeletedAt.Valid)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, deleted)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamProducts_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products"`)).
+		WillReturnError(db.Error)
+
+	err := repo.StreamProductsRepository(t.Context(), false, func(product entity.Product) error { return nil })
+	assert.Equal(t, dto.ErrISEProducts, err)
+}
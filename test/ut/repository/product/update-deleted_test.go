@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
 	"tiga-putra-cashier-be/utils"
@@ -14,21 +15,25 @@ import (
 
 func TestUpdateDeletedProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 
 	barcodeId := "1"
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "deleted_at"=$1,"updated_at"=$2 WHERE barcode_id = $3`)).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "deleted_at"=$1,"image"=$2,"version"=version + 1,"updated_at"=$3 WHERE barcode_id = $4`)).
 		WithArgs(
 			nil,
+			"new_original.jpg",
 			utils.AnyTime{},
 			"1",
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`WITH updated AS (`)).
+		WithArgs("1", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := repo.UpdateDeletedProductRepository(&barcodeId)
+	err := repo.UpdateDeletedProductRepository(t.Context(), &barcodeId, "new_original.jpg")
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -36,21 +41,22 @@ func TestUpdateDeletedProduct_Success(t *testing.T) {
 
 func TestUpdateDeletedProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 
 	barcodeId := "1"
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "deleted_at"=$1,"updated_at"=$2 WHERE barcode_id = $3`)).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "deleted_at"=$1,"image"=$2,"version"=version + 1,"updated_at"=$3 WHERE barcode_id = $4`)).
 		WithArgs(
 			nil,
+			"new_original.jpg",
 			utils.AnyTime{},
 			"1",
 		).
 		WillReturnError(errors.New("ISE"))
 	mock.ExpectRollback()
 
-	err := repo.UpdateDeletedProductRepository(&barcodeId)
+	err := repo.UpdateDeletedProductRepository(t.Context(), &barcodeId, "new_original.jpg")
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
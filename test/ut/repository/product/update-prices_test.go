@@ -0,0 +1,125 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func addThousand(product entity.Product) (decimal.Decimal, error) {
+	if product.BarcodeId == "3" {
+		return product.Price, nil
+	}
+	return product.Price.Add(decimal.NewFromInt(1000)), nil
+}
+
+func TestRetrieveProductsByFilter_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	supplierId := uint(2)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE category = $1 AND supplier_id = $2 AND barcode_id IN ($3,$4) AND title ILIKE $5 ESCAPE '\' AND "products"."deleted_at" IS NULL ORDER BY barcode_id`)).
+		WithArgs("Snack", 2, "1", "2", "%mie%").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "price"}).AddRow("1", "3500"))
+
+	products, err := repo.RetrieveProductsByFilterRepository(t.Context(), dto.ProductFilter{Category: "Snack", SupplierId: &supplierId, BarcodeIds: []string{"1", "2"}, Search: "mie"})
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductsByFilter_EscapesSearch(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	// A search for "50%_off" matches that text, not every title with "50".
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE title ILIKE $1 ESCAPE '\' AND "products"."deleted_at" IS NULL ORDER BY barcode_id`)).
+		WithArgs(`%50\%\_off%`).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "price"}))
+
+	_, err := repo.RetrieveProductsByFilterRepository(t.Context(), dto.ProductFilter{Search: "50%_off"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateProductPrices_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE category = $1 AND "products"."deleted_at" IS NULL ORDER BY barcode_id FOR UPDATE`)).
+		WithArgs("Snack").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "price"}).
+			AddRow("1", "Indomie", "3500").
+			AddRow("3", "Chitato", "10000"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "price"=$1,"version"=version + 1,"updated_at"=$2 WHERE barcode_id = $3 AND "products"."deleted_at" IS NULL`)).
+		WithArgs(decimal.NewFromInt(4500), sqlmock.AnyArg(), "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO price_histories (created_at, updated_at, barcode_id, price)`)).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	changes, err := repo.UpdateProductPricesRepository(t.Context(), dto.ProductFilter{Category: "Snack"}, addThousand)
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.PriceChange{
+		{BarcodeId: "1", Title: "Indomie", OldPrice: decimal.NewFromInt(3500), NewPrice: decimal.NewFromInt(4500)},
+		{BarcodeId: "3", Title: "Chitato", OldPrice: decimal.NewFromInt(10000), NewPrice: decimal.NewFromInt(10000)},
+	}, changes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateProductPrices_RollbackOnAdjustError(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "price"}).AddRow("1", "3500").AddRow("2", "500"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "price"=$1`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateProductPricesRepository(t.Context(), dto.ProductFilter{Search: "a"}, func(product entity.Product) (decimal.Decimal, error) {
+		if product.BarcodeId == "2" {
+			return decimal.Decimal{}, dto.ErrNegativeAdjustedPrice
+		}
+		return decimal.NewFromInt(4000), nil
+	})
+	assert.Equal(t, dto.ErrNegativeAdjustedPrice, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateProductPrices_NotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).WillReturnRows(sqlmock.NewRows([]string{"barcode_id"}))
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateProductPricesRepository(t.Context(), dto.ProductFilter{BarcodeIds: []string{"9"}}, addThousand)
+	assert.Equal(t, dto.ErrProductsNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateProductPrices_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).WillReturnError(errors.New("ISE"))
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateProductPricesRepository(t.Context(), dto.ProductFilter{Category: "Snack"}, addThousand)
+	assert.Equal(t, dto.ErrToUpdateProductPrices, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
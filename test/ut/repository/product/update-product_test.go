@@ -4,6 +4,8 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/repository"
 	test "tiga-putra-cashier-be/test/mocks/db"
 	"tiga-putra-cashier-be/utils"
@@ -14,7 +16,7 @@ import (
 
 func TestUpdateProduct_Success(t *testing.T) {
 	db, mock := test.MockDB(t)
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 
 	barcodeId := "1"
 	productUpdates := map[string]any{
@@ -25,7 +27,7 @@ func TestUpdateProduct_Success(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "description"=$1,"image"=$2,"price"=$3,"title"=$4,"updated_at"=$5 WHERE barcode_id = $6 AND "products"."deleted_at" IS NULL`)).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "description"=$1,"image"=$2,"price"=$3,"title"=$4,"version"=version + 1,"updated_at"=$5 WHERE barcode_id = $6 AND "products"."deleted_at" IS NULL`)).
 		WithArgs(
 			"Updated description",
 			"updated-img.png",
@@ -35,9 +37,15 @@ func TestUpdateProduct_Success(t *testing.T) {
 			"1",
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`WITH updated AS (`)).
+		WithArgs("1", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO price_histories (created_at, updated_at, barcode_id, price)`)).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := repo.UpdateProductRepository(&barcodeId, &productUpdates)
+	err := repo.UpdateProductRepository(t.Context(), &barcodeId, &productUpdates, nil)
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -45,7 +53,7 @@ func TestUpdateProduct_Success(t *testing.T) {
 
 func TestUpdateProduct_Error(t *testing.T) {
 	db, mock := test.MockDB(t)
-	repo := repository.NewProductRepository(db)
+	repo := repository.NewProductRepository(db, config.Default())
 
 	barcodeId := "1"
 	productUpdates := map[string]any{
@@ -57,7 +65,7 @@ func TestUpdateProduct_Error(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.MatchExpectationsInOrder(false)
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "description"=$1,"image"=$2,"price"=$3,"title"=$4,"updated_at"=$5 WHERE barcode_id = $6 AND "products"."deleted_at" IS NULL`)).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "description"=$1,"image"=$2,"price"=$3,"title"=$4,"version"=version + 1,"updated_at"=$5 WHERE barcode_id = $6 AND "products"."deleted_at" IS NULL`)).
 		WithArgs(
 			"Updated description",
 			"updated-img.png",
@@ -69,9 +77,50 @@ func TestUpdateProduct_Error(t *testing.T) {
 		WillReturnError(errors.New("ISE"))
 	mock.ExpectRollback()
 
-	err := repo.UpdateProductRepository(&barcodeId, &productUpdates)
+	err := repo.UpdateProductRepository(t.Context(), &barcodeId, &productUpdates, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUpdateProduct_WithoutPriceKeepsHistory(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductRepository(db, config.Default())
+
+	barcodeId := "1"
+	productUpdates := map[string]any{"title": "Updated Title"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "title"=$1,"version"=version + 1,"updated_at"=$2 WHERE barcode_id = $3 AND "products"."deleted_at" IS NULL`)).
+		WithArgs("Updated Title", utils.AnyTime{}, "1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpdateProductRepository(t.Context(), &barcodeId, &productUpdates, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateProduct_VersionMismatch(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductRepository(db, config.Default())
+
+	barcodeId := "1"
+	version := uint(2)
+	productUpdates := map[string]any{
+		"title": "Updated Title",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "title"=$1,"version"=version + 1,"updated_at"=$2 WHERE barcode_id = $3 AND version = $4 AND "products"."deleted_at" IS NULL`)).
+		WithArgs("Updated Title", utils.AnyTime{}, "1", uint(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.UpdateProductRepository(t.Context(), &barcodeId, &productUpdates, &version)
+
+	assert.Equal(t, dto.ErrVersionMismatch, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUpsertProducts_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	products := []entity.Product{
+		{BarcodeId: "1", Title: "Indomie", Price: decimal.NewFromInt(3500), Description: "desc-1", Category: "Snack", Image: "new.jpg"},
+		{BarcodeId: "2", Title: "Aqua", Price: decimal.NewFromInt(4000), Description: "desc-2"},
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "products" ("created_at","updated_at","deleted_at","barcode_id","image","title","price","description","category","min_stock","reorder_quantity","supplier_id","version") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13),($14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26) ON CONFLICT ("barcode_id") DO UPDATE SET "updated_at"="excluded"."updated_at","title"="excluded"."title","price"="excluded"."price","description"="excluded"."description","category"="excluded"."category","min_stock"="excluded"."min_stock","reorder_quantity"="excluded"."reorder_quantity","supplier_id"="excluded"."supplier_id","image"=CASE WHEN excluded.image = '' THEN "products".image ELSE excluded.image END,"deleted_at"=$27,"version"="products".version + 1 WHERE ("products".title, "products".price, "products".description, "products".category, "products".min_stock, "products".reorder_quantity, "products".supplier_id) IS DISTINCT FROM (excluded.title, excluded.price, excluded.description, excluded.category, excluded.min_stock, excluded.reorder_quantity, excluded.supplier_id) OR (excluded.image <> '' AND excluded.image <> "products".image) OR "products".deleted_at IS NOT NULL RETURNING "id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta(`WITH updated AS (`)).
+		WithArgs("1", "2", "1", "2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO price_histories (created_at, updated_at, barcode_id, price)`)).
+		WithArgs("1", "2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpsertProductsRepository(t.Context(), products, []string{"category", "min_stock", "reorder_quantity", "supplier_id"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// A file without the stock level and supplier columns keeps what products
+// have for them.
+func TestUpsertProducts_OnlyColumnsInFile(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT ("barcode_id") DO UPDATE SET "updated_at"="excluded"."updated_at","title"="excluded"."title","price"="excluded"."price","description"="excluded"."description","image"=CASE WHEN excluded.image = '' THEN "products".image ELSE excluded.image END,"deleted_at"=$14,"version"="products".version + 1 WHERE ("products".title, "products".price, "products".description) IS DISTINCT FROM (excluded.title, excluded.price, excluded.description) OR`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(`WITH updated AS (`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO price_histories (created_at, updated_at, barcode_id, price)`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpsertProductsRepository(t.Context(), []entity.Product{{BarcodeId: "1", Title: "Indomie"}}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertProducts_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnError(errors.New("ISE"))
+	mock.ExpectRollback()
+
+	err := repo.UpsertProductsRepository(t.Context(), []entity.Product{{BarcodeId: "1", Title: "Indomie"}}, nil)
+	assert.Equal(t, dto.ErrToAddProduct, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductsByBarcodeIds_IncludesDeleted(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewProductRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE barcode_id IN ($1,$2)`)).
+		WithArgs("1", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at", "barcode_id", "image"}).
+			AddRow(1, nil, "1", "img-1").
+			AddRow(2, time.Now(), "2", "img-2"))
+
+	existing, err := repo.RetrieveProductsByBarcodeIdsRepository(t.Context(), []string{"1", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, gorm.DeletedAt{}, existing["1"].DeletedAt)
+	assert.True(t, existing["2"].DeletedAt.Valid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const productColumns = `"products"."barcode_id","products"."image","products"."title","products"."price","products"."description","products"."category","products"."min_stock","products"."reorder_quantity","products"."supplier_id","products"."version"`
+
+func TestWithTx_LocksReadsAndCommits(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductRepository(db, config.Default())
+
+	barcodeId := "1"
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT `+productColumns+` FROM "products" WHERE barcode_id = $1 AND deleted_at IS NOT NULL ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id"}).AddRow("1"))
+	mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "deleted_at"=$1,"image"=$2,"version"=version + 1,"updated_at"=$3 WHERE barcode_id = $4`)).
+		WithArgs(nil, "new_original.jpg", utils.AnyTime{}, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`WITH updated AS (`)).
+		WithArgs("1", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.WithTx(t.Context(), func(repo repository.ProductRepository) error {
+		if _, ok := repo.RetrieveDeletedProductByBarcodeId(t.Context(), &barcodeId); !ok {
+			return errors.New("not deleted")
+		}
+		return repo.UpdateDeletedProductRepository(t.Context(), &barcodeId, "new_original.jpg")
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_RollsBackOnError(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductRepository(db, config.Default())
+
+	barcodeId := "1"
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT `+productColumns+` FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id"}).AddRow("1"))
+	mock.ExpectRollback()
+
+	err := repo.WithTx(t.Context(), func(repo repository.ProductRepository) error {
+		repo.RetrieveProductByBarcodeId(t.Context(), &barcodeId)
+		return errors.New("ISE")
+	})
+
+	assert.EqualError(t, err, "ISE")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_ReadsOutsideDontLock(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewProductRepository(db, config.Default())
+
+	barcodeId := "1"
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT `+productColumns+` FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2`)+`$`).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id"}).AddRow("1"))
+
+	_, ok := repo.RetrieveProductByBarcodeId(t.Context(), &barcodeId)
+
+	assert.True(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,131 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var filter = dto.ReportQuery{From: "2025-01-01", To: "2025-01-31", StoreId: 2}
+
+func TestRetrieveSalesByPeriod_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewReportRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_char(date_trunc($1, t.created_at AT TIME ZONE $2), 'YYYY-MM-DD') AS period`)).
+		WithArgs(constant.ReportPeriodMonth, constant.ReportTimeZone, "2025-01-01", constant.ReportTimeZone, "2025-01-31", constant.ReportTimeZone, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"period", "transactions", "revenue", "average_ticket"}).
+			AddRow("2025-01-01", 120, "1800000", "15000.00"))
+
+	totals, err := repo.RetrieveSalesByPeriodRepository(t.Context(), filter, constant.ReportPeriodMonth)
+	assert.NoError(t, err)
+	assert.Len(t, totals, 1)
+	assert.Equal(t, int64(120), totals[0].Transactions)
+	assert.True(t, decimal.NewFromInt(15000).Equal(totals[0].AverageTicket))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveSalesByHour_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewReportRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXTRACT(HOUR FROM t.created_at AT TIME ZONE $1)::int AS hour`)).
+		WithArgs(constant.ReportTimeZone, "2025-01-01", constant.ReportTimeZone, "2025-01-31", constant.ReportTimeZone, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"hour", "transactions", "revenue"}).AddRow(8, 10, "150000").AddRow(17, 25, "410000"))
+
+	sales, err := repo.RetrieveSalesByHourRepository(t.Context(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 17, sales[1].Hour)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveSalesByCategory_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewReportRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`LEFT JOIN products p ON p.barcode_id = ti.barcode_id`)).
+		WithArgs(constant.UncategorizedCategory, "2025-01-01", constant.ReportTimeZone, "2025-01-31", constant.ReportTimeZone, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"category", "quantity", "revenue"}).AddRow("Snack", 40, "140000"))
+
+	sales, err := repo.RetrieveSalesByCategoryRepository(t.Context(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.CategorySales{{Category: "Snack", Quantity: 40, Revenue: decimal.NewFromInt(140000)}}, sales)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveTopProducts_ByQuantity(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewReportRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY quantity DESC, revenue DESC, ti.barcode_id
+		LIMIT $7`)).
+		WithArgs("2025-01-01", constant.ReportTimeZone, "2025-01-31", constant.ReportTimeZone, 2, 2, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "quantity", "revenue"}).AddRow("1", "Indomie", 40, "140000"))
+
+	products, err := repo.RetrieveTopProductsRepository(t.Context(), filter, constant.ReportRankByQuantity, 5)
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveSalesByCashier_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewReportRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY t.cashier`)).WillReturnError(errors.New("ISE"))
+
+	_, err := repo.RetrieveSalesByCashierRepository(t.Context(), filter)
+	assert.Equal(t, dto.ErrISEReport, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamStockValuation_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewReportRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`LEFT JOIN store_prices sp ON sp.store_id = s.store_id AND sp.barcode_id = s.barcode_id AND sp.deleted_at IS NULL
+		WHERE s.current_stock <> 0 AND ($1 = 0 OR s.store_id = $2)`)).
+		WithArgs(0, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"store_id", "store_name", "barcode_id", "title", "quantity", "unit_price", "value"}).
+			AddRow(1, "Main Store", "1", "Indomie", 10, "3500", "35000").
+			AddRow(2, "Branch", "1", "Indomie", 4, "3700", "14800"))
+
+	var valuations []dto.StockValuation
+	err := repo.StreamStockValuationRepository(t.Context(), 0, func(valuation dto.StockValuation) error {
+		valuations = append(valuations, valuation)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, valuations, 2)
+	assert.Equal(t, "Branch", valuations[1].StoreName)
+	assert.True(t, decimal.NewFromInt(14800).Equal(valuations[1].Value))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamStockValuation_StopsOnCallbackError(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewReportRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM (SELECT store_id, barcode_id, SUM(quantity) AS current_stock`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"store_id", "store_name", "barcode_id", "title", "quantity", "unit_price", "value"}).
+			AddRow(1, "Main Store", "1", "Indomie", 10, "3500", "35000").
+			AddRow(1, "Main Store", "2", "Aqua", 4, "4000", "16000"))
+
+	calls := 0
+	err := repo.StreamStockValuationRepository(t.Context(), 1, func(valuation dto.StockValuation) error {
+		calls++
+		return dto.ErrToWriteExport
+	})
+	assert.Equal(t, dto.ErrToWriteExport, err)
+	assert.Equal(t, 1, calls)
+}
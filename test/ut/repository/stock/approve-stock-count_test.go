@@ -0,0 +1,149 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRetrieveVariances_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	startedAt := time.Now()
+	session := entity.StockCountSession{Model: gorm.Model{ID: 1}, StoreId: 2, StartedAt: startedAt}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.barcode_id`)).
+		WithArgs(1, 2, startedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "system_quantity", "counted_quantity", "variance"}).
+			AddRow("1", "Product A", 10, 8, -2))
+
+	variances, err := repo.RetrieveVariancesRepository(t.Context(), session)
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.StockVariance{{BarcodeId: "1", Title: "Product A", SystemQuantity: 10, CountedQuantity: 8, Variance: -2}}, variances)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveUncountedProducts_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	startedAt := time.Now()
+	session := entity.StockCountSession{Model: gorm.Model{ID: 1}, StoreId: 2, StartedAt: startedAt}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.barcode_id, p.title`)).
+		WithArgs(2, startedAt, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "system_quantity"}).AddRow("2", "Product B", 5))
+
+	uncounted, err := repo.RetrieveUncountedProductsRepository(t.Context(), session)
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.UncountedProduct{{BarcodeId: "2", Title: "Product B", SystemQuantity: 5}}, uncounted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectStockLocked(mock sqlmock.Sqlmock, barcodeId string, stock int64) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs(barcodeId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(2, barcodeId).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(stock))
+}
+
+func TestApproveSession_SuccessIncludeUncounted(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	startedAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1 AND "stock_count_sessions"."deleted_at" IS NULL ORDER BY "stock_count_sessions"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "started_at", "store_id"}).AddRow(1, constant.StockCountOpen, startedAt, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.barcode_id`)).
+		WithArgs(1, 2, startedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "system_quantity", "counted_quantity", "variance"}).
+			AddRow("1", "Product A", 10, 8, -2).
+			AddRow("3", "Product C", 4, 4, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.barcode_id, p.title`)).
+		WithArgs(2, startedAt, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "system_quantity"}).AddRow("2", "Product B", 5))
+	// Product 1 is short 2, taken from its batch.
+	expectStockLocked(mock, "1", 10)
+	expectStockLocked(mock, "1", 10)
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY expiry_date ASC NULLS LAST, id`)).
+		WithArgs(2, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quantity", "expired"}).AddRow(7, 6, true))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=remaining_quantity - $1`)).
+		WithArgs(2, sqlmock.AnyArg(), 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -2, constant.MovementAdjustment, "stock-count-1", "Stock opname adjustment", 7, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	// Product 2 is short 5, but sales since the count left only 3.
+	expectStockLocked(mock, "2", 3)
+	expectStockLocked(mock, "2", 3)
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY expiry_date ASC NULLS LAST, id`)).
+		WithArgs(2, "2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quantity", "expired"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "2", -3, constant.MovementAdjustment, "stock-count-1", "Stock opname adjustment", nil, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "2", -2, constant.MovementAdjustment, "stock-count-1", "Stock opname adjustment", nil, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_count_sessions" SET "approved_at"=$1,"approved_by"=$2,"status"=$3,"updated_at"=$4 WHERE id = $5`)).
+		WithArgs(sqlmock.AnyArg(), "supervisor", constant.StockCountApproved, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	variances, err := repo.ApproveSessionRepository(t.Context(), 1, "supervisor", true)
+	assert.NoError(t, err)
+	assert.Len(t, variances, 3)
+	assert.Equal(t, int64(-5), variances[2].Variance)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApproveSession_Uncounted(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	startedAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "started_at", "store_id"}).AddRow(1, constant.StockCountOpen, startedAt, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.barcode_id`)).
+		WithArgs(1, 2, startedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "system_quantity", "counted_quantity", "variance"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.barcode_id, p.title`)).
+		WithArgs(2, startedAt, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "title", "system_quantity"}).AddRow("2", "Product B", 5))
+	mock.ExpectRollback()
+
+	_, err := repo.ApproveSessionRepository(t.Context(), 1, "supervisor", false)
+	assert.Equal(t, dto.ErrUncountedProducts, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApproveSession_NotOpen(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, constant.StockCountApproved))
+	mock.ExpectRollback()
+
+	_, err := repo.ApproveSessionRepository(t.Context(), 1, "supervisor", true)
+	assert.Equal(t, dto.ErrStockCountNotOpen, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,64 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrieveLowStockProducts_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`CROSS JOIN stores st`)).
+		WithArgs(0, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"store_id", "store_name", "barcode_id", "title", "current_stock", "min_stock", "reorder_quantity", "supplier_id"}).
+			AddRow(1, "Main Store", "1", "Indomie", 3, 10, 40, 2))
+
+	products, err := repo.RetrieveLowStockProductsRepository(t.Context(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Equal(t, int64(3), products[0].CurrentStock)
+	assert.Equal(t, uint(2), *products[0].SupplierId)
+	assert.Equal(t, "Main Store", products[0].StoreName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveLowStockProducts_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`CROSS JOIN stores st`)).WithArgs(0, 0).WillReturnError(errors.New("ISE"))
+
+	_, err := repo.RetrieveLowStockProductsRepository(t.Context(), 0)
+	assert.Equal(t, dto.ErrISEStock, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveReorderCandidates_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	since := time.Now().AddDate(0, 0, -30)
+	mock.ExpectQuery(regexp.QuoteMeta(`LEFT JOIN suppliers sp ON sp.id = p.supplier_id`)).
+		WithArgs(2, 2, constant.MovementSale, since).
+		WillReturnRows(sqlmock.NewRows([]string{"store_id", "store_name", "barcode_id", "title", "current_stock", "min_stock", "reorder_quantity", "supplier_id", "supplier_name", "lead_time_days", "sold_quantity"}).
+			AddRow(2, "Branch", "1", "Indomie", 3, 10, 40, 2, "Wings", 3, 120))
+
+	candidates, err := repo.RetrieveReorderCandidatesRepository(t.Context(), 2, since)
+	assert.NoError(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, "Wings", candidates[0].SupplierName)
+	assert.Equal(t, int64(120), candidates[0].SoldQuantity)
+	assert.Equal(t, int64(10), candidates[0].MinStock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
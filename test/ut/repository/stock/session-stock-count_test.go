@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrieveOpenSession_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE (store_id = $1 AND status = $2) AND "stock_count_sessions"."deleted_at" IS NULL ORDER BY "stock_count_sessions"."id" LIMIT $3`)).
+		WithArgs(1, constant.StockCountOpen, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "started_at"}).AddRow(1, constant.StockCountOpen, time.Now()))
+
+	session, ok := repo.RetrieveOpenSessionRepository(t.Context(), 1)
+	assert.True(t, ok)
+	assert.Equal(t, uint(1), session.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveOpenSession_NotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE (store_id = $1 AND status = $2)`)).
+		WithArgs(1, constant.StockCountOpen, 1).
+		WillReturnError(errors.New("record not found"))
+
+	_, ok := repo.RetrieveOpenSessionRepository(t.Context(), 1)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveSessionById_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1 AND "stock_count_sessions"."deleted_at" IS NULL ORDER BY "stock_count_sessions"."id" LIMIT $2`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, constant.StockCountApproved))
+
+	session, ok := repo.RetrieveSessionByIdRepository(t.Context(), 1)
+	assert.True(t, ok)
+	assert.Equal(t, constant.StockCountApproved, session.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateSession_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_count_sessions"`)).
+		WillReturnError(errors.New("ISE"))
+	mock.ExpectRollback()
+
+	err := repo.CreateSessionRepository(t.Context(), &entity.StockCountSession{Status: constant.StockCountOpen, StartedAt: time.Now(), StoreId: 1})
+	assert.Equal(t, dto.ErrISEStockCount, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveExistingBarcodes_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "barcode_id" FROM "products" WHERE barcode_id IN ($1,$2) AND "products"."deleted_at" IS NULL`)).
+		WithArgs("1", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id"}).AddRow("1"))
+
+	existing, err := repo.RetrieveExistingBarcodesRepository(t.Context(), []string{"1", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, existing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,232 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestReceiveStock_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	expiryDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_batches" ("created_at","updated_at","deleted_at","barcode_id","batch_number","expiry_date","received_quantity","remaining_quantity","store_id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", "LOT-1", expiryDate, 10, 10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", 10, constant.MovementReceipt, "PO-1", "", 4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	batch := entity.StockBatch{BarcodeId: "1", BatchNumber: "LOT-1", ExpiryDate: &expiryDate, ReceivedQuantity: 10, RemainingQuantity: 10, StoreId: 1}
+	err := repo.ReceiveStockRepository(t.Context(), &batch, "PO-1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), batch.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceiveStock_ProductNotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	err := repo.ReceiveStockRepository(t.Context(), &entity.StockBatch{BarcodeId: "1", ReceivedQuantity: 10, StoreId: 1}, "")
+	assert.Equal(t, dto.ErrProductDoesntExist, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceiveStock_StoreNotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(9, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	err := repo.ReceiveStockRepository(t.Context(), &entity.StockBatch{BarcodeId: "1", ReceivedQuantity: 10, StoreId: 9}, "")
+	assert.Equal(t, dto.ErrStoreDoesntExist, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveExpiringBatches_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	expiryDate := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`b.expiry_date <= CURRENT_DATE + $3::int`)).
+		WithArgs(2, 2, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"batch_id", "store_id", "barcode_id", "title", "batch_number", "expiry_date", "remaining_quantity", "expired"}).
+			AddRow(4, 2, "1", "Milk", "LOT-1", expiryDate, 6, false))
+
+	batches, err := repo.RetrieveExpiringBatchesRepository(t.Context(), 2, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ExpiringBatch{{BatchId: 4, StoreId: 2, BarcodeId: "1", Title: "Milk", BatchNumber: "LOT-1", ExpiryDate: expiryDate, RemainingQuantity: 6}}, batches)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWriteOffBatch_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	batchRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "barcode_id", "received_quantity", "remaining_quantity", "store_id"}).AddRow(4, "1", 10, 6, 1)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(8))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -6, constant.MovementWriteOff, "batch-4", "expired", 4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=$1`)).
+		WithArgs(0, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	batch, err := repo.WriteOffBatchRepository(t.Context(), 4, "expired")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), batch.RemainingQuantity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// A stock count found 4 of the 6 units in the batch, so only those are
+// written off and the stock doesn't go negative.
+func TestWriteOffBatch_CappedAtStock(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	batchRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "barcode_id", "received_quantity", "remaining_quantity", "store_id"}).AddRow(4, "1", 10, 6, 1)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(4))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -4, constant.MovementWriteOff, "batch-4", "", 4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=$1`)).
+		WithArgs(0, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	batch, err := repo.WriteOffBatchRepository(t.Context(), 4, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), batch.RemainingQuantity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWriteOffBatch_NoStockLeft(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	batchRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "barcode_id", "received_quantity", "remaining_quantity", "store_id"}).AddRow(4, "1", 10, 6, 1)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=$1`)).
+		WithArgs(0, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err := repo.WriteOffBatchRepository(t.Context(), 4, "")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWriteOffBatch_NotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	_, err := repo.WriteOffBatchRepository(t.Context(), 4, "")
+	assert.Equal(t, dto.ErrBatchNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWriteOffBatch_Empty(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	batchRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "barcode_id", "received_quantity", "remaining_quantity"}).AddRow(4, "1", 10, 0)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1`)).
+		WithArgs(4, 4, 1).
+		WillReturnRows(batchRows())
+	mock.ExpectRollback()
+
+	_, err := repo.WriteOffBatchRepository(t.Context(), 4, "")
+	assert.Equal(t, dto.ErrBatchEmpty, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,155 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func expectStoreCheck(mock sqlmock.Sqlmock, storeId int) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(storeId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(storeId))
+}
+
+func TestCreateTransfer_SuccessPerBatch(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockTransferRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	expectStoreCheck(mock, 1)
+	expectStoreCheck(mock, 2)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_transfers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(10))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY expiry_date ASC NULLS LAST, id`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quantity", "expired"}).AddRow(4, 3, false).AddRow(5, 7, false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=remaining_quantity - $1`)).
+		WithArgs(3, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=remaining_quantity - $1`)).
+		WithArgs(2, sqlmock.AnyArg(), 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -3, constant.MovementTransferOut, "transfer-3", "", 4, 1,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -2, constant.MovementTransferOut, "transfer-3", "", 5, 1,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_transfer_items" ("created_at","updated_at","deleted_at","transfer_id","barcode_id","quantity","source_batch_id") VALUES ($1,$2,$3,$4,$5,$6,$7),($8,$9,$10,$11,$12,$13,$14) RETURNING "id"`)).
+		WithArgs(
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 3, "1", 3, 4,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 3, "1", 2, 5,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	transfer := entity.StockTransfer{FromStoreId: 1, ToStoreId: 2, Status: constant.TransferInTransit, CreatedBy: "staff-1"}
+	err := repo.CreateTransferRepository(t.Context(), &transfer, map[string]int64{"1": 5})
+	assert.NoError(t, err)
+	assert.Len(t, transfer.Items, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTransfer_StoreNotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockTransferRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	expectStoreCheck(mock, 1)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(9, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	transfer := entity.StockTransfer{FromStoreId: 1, ToStoreId: 9}
+	err := repo.CreateTransferRepository(t.Context(), &transfer, map[string]int64{"1": 5})
+	assert.Equal(t, dto.ErrStoreDoesntExist, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceiveTransfer_SuccessKeepsExpiry(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockTransferRepository(db, config.Default())
+
+	expiryDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_transfers" WHERE id = $1 AND "stock_transfers"."deleted_at" IS NULL ORDER BY "stock_transfers"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs(3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "from_store_id", "to_store_id", "status"}).AddRow(3, 1, 2, constant.TransferInTransit))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_transfer_items" WHERE transfer_id = $1 AND "stock_transfer_items"."deleted_at" IS NULL ORDER BY barcode_id, id`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "transfer_id", "barcode_id", "quantity", "source_batch_id"}).AddRow(1, 3, "1", 3, 4))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_batches" WHERE id = $1 ORDER BY "stock_batches"."id" LIMIT $2`)).
+		WithArgs(4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "batch_number", "expiry_date"}).AddRow(4, "LOT-1", expiryDate))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_batches"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", "LOT-1", expiryDate, 3, 3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", 3, constant.MovementTransferIn, "transfer-3", "", 8, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_transfers" SET "received_at"=$1,"received_by"=$2,"status"=$3,"updated_at"=$4 WHERE id = $5`)).
+		WithArgs(sqlmock.AnyArg(), "staff-2", constant.TransferReceived, sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	transfer, err := repo.ReceiveTransferRepository(t.Context(), 3, "staff-2")
+	assert.NoError(t, err)
+	assert.Equal(t, constant.TransferReceived, transfer.Status)
+	assert.NotNil(t, transfer.ReceivedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceiveTransfer_NotInTransit(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockTransferRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_transfers" WHERE id = $1`)).
+		WithArgs(3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(3, constant.TransferReceived))
+	mock.ExpectRollback()
+
+	_, err := repo.ReceiveTransferRepository(t.Context(), 3, "staff-2")
+	assert.Equal(t, dto.ErrTransferNotInTransit, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveStockLevels_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM stores st`)).
+		WithArgs("1", constant.TransferInTransit, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"store_id", "store_name", "current_stock", "in_transit"}).
+			AddRow(1, "Main Store", 10, 0).
+			AddRow(2, "Branch", 4, 6))
+
+	levels, err := repo.RetrieveStockLevelsRepository(t.Context(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.StoreStockLevel{
+		{StoreId: 1, StoreName: "Main Store", CurrentStock: 10},
+		{StoreId: 2, StoreName: "Branch", CurrentStock: 4, InTransit: 6},
+	}, levels)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
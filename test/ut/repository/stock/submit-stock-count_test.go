@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUpsertCountItems_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1 AND "stock_count_sessions"."deleted_at" IS NULL ORDER BY "stock_count_sessions"."id" LIMIT $2 FOR SHARE`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, constant.StockCountOpen))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_count_items" ("created_at","updated_at","deleted_at","session_id","barcode_id","device_id","counted_by","counted_quantity") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ("session_id","barcode_id","device_id") DO UPDATE SET "counted_quantity"="excluded"."counted_quantity","counted_by"="excluded"."counted_by","updated_at"="excluded"."updated_at" RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, "1", "till-1", "staff-1", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.UpsertCountItemsRepository(t.Context(), 1, []entity.StockCountItem{
+		{SessionId: 1, BarcodeId: "1", DeviceId: "till-1", CountedBy: "staff-1", CountedQuantity: 5},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertCountItems_NotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	err := repo.UpsertCountItemsRepository(t.Context(), 1, []entity.StockCountItem{{SessionId: 1, BarcodeId: "1"}})
+	assert.Equal(t, dto.ErrStockCountNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertCountItems_NotOpen(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, constant.StockCountApproved))
+	mock.ExpectRollback()
+
+	err := repo.UpsertCountItemsRepository(t.Context(), 1, []entity.StockCountItem{{SessionId: 1, BarcodeId: "1"}})
+	assert.Equal(t, dto.ErrStockCountNotOpen, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertCountItems_InsertError(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStockCountRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_sessions" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, constant.StockCountOpen))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_count_items"`)).
+		WillReturnError(errors.New("ISE"))
+	mock.ExpectRollback()
+
+	err := repo.UpsertCountItemsRepository(t.Context(), 1, []entity.StockCountItem{{SessionId: 1, BarcodeId: "1"}})
+	assert.Equal(t, dto.ErrISEStockCount, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,93 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrieveStores_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStoreRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stores" WHERE "stores"."deleted_at" IS NULL ORDER BY id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "type"}).AddRow(1, "MAIN", "Main Store", "store"))
+
+	stores, err := repo.RetrieveStoresRepository(t.Context())
+	assert.NoError(t, err)
+	assert.Len(t, stores, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveStoreByCode_IncludesDeleted(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStoreRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stores" WHERE code = $1 ORDER BY "stores"."id" LIMIT $2`)).
+		WithArgs("MAIN", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).AddRow(1, "MAIN"))
+
+	store, ok := repo.RetrieveStoreByCodeRepository(t.Context(), "MAIN")
+	assert.True(t, ok)
+	assert.Equal(t, "MAIN", store.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertStorePrice_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStoreRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "store_prices" ("created_at","updated_at","deleted_at","store_id","barcode_id","price") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("store_id","barcode_id") DO UPDATE SET "price"="excluded"."price","updated_at"="excluded"."updated_at" RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 2, "1", decimal.NewFromInt(5500)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "store_price_histories" ("created_at","updated_at","deleted_at","store_id","barcode_id","price") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 2, "1", decimal.NewNullDecimal(decimal.NewFromInt(5500))).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.UpsertStorePriceRepository(t.Context(), &entity.StorePrice{StoreId: 2, BarcodeId: "1", Price: decimal.NewFromInt(5500)})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteStorePrice_NotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStoreRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "store_prices" WHERE store_id = $1 AND barcode_id = $2`)).
+		WithArgs(2, "1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.DeleteStorePriceRepository(t.Context(), 2, "1")
+	assert.Equal(t, dto.ErrStorePriceNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteStorePrice_RecordsRemoval(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewStoreRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "store_prices" WHERE store_id = $1 AND barcode_id = $2`)).
+		WithArgs(2, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "store_price_histories"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 2, "1", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.DeleteStorePriceRepository(t.Context(), 2, "1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
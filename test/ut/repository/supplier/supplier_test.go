@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrieveSuppliers_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewSupplierRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suppliers" WHERE "suppliers"."deleted_at" IS NULL ORDER BY name`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "lead_time_days"}).AddRow(1, "Wings", 3))
+
+	suppliers, err := repo.RetrieveSuppliersRepository(t.Context())
+	assert.NoError(t, err)
+	assert.Len(t, suppliers, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveSupplierById_NotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewSupplierRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suppliers" WHERE id = $1 AND "suppliers"."deleted_at" IS NULL ORDER BY "suppliers"."id" LIMIT $2`)).
+		WithArgs(1, 1).
+		WillReturnError(errors.New("record not found"))
+
+	_, ok := repo.RetrieveSupplierByIdRepository(t.Context(), 1)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateSupplier_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewSupplierRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suppliers" ("created_at","updated_at","deleted_at","name","phone","lead_time_days") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "Wings", "", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.CreateSupplierRepository(t.Context(), &entity.Supplier{Name: "Wings", LeadTimeDays: 3})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateSupplier_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewSupplierRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "suppliers" SET "name"=$1,"updated_at"=$2 WHERE id = $3`)).
+		WithArgs("Indofood", sqlmock.AnyArg(), 1).
+		WillReturnError(errors.New("ISE"))
+	mock.ExpectRollback()
+
+	updates := map[string]interface{}{"name": "Indofood"}
+	err := repo.UpdateSupplierRepository(t.Context(), 1, &updates)
+	assert.Equal(t, dto.ErrISESupplier, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
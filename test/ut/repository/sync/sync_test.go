@@ -0,0 +1,67 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const changedAt = "GREATEST(products.updated_at, COALESCE(products.deleted_at, products.updated_at))"
+
+func TestRetrieveProductChanges_FirstBatch(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewSyncRepository(db, config.Default())
+	first := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.*, `+changedAt+` AS changed_at FROM "products" WHERE `+changedAt+` <= $1 ORDER BY `+changedAt+` ASC,products.id ASC LIMIT $2`)).
+		WithArgs(utils.AnyTime{}, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "barcode_id", "deleted_at", "changed_at"}).
+			AddRow(1, "1", nil, first).
+			AddRow(2, "2", second, second))
+
+	products, last, err := repo.RetrieveProductChangesRepository(t.Context(), nil, time.Now(), 500)
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.True(t, products[1].DeletedAt.Valid)
+	assert.Equal(t, &utils.Cursor{Value: second.Format(time.RFC3339Nano), Id: 2}, last)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductChanges_AfterCursor(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewSyncRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE `+changedAt+` <= $1 AND (`+changedAt+`, products.id) > ($2, $3) ORDER BY`)).
+		WithArgs(utils.AnyTime{}, "2026-03-01T08:01:00Z", 2, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "barcode_id"}))
+
+	products, last, err := repo.RetrieveProductChangesRepository(t.Context(), &utils.Cursor{Value: "2026-03-01T08:01:00Z", Id: 2}, time.Now(), 500)
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+	assert.Nil(t, last)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveProductChanges_Error(t *testing.T) {
+	db, mock := test.MockDB(t)
+
+	repo := repository.NewSyncRepository(db, config.Default())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.*`)).
+		WillReturnError(db.Error)
+
+	_, _, err := repo.RetrieveProductChangesRepository(t.Context(), nil, time.Now(), 500)
+	assert.Equal(t, dto.ErrISESync, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
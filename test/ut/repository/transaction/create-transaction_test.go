@@ -0,0 +1,182 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newTransaction(quantity int64) entity.Transaction {
+	return entity.Transaction{
+		Cashier:       "cashier-1",
+		StoreId:       1,
+		PaymentMethod: "cash",
+		Total:         decimal.NewFromInt(1000 * quantity),
+		Items: []entity.TransactionItem{
+			{BarcodeId: "1", Title: "Milk", Quantity: quantity, UnitPrice: decimal.NewFromInt(1000), Subtotal: decimal.NewFromInt(1000 * quantity)},
+		},
+	}
+}
+
+func expectTransactionInsert(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transaction_items"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1 AND "products"."deleted_at" IS NULL ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+func TestCreateTransaction_SuccessFEFO(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectTransactionInsert(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY expiry_date ASC NULLS LAST, id`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quantity", "expired"}).
+			AddRow(1, 3, true).
+			AddRow(2, 4, false).
+			AddRow(3, 4, false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=remaining_quantity - $1`)).
+		WithArgs(4, sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=remaining_quantity - $1`)).
+		WithArgs(1, sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -4, constant.MovementSale, "transaction-1", "", 2, 1,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -1, constant.MovementSale, "transaction-1", "", 3, 1,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	transaction := newTransaction(5)
+	err := repo.CreateTransactionRepository(t.Context(), &transaction, false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), transaction.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_SuccessUntrackedStock(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectTransactionInsert(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(6))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY expiry_date ASC NULLS LAST, id`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quantity", "expired"}).AddRow(2, 2, false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=remaining_quantity - $1`)).
+		WithArgs(2, sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -2, constant.MovementSale, "transaction-1", "", 2, 1,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -3, constant.MovementSale, "transaction-1", "", nil, 1,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	transaction := newTransaction(5)
+	err := repo.CreateTransactionRepository(t.Context(), &transaction, false)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_ExpiredBatchBlocked(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectTransactionInsert(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY expiry_date ASC NULLS LAST, id`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quantity", "expired"}).AddRow(1, 3, true))
+	mock.ExpectRollback()
+
+	transaction := newTransaction(2)
+	err := repo.CreateTransactionRepository(t.Context(), &transaction, false)
+	assert.Equal(t, dto.ErrExpiredBatch, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_ExpiredBatchOverridden(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectTransactionInsert(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY expiry_date ASC NULLS LAST, id`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quantity", "expired"}).AddRow(1, 3, true))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_batches" SET "remaining_quantity"=remaining_quantity - $1`)).
+		WithArgs(2, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "1", -2, constant.MovementSale, "transaction-1", "", 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	transaction := newTransaction(2)
+	err := repo.CreateTransactionRepository(t.Context(), &transaction, true)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_StoreNotFound(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	transaction := newTransaction(1)
+	err := repo.CreateTransactionRepository(t.Context(), &transaction, false)
+	assert.Equal(t, dto.ErrStoreDoesntExist, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_InsufficientStock(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectTransactionInsert(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1))
+	mock.ExpectRollback()
+
+	transaction := newTransaction(5)
+	err := repo.CreateTransactionRepository(t.Context(), &transaction, false)
+	assert.Equal(t, dto.ErrInsufficientStock, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,174 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/repository"
+	test "tiga-putra-cashier-be/test/mocks/db"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOfflineTransaction(quantity int64) entity.Transaction {
+	clientId := "6f1c2a8e-3d4b-4c5d-9e6f-7a8b9c0d1e2f"
+	transaction := newTransaction(quantity)
+	transaction.ClientId = &clientId
+	transaction.CreatedAt = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	return transaction
+}
+
+func expectOfflineLookup(mock sqlmock.Sqlmock, existing *sqlmock.Rows) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "stores" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "transactions" WHERE client_id = $1 AND "transactions"."deleted_at" IS NULL LIMIT $2`)).
+		WithArgs("6f1c2a8e-3d4b-4c5d-9e6f-7a8b9c0d1e2f", 1).
+		WillReturnRows(existing)
+}
+
+func TestCreateOfflineTransaction_Duplicate(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectOfflineLookup(mock, sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectRollback()
+
+	transaction := newOfflineTransaction(2)
+	_, err := repo.CreateOfflineTransactionRepository(t.Context(), &transaction, nil)
+	assert.Equal(t, dto.ErrTransactionUploaded, err)
+	assert.Equal(t, uint(7), transaction.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateOfflineTransaction_ConcurrentDuplicate(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectOfflineLookup(mock, sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions"`)).
+		WillReturnError(&pgconn.PgError{Code: constant.PgUniqueViolation, ConstraintName: constant.TransactionClientIdIndex})
+	mock.ExpectRollback()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "transactions" WHERE client_id = $1 AND "transactions"."deleted_at" IS NULL ORDER BY "transactions"."id" LIMIT $2`)).
+		WithArgs("6f1c2a8e-3d4b-4c5d-9e6f-7a8b9c0d1e2f", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	transaction := newOfflineTransaction(2)
+	_, err := repo.CreateOfflineTransactionRepository(t.Context(), &transaction, nil)
+	assert.Equal(t, dto.ErrTransactionUploaded, err)
+	assert.Equal(t, uint(7), transaction.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateOfflineTransaction_InsufficientStockRecorded(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectOfflineLookup(mock, sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transaction_items"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE barcode_id = $1`)).
+		WithArgs("1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(quantity), 0) FROM "stock_movements"`)).
+		WithArgs(1, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "sale_conflicts"`)).
+		WithArgs(
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, "1", constant.ConflictPriceMismatch, 3, "Sold at 900, store price was 1000", "", nil,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, "1", constant.ConflictInsufficientStock, 3, dto.ErrInsufficientStock.Error(), "", nil,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	transaction := newOfflineTransaction(3)
+	conflicts, err := repo.CreateOfflineTransactionRepository(t.Context(), &transaction, []entity.SaleConflict{
+		{BarcodeId: "1", Type: constant.ConflictPriceMismatch, Quantity: 3, Detail: "Sold at 900, store price was 1000"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 2)
+	assert.Equal(t, uint(1), conflicts[1].TransactionId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateOfflineTransaction_DeletedProductSkipsStock(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	expectOfflineLookup(mock, sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transaction_items"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "sale_conflicts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	transaction := newOfflineTransaction(2)
+	conflicts, err := repo.CreateOfflineTransactionRepository(t.Context(), &transaction, []entity.SaleConflict{
+		{BarcodeId: "1", Type: constant.ConflictProductDeleted, Quantity: 2},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveSaleConflicts_OpenInStore(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "sale_conflicts"."id","sale_conflicts"."created_at","sale_conflicts"."updated_at","sale_conflicts"."deleted_at","sale_conflicts"."transaction_id","sale_conflicts"."barcode_id","sale_conflicts"."type","sale_conflicts"."quantity","sale_conflicts"."detail","sale_conflicts"."resolved_by","sale_conflicts"."resolved_at" FROM "sale_conflicts" JOIN transactions ON transactions.id = sale_conflicts.transaction_id WHERE transactions.store_id = $1 AND sale_conflicts.resolved_at IS NULL AND "sale_conflicts"."deleted_at" IS NULL ORDER BY sale_conflicts.id`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "type"}).AddRow(1, 4, constant.ConflictInsufficientStock))
+
+	conflicts, err := repo.RetrieveSaleConflictsRepository(t.Context(), dto.SaleConflictQuery{StoreId: 2})
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveSaleConflict_AlreadyResolved(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sale_conflicts" WHERE id = $1 AND "sale_conflicts"."deleted_at" IS NULL ORDER BY "sale_conflicts"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs(3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "resolved_by", "resolved_at"}).AddRow(3, "supervisor-1", time.Now()))
+	mock.ExpectRollback()
+
+	_, err := repo.ResolveSaleConflictRepository(t.Context(), 3, "supervisor-2")
+	assert.Equal(t, dto.ErrSaleConflictResolved, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveSaleConflict_Success(t *testing.T) {
+	db, mock := test.MockDB(t)
+	repo := repository.NewTransactionRepository(db, config.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sale_conflicts" WHERE id = $1`)).
+		WithArgs(3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type"}).AddRow(3, constant.ConflictPriceMismatch))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "sale_conflicts" SET "resolved_at"=$1,"resolved_by"=$2,"updated_at"=$3 WHERE "sale_conflicts"."deleted_at" IS NULL AND "id" = $4`)).
+		WithArgs(sqlmock.AnyArg(), "supervisor-1", sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	conflict, err := repo.ResolveSaleConflictRepository(t.Context(), 3, "supervisor-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "supervisor-1", conflict.ResolvedBy)
+	assert.NotNil(t, conflict.ResolvedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
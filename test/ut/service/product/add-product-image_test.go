@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"errors"
+	"mime/multipart"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddProductImage_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductImageService(mockedRepo, mockedUtils, config.Default())
+
+	image := &multipart.FileHeader{Filename: "image-1.jpg", Size: 1000}
+	mockedUtils.On("GetFileNameExtension", "image-1.jpg").Return("jpg")
+	mockedRepo.On("RetrieveProductImagesRepository", "1").Return([]entity.ProductImage{{BarcodeId: "1", Image: "a_original.jpg", IsPrimary: true}}, nil)
+	mockedUtils.On("UploadImage", image).Return("b_original.jpg", nil)
+	mockedRepo.On("CreateProductImageRepository", mock.AnythingOfType("*entity.ProductImage")).
+		Run(func(args mock.Arguments) {
+			created := args.Get(0).(*entity.ProductImage)
+			created.ID, created.Position = 2, 1
+		}).
+		Return(nil)
+
+	res, err := ps.AddProductImageService(t.Context(), "1", image)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), res.Id)
+	assert.Equal(t, 1, res.Position)
+	assert.False(t, res.Primary)
+	assert.Equal(t, "/assets/image/b_thumbnail.jpg", res.Urls.Thumbnail)
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
+}
+
+func TestAddProductImage_TooMany(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductImageService(mockedRepo, mockedUtils, config.Default())
+
+	image := &multipart.FileHeader{Filename: "image-1.jpg", Size: 1000}
+	mockedUtils.On("GetFileNameExtension", "image-1.jpg").Return("jpg")
+	mockedRepo.On("RetrieveProductImagesRepository", "1").Return(make([]entity.ProductImage, 10), nil)
+
+	_, err := ps.AddProductImageService(t.Context(), "1", image)
+
+	assert.Equal(t, dto.ErrTooManyProductImages, err)
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertNotCalled(t, "UploadImage", image)
+}
+
+func TestAddProductImage_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductImageService(mockedRepo, mockedUtils, config.Default())
+
+	image := &multipart.FileHeader{Filename: "image-1.jpg", Size: 1000}
+	mockedUtils.On("GetFileNameExtension", "image-1.jpg").Return("jpg")
+	mockedRepo.On("RetrieveProductImagesRepository", "1").Return(nil, dto.ErrProductDoesntExist)
+
+	_, err := ps.AddProductImageService(t.Context(), "1", image)
+
+	assert.Equal(t, dto.ErrProductDoesntExist, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestAddProductImage_BadRequestExtension(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductImageService(mockedRepo, mockedUtils, config.Default())
+
+	image := &multipart.FileHeader{Filename: "image-1.webp", Size: 1000}
+	mockedUtils.On("GetFileNameExtension", "image-1.webp").Return("webp")
+
+	_, err := ps.AddProductImageService(t.Context(), "1", image)
+
+	assert.Equal(t, dto.ErrWrongFileExtension, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestAddProductImage_ISECreateRemovesUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductImageService(mockedRepo, mockedUtils, config.Default())
+
+	image := &multipart.FileHeader{Filename: "image-1.jpg", Size: 1000}
+	mockedUtils.On("GetFileNameExtension", "image-1.jpg").Return("jpg")
+	mockedRepo.On("RetrieveProductImagesRepository", "1").Return([]entity.ProductImage{}, nil)
+	mockedUtils.On("UploadImage", image).Return("b_original.jpg", nil)
+	mockedRepo.On("CreateProductImageRepository", mock.AnythingOfType("*entity.ProductImage")).Return(errors.New("ISE"))
+	mockedUtils.On("DeleteImage", "b_original.jpg").Return(nil)
+
+	_, err := ps.AddProductImageService(t.Context(), "1", image)
+
+	assert.EqualError(t, err, "ISE")
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdjustPrices_PreviewPercentageRounded(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	filter := dto.ProductFilter{Category: "Snack"}
+	mockedRepo.On("RetrieveProductsByFilterRepository", filter).Return([]entity.Product{
+		{BarcodeId: "1", Title: "Indomie", Price: decimal.NewFromInt(3200)},
+		{BarcodeId: "2", Title: "Chitato", Price: decimal.NewFromInt(9000)},
+	}, nil)
+
+	result, err := ps.AdjustPricesService(t.Context(), dto.PriceAdjustmentRequest{
+		ProductFilter: filter, Type: "percentage", Value: decimal.NewFromInt(10), RoundTo: decimal.NewFromInt(500), Preview: true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Preview)
+	assert.Equal(t, 2, result.Total)
+	assert.Equal(t, 2, result.Changed)
+	assert.True(t, decimal.NewFromInt(3500).Equal(result.Products[0].NewPrice))
+	assert.True(t, decimal.NewFromInt(10000).Equal(result.Products[1].NewPrice))
+	mockedRepo.AssertNotCalled(t, "UpdateProductPricesRepository", mock.Anything, mock.Anything)
+}
+
+func TestAdjustPrices_FixedRoundedDown(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	supplierId := uint(2)
+	filter := dto.ProductFilter{SupplierId: &supplierId}
+	mockedRepo.On("UpdateProductPricesRepository", filter, mock.Anything).Return([]entity.Product{
+		{BarcodeId: "1", Price: decimal.NewFromInt(3500)},
+		{BarcodeId: "2", Price: decimal.NewFromInt(4000)},
+	}, nil)
+
+	result, err := ps.AdjustPricesService(t.Context(), dto.PriceAdjustmentRequest{
+		ProductFilter: filter, Type: "fixed", Value: decimal.NewFromInt(-250), RoundTo: decimal.NewFromInt(500), Rounding: "down",
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Preview)
+	assert.Equal(t, 2, result.Changed)
+	assert.True(t, decimal.NewFromInt(3000).Equal(result.Products[0].NewPrice))
+	assert.True(t, decimal.NewFromInt(3500).Equal(result.Products[1].NewPrice))
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestAdjustPrices_RoundsUpToCents(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	filter := dto.ProductFilter{BarcodeIds: []string{"1"}}
+	mockedRepo.On("UpdateProductPricesRepository", filter, mock.Anything).Return([]entity.Product{
+		{BarcodeId: "1", Price: decimal.NewFromInt(1000)},
+	}, nil)
+
+	result, err := ps.AdjustPricesService(t.Context(), dto.PriceAdjustmentRequest{
+		ProductFilter: filter, Type: "percentage", Value: decimal.RequireFromString("3.3333"), Rounding: "up",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1033.34", result.Products[0].NewPrice.String())
+}
+
+func TestAdjustPrices_NegativePrice(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	filter := dto.ProductFilter{Search: "aqua"}
+	mockedRepo.On("UpdateProductPricesRepository", filter, mock.Anything).Return([]entity.Product{
+		{BarcodeId: "1", Price: decimal.NewFromInt(4000)},
+		{BarcodeId: "2", Price: decimal.NewFromInt(500)},
+	}, nil)
+
+	_, err := ps.AdjustPricesService(t.Context(), dto.PriceAdjustmentRequest{ProductFilter: filter, Type: "fixed", Value: decimal.NewFromInt(-1000)})
+
+	assert.Equal(t, dto.ErrNegativeAdjustedPrice, err)
+}
+
+func TestAdjustPrices_NoFilter(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	_, err := ps.AdjustPricesService(t.Context(), dto.PriceAdjustmentRequest{Type: "fixed", Value: decimal.NewFromInt(500)})
+
+	assert.Equal(t, dto.ErrNoPriceFilter, err)
+}
+
+func TestAdjustPrices_ZeroValue(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	_, err := ps.AdjustPricesService(t.Context(), dto.PriceAdjustmentRequest{ProductFilter: dto.ProductFilter{Category: "Snack"}, Type: "fixed", Value: decimal.Zero})
+
+	assert.Equal(t, dto.ErrInvalidPriceAdjustment, err)
+}
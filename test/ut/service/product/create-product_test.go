@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mime/multipart"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
 	"tiga-putra-cashier-be/service"
@@ -19,7 +20,7 @@ func TestCreateProduct_SuccessAdd(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -31,14 +32,12 @@ func TestCreateProduct_SuccessAdd(t *testing.T) {
 		Price:       decimal.NewFromInt32(1000),
 		Description: "desc-1",
 	}
-	newFilename := "generated-1.jpg"
-	pathDir := "assets/image"
+	newFilename := "generated-1_original.jpg"
 
 	mockedUtils.On("GetFileNameExtension", req.Image.Filename).Return("jpg")
-	mockedUtils.On("GenerateNewFileName", "jpg").Return("generated-1.jpg")
 	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
 	mockedRepo.On("RetrieveProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
-	mockedUtils.On("UploadFile", req.Image, newFilename, pathDir).Return(nil)
+	mockedUtils.On("UploadImage", req.Image).Return(newFilename, nil)
 
 	newReq := entity.Product{
 		BarcodeId:   "1",
@@ -49,7 +48,7 @@ func TestCreateProduct_SuccessAdd(t *testing.T) {
 	}
 	mockedRepo.On("CreateProductRepository", &newReq).Return(nil)
 
-	err := ps.CreateProductService(req)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Nil(t, err)
 	mockedRepo.AssertExpectations(t)
@@ -60,7 +59,7 @@ func TestCreateProduct_SuccessRestore(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -73,8 +72,10 @@ func TestCreateProduct_SuccessRestore(t *testing.T) {
 		Description: "desc-1",
 	}
 	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
-	mockedRepo.On("UpdateDeletedProductRepository", &req.BarcodeId).Return(nil)
-	err := ps.CreateProductService(req)
+	mockedUtils.On("GetFileNameExtension", "image-1.jpg").Return("jpg")
+	mockedUtils.On("UploadImage", req.Image).Return("generated-1_original.jpg", nil)
+	mockedRepo.On("UpdateDeletedProductRepository", &req.BarcodeId, "generated-1_original.jpg").Return(nil)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Nil(t, err)
 	mockedRepo.AssertExpectations(t)
@@ -84,7 +85,7 @@ func TestCreateProduct_ISEUpdate(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -97,19 +98,23 @@ func TestCreateProduct_ISEUpdate(t *testing.T) {
 		Description: "desc-1",
 	}
 	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
-	mockedRepo.On("UpdateDeletedProductRepository", &req.BarcodeId).Return(errors.New("ISE"))
-	err := ps.CreateProductService(req)
+	mockedUtils.On("GetFileNameExtension", "image-1.jpg").Return("jpg")
+	mockedUtils.On("UploadImage", req.Image).Return("generated-1_original.jpg", nil)
+	mockedRepo.On("UpdateDeletedProductRepository", &req.BarcodeId, "generated-1_original.jpg").Return(errors.New("ISE"))
+	mockedUtils.On("DeleteImage", "generated-1_original.jpg").Return(nil)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
 	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
 }
 
 func TestCreateProduct_ISESaveFile(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -121,20 +126,15 @@ func TestCreateProduct_ISESaveFile(t *testing.T) {
 		Price:       decimal.NewFromInt32(1000),
 		Description: "desc-1",
 	}
-	newFilename := "generated-1.jpg"
-	pathDir := "assets/image"
-
 	mockedUtils.On("GetFileNameExtension", req.Image.Filename).Return("jpg")
-	mockedUtils.On("GenerateNewFileName", "jpg").Return("generated-1.jpg")
-	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
-	mockedRepo.On("RetrieveProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
-	mockedUtils.On("UploadFile", req.Image, newFilename, pathDir).Return(dto.ErrToSaveFile)
+	mockedUtils.On("UploadImage", req.Image).Return("", dto.ErrToSaveFile)
 
-	err := ps.CreateProductService(req)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrToSaveFile)
-	mockedRepo.AssertExpectations(t)
+	// The image is uploaded before the transaction, which never opens.
+	mockedRepo.AssertNotCalled(t, "RetrieveProductByBarcodeId", &req.BarcodeId)
 	mockedUtils.AssertExpectations(t)
 }
 
@@ -142,7 +142,7 @@ func TestCreateProduct_ISEAddProduct(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -154,14 +154,12 @@ func TestCreateProduct_ISEAddProduct(t *testing.T) {
 		Price:       decimal.NewFromInt32(1000),
 		Description: "desc-1",
 	}
-	newFilename := "generated-1.jpg"
-	pathDir := "assets/image"
+	newFilename := "generated-1_original.jpg"
 
 	mockedUtils.On("GetFileNameExtension", req.Image.Filename).Return("jpg")
-	mockedUtils.On("GenerateNewFileName", "jpg").Return("generated-1.jpg")
 	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
 	mockedRepo.On("RetrieveProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
-	mockedUtils.On("UploadFile", req.Image, newFilename, pathDir).Return(nil)
+	mockedUtils.On("UploadImage", req.Image).Return(newFilename, nil)
 
 	newReq := entity.Product{
 		BarcodeId:   "1",
@@ -171,8 +169,9 @@ func TestCreateProduct_ISEAddProduct(t *testing.T) {
 		Description: "desc-1",
 	}
 	mockedRepo.On("CreateProductRepository", &newReq).Return(dto.ErrToAddProduct)
+	mockedUtils.On("DeleteImage", newFilename).Return(nil)
 
-	err := ps.CreateProductService(req)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrToAddProduct)
@@ -184,7 +183,7 @@ func TestCreateProduct_BadRequestExtension(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -196,9 +195,8 @@ func TestCreateProduct_BadRequestExtension(t *testing.T) {
 		Price:       decimal.NewFromInt32(1000),
 		Description: "desc-1",
 	}
-	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
 	mockedUtils.On("GetFileNameExtension", req.Image.Filename).Return("webp")
-	err := ps.CreateProductService(req)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrWrongFileExtension.Error())
@@ -210,7 +208,7 @@ func TestCreateProduct_BadRequestSize(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -222,9 +220,8 @@ func TestCreateProduct_BadRequestSize(t *testing.T) {
 		Price:       decimal.NewFromInt32(1000),
 		Description: "desc-1",
 	}
-	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
 	mockedUtils.On("GetFileNameExtension", req.Image.Filename).Return("jpg")
-	err := ps.CreateProductService(req)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrLimitSizeExceeded.Error())
@@ -236,7 +233,7 @@ func TestCreateProduct_ConflictExist(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	req := dto.AddProductRequest{
 		BarcodeId: "1",
@@ -250,8 +247,10 @@ func TestCreateProduct_ConflictExist(t *testing.T) {
 	}
 	mockedRepo.On("RetrieveDeletedProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
 	mockedUtils.On("GetFileNameExtension", req.Image.Filename).Return("jpg")
+	mockedUtils.On("UploadImage", req.Image).Return("generated-1_original.jpg", nil)
 	mockedRepo.On("RetrieveProductByBarcodeId", &req.BarcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
-	err := ps.CreateProductService(req)
+	mockedUtils.On("DeleteImage", "generated-1_original.jpg").Return(nil)
+	err := ps.CreateProductService(t.Context(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrProductExist.Error())
@@ -0,0 +1,46 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteProductImage_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductImageService(mockedRepo, mockedUtils, config.Default())
+
+	mockedRepo.On("DeleteProductImageRepository", "1", uint(2)).Return(entity.ProductImage{Image: "b_original.jpg"}, nil)
+	mockedUtils.On("DeleteImage", "b_original.jpg").Return(errors.New("ISE"))
+
+	err := ps.DeleteProductImageService(t.Context(), "1", 2)
+
+	assert.NoError(t, err)
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
+}
+
+func TestDeleteProductImage_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductImageRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductImageService(mockedRepo, mockedUtils, config.Default())
+
+	mockedRepo.On("DeleteProductImageRepository", "1", uint(2)).Return(entity.ProductImage{}, dto.ErrProductImageNotFound)
+
+	err := ps.DeleteProductImageService(t.Context(), "1", 2)
+
+	assert.Equal(t, dto.ErrProductImageNotFound, err)
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertNotCalled(t, "DeleteImage", "")
+}
@@ -3,6 +3,7 @@ package service_test
 import (
 	"errors"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/service"
 	testRepo "tiga-putra-cashier-be/test/mocks/product"
@@ -16,27 +17,47 @@ func TestDeleteProduct_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testRepo.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
-	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
-	mockedRepo.On("DeleteProductRepository", &barcodeId).Return(nil)
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{Image: "a_original.jpg"}, true)
+	mockedRepo.On("DeleteProductRepository", &barcodeId, (*uint)(nil)).Return([]string{"a_original.jpg", "b_original.jpg"}, nil)
+	mockedUtils.On("DeleteImage", "a_original.jpg").Return(nil)
+	mockedUtils.On("DeleteImage", "b_original.jpg").Return(errors.New("ISE"))
 
-	err := ps.DeleteProductService(&barcodeId)
+	err := ps.DeleteProductService(t.Context(), &barcodeId, nil)
 	assert.Nil(t, err)
 	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
+}
+
+func TestDeleteProduct_LegacyImage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testRepo.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	barcodeId := "1"
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{Image: "legacy.jpg"}, true)
+	mockedRepo.On("DeleteProductRepository", &barcodeId, (*uint)(nil)).Return([]string{}, nil)
+	mockedUtils.On("DeleteImage", "legacy.jpg").Return(nil)
+
+	err := ps.DeleteProductService(t.Context(), &barcodeId, nil)
+	assert.Nil(t, err)
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
 }
 
 func TestDeleteProduct_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testRepo.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
 
-	err := ps.DeleteProductService(&barcodeId)
+	err := ps.DeleteProductService(t.Context(), &barcodeId, nil)
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrProductDoesntExist)
 	mockedRepo.AssertExpectations(t)
@@ -46,14 +67,15 @@ func TestDeleteProduct_ISEDelete(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testRepo.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
-	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
-	mockedRepo.On("DeleteProductRepository", &barcodeId).Return(errors.New("ISE"))
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{Image: "a_original.jpg"}, true)
+	mockedRepo.On("DeleteProductRepository", &barcodeId, (*uint)(nil)).Return(nil, errors.New("ISE"))
 
-	err := ps.DeleteProductService(&barcodeId)
+	err := ps.DeleteProductService(t.Context(), &barcodeId, nil)
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
 	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertNotCalled(t, "DeleteImage", "a_original.jpg")
 }
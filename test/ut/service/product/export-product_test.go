@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func exportedProducts() []entity.Product {
+	supplierId := uint(2)
+	return []entity.Product{
+		{BarcodeId: "1", Title: "Indomie", Category: "Snack", Price: decimal.RequireFromString("3500.50"), Description: "Mie goreng, pedas", MinStock: 10, ReorderQuantity: 40, SupplierId: &supplierId, Image: "1.jpg"},
+		{BarcodeId: "2", Title: "Aqua", Price: decimal.NewFromInt(4000), Description: "Air mineral", Model: gorm.Model{DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}},
+	}
+}
+
+func TestExportProducts_CSV(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	mockedRepo.On("StreamProductsRepository", false, mock.Anything).Return(exportedProducts()[:1], nil)
+
+	var buf bytes.Buffer
+	w, _ := utils.NewExportWriter(constant.ExportFormatCSV, "Products", &buf)
+	images, err := ps.ExportProductsService(t.Context(), false, w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.jpg"}, images)
+	assert.Equal(t, "barcode_id,title,price,description,category,image,min_stock,reorder_quantity,supplier_id\n"+
+		"1,Indomie,3500.5,\"Mie goreng, pedas\",Snack,1.jpg,10,40,2\n", buf.String())
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestExportProducts_IncludeDeleted(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	mockedRepo.On("StreamProductsRepository", true, mock.Anything).Return(exportedProducts(), nil)
+
+	var buf bytes.Buffer
+	w, _ := utils.NewExportWriter(constant.ExportFormatCSV, "Products", &buf)
+	_, err := ps.ExportProductsService(t.Context(), true, w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "barcode_id,title,price,description,category,image,min_stock,reorder_quantity,supplier_id,status\n"+
+		"1,Indomie,3500.5,\"Mie goreng, pedas\",Snack,1.jpg,10,40,2,active\n"+
+		"2,Aqua,4000,Air mineral,,,0,0,,deleted\n", buf.String())
+	assert.Equal(t, dto.PRODUCT_IMPORT_COLUMNS, []string{"barcode_id", "title", "price", "description", "category", "image", "min_stock", "reorder_quantity", "supplier_id"})
+}
+
+func TestExportProducts_Error(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	mockedRepo.On("StreamProductsRepository", false, mock.Anything).Return([]entity.Product{}, dto.ErrISEProducts)
+
+	var buf bytes.Buffer
+	w, _ := utils.NewExportWriter(constant.ExportFormatCSV, "Products", &buf)
+	_, err := ps.ExportProductsService(t.Context(), false, w)
+
+	assert.Equal(t, dto.ErrISEProducts, err)
+}
+
+func TestExportProductArchive_WithImages(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	products := exportedProducts()
+	products[1].Image = "gone.png"
+	mockedRepo.On("StreamProductsRepository", true, mock.Anything).Return(products, nil)
+	mockedUtils.On("OpenFile", "assets/image/1.jpg").Return(io.NopCloser(bytes.NewBufferString("jpeg")), nil)
+	mockedUtils.On("OpenFile", "assets/image/gone.png").Return(nil, fs.ErrNotExist)
+
+	var buf bytes.Buffer
+	err := ps.ExportProductArchiveService(t.Context(), dto.ExportProductQuery{Format: constant.ExportFormatCSV, IncludeDeleted: true, WithImages: true}, &buf)
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{"products.csv", "images/1.jpg"}, names)
+	image, _ := archive.File[1].Open()
+	content, _ := io.ReadAll(image)
+	assert.Equal(t, "jpeg", string(content))
+	mockedUtils.AssertExpectations(t)
+}
+
+func TestExportProductArchive_OpenError(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	mockedRepo.On("StreamProductsRepository", false, mock.Anything).Return(exportedProducts()[:1], nil)
+	mockedUtils.On("OpenFile", "assets/image/1.jpg").Return(nil, errors.New("permission denied"))
+
+	err := ps.ExportProductArchiveService(t.Context(), dto.ExportProductQuery{Format: constant.ExportFormatXLSX, WithImages: true}, &bytes.Buffer{})
+
+	assert.Equal(t, dto.ErrToWriteExport, err)
+}
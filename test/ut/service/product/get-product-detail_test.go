@@ -1,7 +1,9 @@
 package service_test
 
 import (
+	"context"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/service"
 	testProduct "tiga-putra-cashier-be/test/mocks/product"
@@ -17,12 +19,12 @@ func TestGetProductDetail_Success(t *testing.T) {
 
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 	barcodeId := "1"
 	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{
 		BarcodeId: "1", Image: "image-1", Title: "title-1", Price: decimal.NewFromInt32(1000), Description: "desc-1",
 	}, true)
-	result, err := ps.GetProductDetailService(&barcodeId)
+	result, err := ps.GetProductDetailService(t.Context(), &barcodeId, 0)
 	assert.Nil(t, err)
 	assert.Equal(t, result.BarcodeId, "1")
 }
@@ -31,12 +33,75 @@ func TestGetProductDetail_RetrieveError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 	barcodeId := "1"
 	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
-	result, err := ps.GetProductDetailService(&barcodeId)
+	result, err := ps.GetProductDetailService(t.Context(), &barcodeId, 0)
 
 	assert.NotNil(t, err)
 	assert.Equal(t, err.Error(), dto.ErrProductDoesntExist.Error())
 	assert.Equal(t, result, dto.ProductWithoutTimeStamp{})
 }
+
+func TestGetProductDetail_SuccessStorePrice(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	barcodeId := "1"
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{
+		BarcodeId: "1", Title: "title-1", Price: decimal.NewFromInt32(1000),
+	}, true)
+	mockedRepo.On("RetrieveStorePriceOverridesRepository", uint(2), []string{"1"}).Return(map[string]decimal.Decimal{"1": decimal.NewFromInt32(1200)}, nil)
+	result, err := ps.GetProductDetailService(t.Context(), &barcodeId, 2)
+
+	assert.Nil(t, err)
+	assert.True(t, decimal.NewFromInt32(1200).Equal(result.Price))
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetProductDetail_StoreNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	barcodeId := "1"
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{BarcodeId: "1"}, true)
+	mockedRepo.On("RetrieveStorePriceOverridesRepository", uint(9), []string{"1"}).Return(map[string]decimal.Decimal(nil), dto.ErrStoreDoesntExist)
+	_, err := ps.GetProductDetailService(t.Context(), &barcodeId, 9)
+
+	assert.Equal(t, dto.ErrStoreDoesntExist, err)
+}
+
+func TestGetProductDetail_ImageSizes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	barcodeId := "1"
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{
+		BarcodeId: "1", Image: "abc_original.jpg",
+	}, true)
+	result, err := ps.GetProductDetailService(t.Context(), &barcodeId, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, dto.ProductImages{
+		Original:  "/assets/image/abc_original.jpg",
+		Medium:    "/assets/image/abc_medium.jpg",
+		Thumbnail: "/assets/image/abc_thumbnail.jpg",
+	}, result.Images)
+}
+
+func TestGetProductDetail_Canceled(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	barcodeId := "1"
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
+
+	_, err := ps.GetProductDetailService(ctx, &barcodeId, 0)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
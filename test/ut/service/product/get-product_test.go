@@ -2,37 +2,40 @@ package service_test
 
 import (
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
 	"tiga-putra-cashier-be/service"
 	testProduct "tiga-putra-cashier-be/test/mocks/product"
 	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+	"tiga-putra-cashier-be/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetProductService_SuccessPageZero(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
-	mockedRepo.On("CountProductsRepository").Return(uint16(30), nil)
-	mockedRepo.On("RetrieveProductsRepository", uint16(12), uint16(0)).Return([]entity.Product{
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	mockedRepo.On("CountProductsRepository", mock.Anything).Return(int64(30), nil)
+	mockedRepo.On("RetrieveProductsRepository", mock.Anything, 12, 0).Return([]entity.Product{
 		{BarcodeId: "123", Title: "Product 1", Image: "img1", Price: decimal.NewFromInt32(1000), Description: "Desc 1"},
 		{BarcodeId: "345", Title: "Product 2", Image: "img2", Price: decimal.NewFromInt32(2000), Description: "Desc 2"},
 	}, nil)
-	page := uint16(0)
+	req := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 0}}
 
-	result, err := ps.GetProductService(&page)
+	result, err := ps.GetProductService(t.Context(), &req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, len(result.Products), 2)
-	assert.Equal(t, uint16(result.PageMetaData.Page), uint16(1))
-	assert.Equal(t, uint16(result.PageMetaData.PrevPage), uint16(1))
-	assert.Equal(t, uint16(result.PageMetaData.NextPage), uint16(2))
-	assert.Equal(t, uint16(result.PageMetaData.TotalPage), uint16(3))
+	assert.Equal(t, result.PageMetaData.Page, 1)
+	assert.Equal(t, result.PageMetaData.PrevPage, 1)
+	assert.Equal(t, result.PageMetaData.NextPage, 2)
+	assert.Equal(t, result.PageMetaData.TotalPage, 3)
 	mockedRepo.AssertExpectations(t)
 }
 
@@ -40,22 +43,22 @@ func TestGetProductService_SuccessPageOverTotal(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
-	mockedRepo.On("CountProductsRepository").Return(uint16(30), nil)
-	mockedRepo.On("RetrieveProductsRepository", uint16(12), uint16(24)).Return([]entity.Product{
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	mockedRepo.On("CountProductsRepository", mock.Anything).Return(int64(30), nil)
+	mockedRepo.On("RetrieveProductsRepository", mock.Anything, 12, 24).Return([]entity.Product{
 		{BarcodeId: "123", Title: "Product 1", Image: "img1", Price: decimal.NewFromInt32(1000), Description: "Desc 1"},
 		{BarcodeId: "345", Title: "Product 2", Image: "img2", Price: decimal.NewFromInt32(2000), Description: "Desc 2"},
 	}, nil)
-	page := uint16(5)
+	req := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 5}}
 
-	result, err := ps.GetProductService(&page)
+	result, err := ps.GetProductService(t.Context(), &req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, len(result.Products), 2)
-	assert.Equal(t, uint16(result.PageMetaData.Page), uint16(3))
-	assert.Equal(t, uint16(result.PageMetaData.PrevPage), uint16(2))
-	assert.Equal(t, uint16(result.PageMetaData.NextPage), uint16(3))
-	assert.Equal(t, uint16(result.PageMetaData.TotalPage), uint16(3))
+	assert.Equal(t, result.PageMetaData.Page, 3)
+	assert.Equal(t, result.PageMetaData.PrevPage, 2)
+	assert.Equal(t, result.PageMetaData.NextPage, 3)
+	assert.Equal(t, result.PageMetaData.TotalPage, 3)
 	mockedRepo.AssertExpectations(t)
 }
 
@@ -64,11 +67,11 @@ func TestGetProductService_CountError(t *testing.T) {
 
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
-	mockedRepo.On("CountProductsRepository").Return(uint16(0), dto.ErrISEProducts)
-	page := uint16(1)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	mockedRepo.On("CountProductsRepository", mock.Anything).Return(int64(0), dto.ErrISEProducts)
+	req := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 1}}
 
-	result, err := ps.GetProductService(&page)
+	result, err := ps.GetProductService(t.Context(), &req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrISEProducts.Error())
@@ -81,12 +84,12 @@ func TestGetProductService_RetrieveError(t *testing.T) {
 
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
-	mockedRepo.On("CountProductsRepository").Return(uint16(30), nil)
-	mockedRepo.On("RetrieveProductsRepository", uint16(12), uint16(0)).Return([]entity.Product{}, dto.ErrISEProducts)
-	page := uint16(1)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	mockedRepo.On("CountProductsRepository", mock.Anything).Return(int64(30), nil)
+	mockedRepo.On("RetrieveProductsRepository", mock.Anything, 12, 0).Return([]entity.Product{}, dto.ErrISEProducts)
+	req := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 1}}
 
-	result, err := ps.GetProductService(&page)
+	result, err := ps.GetProductService(t.Context(), &req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrISEProducts.Error())
@@ -99,14 +102,89 @@ func TestGetProductService_NotFound(t *testing.T) {
 
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
-	mockedRepo.On("CountProductsRepository").Return(uint16(0), nil)
-	page := uint16(1)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	mockedRepo.On("CountProductsRepository", mock.Anything).Return(int64(0), nil)
+	req := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 1}}
 
-	result, err := ps.GetProductService(&page)
+	result, err := ps.GetProductService(t.Context(), &req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), dto.ErrProductsNotFound.Error())
 	assert.Equal(t, len(result.Products), 0)
 	mockedRepo.AssertExpectations(t)
 }
+
+func TestGetProductService_PageSizeAndTotalItems(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	hasImage := true
+	req := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{Page: 700, PageSize: 100}, Sort: "price", Category: "Snack", HasImage: &hasImage}
+	mockedRepo.On("CountProductsRepository", &req).Return(int64(70000), nil)
+	mockedRepo.On("RetrieveProductsRepository", &req, 100, 69900).Return([]entity.Product{
+		{BarcodeId: "123", Title: "Product 1", Image: "img1", Price: decimal.NewFromInt32(1000), Description: "Desc 1"},
+	}, nil)
+
+	result, err := ps.GetProductService(t.Context(), &req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.PaginationResponse{Page: 700, PrevPage: 699, NextPage: 700, TotalPage: 700, PageSize: 100, TotalItems: 70000}, result.PageMetaData)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetProductsByCursor_FirstPage(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	req := dto.ProductListQuery{Sort: "price"}
+	mockedRepo.On("RetrieveProductsAfterRepository", &req, (*utils.Cursor)(nil), 12).Return([]entity.Product{
+		{BarcodeId: "123", Title: "Product 1", Price: decimal.NewFromInt32(1000)},
+	}, "next", nil)
+
+	result, err := ps.GetProductsByCursorService(t.Context(), &req)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Products, 1)
+	assert.Equal(t, dto.CursorResponse{NextCursor: "next", HasMore: true, PageSize: 12}, result.Cursor)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetProductsByCursor_LastPage(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	cursor := utils.Cursor{Sort: "price", Order: "desc", Value: "1000", Id: 7}
+	req := dto.ProductListQuery{PaginationRequest: dto.PaginationRequest{PageSize: 500}, Sort: "price", Order: "desc", Cursor: utils.EncodeCursor(cursor)}
+	mockedRepo.On("RetrieveProductsAfterRepository", &req, &cursor, 100).Return([]entity.Product{}, "", nil)
+
+	result, err := ps.GetProductsByCursorService(t.Context(), &req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ProductWithoutTimeStamp{}, result.Products)
+	assert.False(t, result.Cursor.HasMore)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetProductsByCursor_SortMismatch(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	req := dto.ProductListQuery{Sort: "title", Cursor: utils.EncodeCursor(utils.Cursor{Sort: "price", Value: "1000", Id: 7})}
+
+	_, err := ps.GetProductsByCursorService(t.Context(), &req)
+
+	assert.Equal(t, dto.ErrInvalidCursor, err)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductsAfterRepository", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetProductsByCursor_Empty(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	req := dto.ProductListQuery{}
+	mockedRepo.On("RetrieveProductsAfterRepository", &req, (*utils.Cursor)(nil), 12).Return([]entity.Product{}, "", nil)
+
+	_, err := ps.GetProductsByCursorService(t.Context(), &req)
+
+	assert.Equal(t, dto.ErrProductsNotFound, err)
+}
@@ -0,0 +1,258 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+func multipartFile(t *testing.T, field, filename string, content []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, filename)
+	assert.NoError(t, err)
+	_, _ = part.Write(content)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("POST", "/v1/product/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	assert.NoError(t, req.ParseMultipartForm(32<<20))
+	return req.MultipartForm.File[field][0]
+}
+
+func zipArchive(t *testing.T, files map[string][]byte) []byte {
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for name, content := range files {
+		entry, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, _ = entry.Write(content)
+	}
+	assert.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestImportProducts_DryRun(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	csv := "barcode_id,title,price,description,category,min_stock\n" +
+		"1,Indomie,3500,Mie goreng,Snack,10\n" +
+		"2,Aqua,4000,Air mineral,Drink,\n" +
+		"3,Teh Botol,5000,Teh manis,,\n" +
+		"4,,-1,Tanpa judul,,\n" +
+		"\n" +
+		"1,Indomie Kari,3600,Mie kari,Snack,abc\n"
+	mockedUtils.On("GetFileNameExtension", "products.csv").Return("csv")
+	mockedRepo.On("RetrieveProductsByBarcodeIdsRepository", []string{"1", "2", "3"}).Return(map[string]entity.Product{
+		"2": {BarcodeId: "2"},
+		"3": {BarcodeId: "3", Model: gorm.Model{DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}},
+	}, nil)
+
+	result, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{File: multipartFile(t, "file", "products.csv", []byte(csv)), DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Revived)
+	assert.Equal(t, 2, result.Invalid)
+	assert.Equal(t, dto.ImportProductRowResult{
+		Row: 5, BarcodeId: "4", Status: constant.ImportStatusInvalid,
+		Errors: []string{dto.ErrImportMissingField.Error(), dto.ErrImportInvalidPrice.Error()},
+	}, result.Rows[3])
+	assert.Equal(t, dto.ImportProductRowResult{
+		Row: 7, BarcodeId: "1", Status: constant.ImportStatusInvalid,
+		Errors: []string{dto.ErrImportInvalidNumber.Error(), dto.ErrImportDuplicateBarcode.Error()},
+	}, result.Rows[4])
+	mockedRepo.AssertNotCalled(t, "UpsertProductsRepository", mock.Anything, mock.Anything)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestImportProducts_WithArchiveImages(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	csv := "barcode_id,title,price,description,image\n" +
+		"1,Indomie,3500,Mie goreng,photos/indomie.png\n" +
+		"2,Aqua,4000,Air mineral,missing.png\n" +
+		"3,Teh Botol,5000,Teh manis,\n"
+	archive := zipArchive(t, map[string][]byte{"photos/indomie.png": []byte("png")})
+	mockedUtils.On("GetFileNameExtension", "products.csv").Return("csv")
+	mockedUtils.On("GetFileNameExtension", "images.zip").Return("zip")
+	mockedUtils.On("GetFileNameExtension", "photos/indomie.png").Return("png")
+	mockedUtils.On("GetFileNameExtension", "missing.png").Return("png")
+	mockedUtils.On("SaveImage", mock.Anything).Return("new_original.jpg", nil)
+	mockedUtils.On("DeleteImage", "old.jpg").Return(nil)
+	mockedRepo.On("RetrieveProductsByBarcodeIdsRepository", []string{"1", "2", "3"}).Return(map[string]entity.Product{
+		"1": {BarcodeId: "1", Image: "old.jpg"},
+	}, nil)
+	mockedRepo.On("UpsertProductsRepository", []entity.Product{
+		{BarcodeId: "1", Title: "Indomie", Price: decimal.NewFromInt(3500), Description: "Mie goreng", Image: "new_original.jpg"},
+		{BarcodeId: "3", Title: "Teh Botol", Price: decimal.NewFromInt(5000), Description: "Teh manis"},
+	}, []string(nil)).Return(nil)
+
+	result, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{
+		File:   multipartFile(t, "file", "products.csv", []byte(csv)),
+		Images: multipartFile(t, "images", "images.zip", archive),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, []string{dto.ErrImportImageNotFound.Error()}, result.Rows[1].Errors)
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
+}
+
+func TestImportProducts_BatchFailed(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	csv := "barcode_id,title,price,description,image\n" +
+		"1,Indomie,3500,Mie goreng,https://cdn.example.com/indomie.jpg?w=400\n"
+	mockedUtils.On("GetFileNameExtension", "products.csv").Return("csv")
+	mockedUtils.On("GetFileNameExtension", "indomie.jpg").Return("jpg")
+	mockedUtils.On("DownloadFile", "https://cdn.example.com/indomie.jpg?w=400").Return([]byte("jpg"), nil)
+	mockedUtils.On("SaveImage", mock.Anything).Return("new_original.jpg", nil)
+	mockedUtils.On("DeleteImage", "new_original.jpg").Return(nil)
+	mockedRepo.On("RetrieveProductsByBarcodeIdsRepository", []string{"1"}).Return(map[string]entity.Product{}, nil)
+	mockedRepo.On("UpsertProductsRepository", mock.Anything, mock.Anything).Return(dto.ErrToAddProduct)
+
+	result, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{File: multipartFile(t, "file", "products.csv", []byte(csv))})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []string{dto.ErrToAddProduct.Error()}, result.Rows[0].Errors)
+	mockedUtils.AssertExpectations(t)
+}
+
+func TestImportProducts_XLSX(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	file := excelize.NewFile()
+	_ = file.SetSheetRow("Sheet1", "A1", &[]interface{}{"Barcode_Id", "Title", "Price", "Description", "Supplier_Id"})
+	_ = file.SetSheetRow("Sheet1", "A2", &[]interface{}{"1", "Indomie", 3500, "Mie goreng", 2})
+	content, err := file.WriteToBuffer()
+	assert.NoError(t, err)
+
+	supplierId := uint(2)
+	mockedUtils.On("GetFileNameExtension", "products.xlsx").Return("xlsx")
+	mockedRepo.On("RetrieveProductsByBarcodeIdsRepository", []string{"1"}).Return(map[string]entity.Product{}, nil)
+	mockedRepo.On("UpsertProductsRepository", []entity.Product{
+		{BarcodeId: "1", Title: "Indomie", Price: decimal.NewFromInt(3500), Description: "Mie goreng", SupplierId: &supplierId},
+	}, []string{"supplier_id"}).Return(nil)
+
+	result, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{File: multipartFile(t, "file", "products.xlsx", content.Bytes())})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestImportProducts_InvalidHeader(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	mockedUtils.On("GetFileNameExtension", "products.csv").Return("csv")
+
+	_, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{File: multipartFile(t, "file", "products.csv", []byte("barcode,name\n1,Indomie\n"))})
+
+	assert.Equal(t, dto.ErrInvalidImportHeader, err)
+}
+
+func TestImportProducts_WrongExtension(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	mockedUtils.On("GetFileNameExtension", "products.txt").Return("txt")
+
+	_, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{File: multipartFile(t, "file", "products.txt", []byte("barcode_id"))})
+
+	assert.Equal(t, dto.ErrWrongImportFileExtension, err)
+}
+
+func TestImportProducts_ReimportExport(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	csv := "barcode_id,title,price,description,category,image,min_stock,reorder_quantity,supplier_id,status\n" +
+		"1,Indomie,3600,Mie goreng,Snack,1.jpg,10,40,,active\n" +
+		"2,Aqua,4000,Air mineral,,2.jpg,0,0,,deleted\n" +
+		"3,Teh Botol,5000,Teh manis,,,0,0,,archived\n"
+	mockedUtils.On("GetFileNameExtension", "products.csv").Return("csv")
+	mockedUtils.On("GetFileNameExtension", "1.jpg").Return("jpg")
+	mockedRepo.On("RetrieveProductsByBarcodeIdsRepository", []string{"1"}).Return(map[string]entity.Product{
+		"1": {BarcodeId: "1", Image: "1.jpg"},
+	}, nil)
+	mockedRepo.On("UpsertProductsRepository", []entity.Product{
+		{BarcodeId: "1", Title: "Indomie", Price: decimal.NewFromInt(3600), Description: "Mie goreng", Category: "Snack", MinStock: 10, ReorderQuantity: 40},
+	}, []string{"category", "min_stock", "reorder_quantity", "supplier_id"}).Return(nil)
+
+	result, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{File: multipartFile(t, "file", "products.csv", []byte(csv))})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 1, result.Invalid)
+	assert.Equal(t, dto.ImportProductRowResult{Row: 3, BarcodeId: "2", Status: constant.ImportStatusSkipped}, result.Rows[1])
+	assert.Equal(t, []string{dto.ErrImportInvalidStatus.Error()}, result.Rows[2].Errors)
+	mockedUtils.AssertNotCalled(t, "SaveImage", mock.Anything)
+	mockedRepo.AssertExpectations(t)
+}
+
+// Rows that match the product are left out, comparing only the columns the
+// file has, so the stock levels and supplier of a file without them stay.
+func TestImportProducts_Unchanged(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	csv := "barcode_id,title,price,description,category,image\n" +
+		"1,Indomie,3500,Mie goreng,Snack,1.jpg\n" +
+		"2,Aqua Besar,4000,Air mineral,Drink,\n"
+	supplierId := uint(2)
+	mockedUtils.On("GetFileNameExtension", "products.csv").Return("csv")
+	mockedUtils.On("GetFileNameExtension", "1.jpg").Return("jpg")
+	mockedRepo.On("RetrieveProductsByBarcodeIdsRepository", []string{"1", "2"}).Return(map[string]entity.Product{
+		"1": {BarcodeId: "1", Title: "Indomie", Price: decimal.RequireFromString("3500.00"), Description: "Mie goreng", Category: "Snack", Image: "1.jpg", MinStock: 10, SupplierId: &supplierId},
+		"2": {BarcodeId: "2", Title: "Aqua", Price: decimal.NewFromInt(4000), Description: "Air mineral", Category: "Drink", MinStock: 5},
+	}, nil)
+	mockedRepo.On("UpsertProductsRepository", []entity.Product{
+		{BarcodeId: "2", Title: "Aqua Besar", Price: decimal.NewFromInt(4000), Description: "Air mineral", Category: "Drink"},
+	}, []string{"category"}).Return(nil)
+
+	result, err := ps.ImportProductsService(t.Context(), dto.ImportProductRequest{File: multipartFile(t, "file", "products.csv", []byte(csv))})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, dto.ImportProductRowResult{Row: 2, BarcodeId: "1", Status: constant.ImportStatusUnchanged}, result.Rows[0])
+	mockedUtils.AssertNotCalled(t, "SaveImage", mock.Anything)
+	mockedRepo.AssertExpectations(t)
+}
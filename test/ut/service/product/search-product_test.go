@@ -3,6 +3,7 @@ package service_test
 import (
 	"errors"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/entity"
 	"tiga-putra-cashier-be/service"
@@ -19,21 +20,23 @@ func TestSearchProduct_Success(t *testing.T) {
 
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
-	barcodeId := "1"
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 	req := dto.SearchProductQuery{
-		BarcodeId: &barcodeId,
+		Query: "indomi",
+		Page:  2,
 	}
-	mockedRepo.On("RetrieveProductForSearch", &req).Return([]entity.Product{
-		{BarcodeId: "1", Title: "Product 1", Image: "img1", Price: decimal.NewFromInt32(1000), Description: "Desc 1"},
+	mockedRepo.On("CountProductsForSearchRepository", &req).Return(int64(14), nil)
+	mockedRepo.On("RetrieveProductForSearch", &req, 12, 12).Return([]entity.Product{
+		{BarcodeId: "1", Title: "Indomie Goreng", Image: "img1", Price: decimal.NewFromInt32(1000), Description: "Desc 1"},
+		{BarcodeId: "2", Title: "Indomie Kari", Image: "img2", Price: decimal.NewFromInt32(1000), Description: "Desc 2"},
 	}, nil)
 
-	result, err := ps.SearchProductService(&req)
+	result, err := ps.SearchProductService(t.Context(), &req)
 
 	assert.Nil(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, len(result), 1)
-	assert.Equal(t, result[0].BarcodeId, "1")
+	assert.Equal(t, len(result.Products), 2)
+	assert.Equal(t, result.Products[0].BarcodeId, "1")
+	assert.Equal(t, dto.PaginationResponse{Page: 2, PrevPage: 1, NextPage: 2, TotalPage: 2, PageSize: 12, TotalItems: 14}, result.PageMetaData)
 	mockedRepo.AssertExpectations(t)
 }
 
@@ -41,18 +44,19 @@ func TestSearchProduct_RetrieveISE(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 	barcodeId := "1"
 	req := dto.SearchProductQuery{
 		BarcodeId: &barcodeId,
 	}
-	mockedRepo.On("RetrieveProductForSearch", &req).Return([]entity.Product{}, errors.New("ISE"))
+	mockedRepo.On("CountProductsForSearchRepository", &req).Return(int64(1), nil)
+	mockedRepo.On("RetrieveProductForSearch", &req, 12, 0).Return([]entity.Product{}, errors.New("ISE"))
 
-	result, err := ps.SearchProductService(&req)
+	result, err := ps.SearchProductService(t.Context(), &req)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
-	assert.Equal(t, len(result), 0)
+	assert.Equal(t, len(result.Products), 0)
 }
 
 func TestSearchProduct_NotFound(t *testing.T) {
@@ -60,17 +64,42 @@ func TestSearchProduct_NotFound(t *testing.T) {
 
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 	barcodeId := "1"
 	req := dto.SearchProductQuery{
 		BarcodeId: &barcodeId,
 	}
-	mockedRepo.On("RetrieveProductForSearch", &req).Return([]entity.Product{}, nil)
+	mockedRepo.On("CountProductsForSearchRepository", &req).Return(int64(0), nil)
 
-	result, err := ps.SearchProductService(&req)
+	result, err := ps.SearchProductService(t.Context(), &req)
 
 	assert.NotNil(t, err)
 	assert.Equal(t, err.Error(), dto.ErrProductsNotFound.Error())
-	assert.Equal(t, len(result), 0)
+	assert.Equal(t, len(result.Products), 0)
 	mockedRepo.AssertExpectations(t)
 }
+
+func TestAutocompleteProduct_Success(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+	mockedRepo.On("RetrieveProductSuggestionsRepository", "ind", 10).Return([]dto.ProductSuggestion(nil), nil)
+
+	result, err := ps.AutocompleteProductService(t.Context(), " ind ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ProductSuggestion{}, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestAutocompleteProduct_BlankTerm(t *testing.T) {
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	result, err := ps.AutocompleteProductService(t.Context(), "  ")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductSuggestionsRepository", "", 10)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mime/multipart"
 	"testing"
+	"tiga-putra-cashier-be/config"
 	"tiga-putra-cashier-be/dto"
 	"tiga-putra-cashier-be/service"
 	testProduct "tiga-putra-cashier-be/test/mocks/product"
@@ -18,7 +19,7 @@ func TestUpdateProduct_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	title := "title-update"
@@ -35,9 +36,7 @@ func TestUpdateProduct_Success(t *testing.T) {
 		Description: &description,
 	}
 
-	newFilename := "generated-1.jpg"
-	pathDir := "assets/image"
-	pathDeletedImage := "assets/image/deleted-1.jpg"
+	newFilename := "generated-1_original.jpg"
 
 	updates := make(map[string]interface{})
 	updates["title"] = *product.Title
@@ -48,12 +47,11 @@ func TestUpdateProduct_Success(t *testing.T) {
 		Image: "deleted-1.jpg",
 	}, true)
 	mockedUtils.On("GetFileNameExtension", product.Image.Filename).Return("jpg")
-	mockedUtils.On("GenerateNewFileName", "jpg").Return("generated-1.jpg")
-	mockedUtils.On("UploadFile", product.Image, newFilename, pathDir).Return(nil)
-	mockedUtils.On("DeleteFile", pathDeletedImage).Return(nil)
-	mockedRepo.On("UpdateProductRepository", &barcodeId, &updates).Return(nil)
+	mockedUtils.On("UploadImage", product.Image).Return(newFilename, nil)
+	update := mockedRepo.On("UpdateProductRepository", &barcodeId, &updates, (*uint)(nil)).Return(nil)
+	mockedUtils.On("DeleteImage", "deleted-1.jpg").Return(nil).NotBefore(update)
 
-	err := ps.UpdateProductService(barcodeId, product)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
 	assert.Nil(t, err)
 	mockedRepo.AssertExpectations(t)
@@ -64,41 +62,50 @@ func TestUpdateProduct_NoChanges(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	product := dto.UpdateProductRequest{}
-	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
 
-	err := ps.UpdateProductService(barcodeId, product)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrNoChangesRequest)
-	mockedRepo.AssertExpectations(t)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductByBarcodeId", &barcodeId)
 }
 
 func TestUpdateProduct_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
-	product := dto.UpdateProductRequest{}
+	product := dto.UpdateProductRequest{
+		Image: &multipart.FileHeader{
+			Filename: "img-update.jpg",
+			Size:     1000,
+		},
+	}
+	mockedUtils.On("GetFileNameExtension", product.Image.Filename).Return("jpg")
+	mockedUtils.On("UploadImage", product.Image).Return("generated-1_original.jpg", nil)
 	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
+	mockedUtils.On("DeleteImage", "generated-1_original.jpg").Return(nil)
 
-	err := ps.UpdateProductService(barcodeId, product)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
+	// The image was uploaded before the lookup, so it is removed again.
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrProductDoesntExist)
 	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
 }
 
 func TestUpdateProduct_BadRequestExtension(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	product := dto.UpdateProductRequest{
@@ -107,14 +114,13 @@ func TestUpdateProduct_BadRequestExtension(t *testing.T) {
 			Size:     1000,
 		},
 	}
-	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
 	mockedUtils.On("GetFileNameExtension", product.Image.Filename).Return("webp")
 
-	err := ps.UpdateProductService(barcodeId, product)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrWrongFileExtension)
-	mockedRepo.AssertExpectations(t)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductByBarcodeId", &barcodeId)
 	mockedUtils.AssertExpectations(t)
 }
 
@@ -122,7 +128,7 @@ func TestUpdateProduct_BadRequestSize(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	product := dto.UpdateProductRequest{
@@ -131,14 +137,13 @@ func TestUpdateProduct_BadRequestSize(t *testing.T) {
 			Size:     7 * 1024 * 1024,
 		},
 	}
-	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
 	mockedUtils.On("GetFileNameExtension", product.Image.Filename).Return("jpg")
 
-	err := ps.UpdateProductService(barcodeId, product)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrLimitSizeExceeded)
-	mockedRepo.AssertExpectations(t)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductByBarcodeId", &barcodeId)
 	mockedUtils.AssertExpectations(t)
 }
 
@@ -146,7 +151,7 @@ func TestUpdateProduct_ISESaveFile(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	product := dto.UpdateProductRequest{
@@ -156,19 +161,14 @@ func TestUpdateProduct_ISESaveFile(t *testing.T) {
 		},
 	}
 
-	newFilename := "generated-1.jpg"
-	pathDir := "assets/image"
-
-	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
 	mockedUtils.On("GetFileNameExtension", product.Image.Filename).Return("jpg")
-	mockedUtils.On("GenerateNewFileName", "jpg").Return("generated-1.jpg")
-	mockedUtils.On("UploadFile", product.Image, newFilename, pathDir).Return(dto.ErrToSaveFile)
+	mockedUtils.On("UploadImage", product.Image).Return("", dto.ErrToSaveFile)
 
-	err := ps.UpdateProductService(barcodeId, product)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, dto.ErrToSaveFile)
-	mockedRepo.AssertExpectations(t)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductByBarcodeId", &barcodeId)
 	mockedUtils.AssertExpectations(t)
 }
 
@@ -176,7 +176,40 @@ func TestUpdateProduct_ISEDeleteFile(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	barcodeId := "1"
+	product := dto.UpdateProductRequest{
+		Image: &multipart.FileHeader{
+			Filename: "img-update.jpg",
+			Size:     1000,
+		},
+	}
+
+	newFilename := "generated-1_original.jpg"
+	updates := map[string]interface{}{"image": newFilename}
+
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{
+		Image: "deleted-1.jpg",
+	}, true)
+	mockedUtils.On("GetFileNameExtension", product.Image.Filename).Return("jpg")
+	mockedUtils.On("UploadImage", product.Image).Return(newFilename, nil)
+	mockedRepo.On("UpdateProductRepository", &barcodeId, &updates, (*uint)(nil)).Return(nil)
+	mockedUtils.On("DeleteImage", "deleted-1.jpg").Return(errors.New("ISE"))
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
+
+	// The product already points at the new image, the old one is left for
+	// the image garbage collection.
+	assert.NoError(t, err)
+	mockedRepo.AssertExpectations(t)
+	mockedUtils.AssertExpectations(t)
+}
+
+func TestUpdateProduct_ISEUpdateWithImage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	product := dto.UpdateProductRequest{
@@ -186,30 +219,30 @@ func TestUpdateProduct_ISEDeleteFile(t *testing.T) {
 		},
 	}
 
-	newFilename := "generated-1.jpg"
-	pathDir := "assets/image"
-	pathDeletedImage := "assets/image/deleted-1.jpg"
+	newFilename := "generated-1_original.jpg"
+	updates := map[string]interface{}{"image": newFilename}
 
 	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{
 		Image: "deleted-1.jpg",
 	}, true)
 	mockedUtils.On("GetFileNameExtension", product.Image.Filename).Return("jpg")
-	mockedUtils.On("GenerateNewFileName", "jpg").Return("generated-1.jpg")
-	mockedUtils.On("UploadFile", product.Image, newFilename, pathDir).Return(nil)
-	mockedUtils.On("DeleteFile", pathDeletedImage).Return(errors.New("ISE"))
-	err := ps.UpdateProductService(barcodeId, product)
+	mockedUtils.On("UploadImage", product.Image).Return(newFilename, nil)
+	mockedRepo.On("UpdateProductRepository", &barcodeId, &updates, (*uint)(nil)).Return(errors.New("ISE"))
+	mockedUtils.On("DeleteImage", newFilename).Return(nil)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
 	mockedRepo.AssertExpectations(t)
 	mockedUtils.AssertExpectations(t)
+	mockedUtils.AssertNotCalled(t, "DeleteImage", "deleted-1.jpg")
 }
 
 func TestUpdateProduct_ISEUpdateProduct(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockedRepo := new(testProduct.MockProductRepository)
 	mockedUtils := new(testUtils.MockFileManagement)
-	ps := service.NewProductService(mockedRepo, mockedUtils)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
 
 	barcodeId := "1"
 	title := "title-update"
@@ -219,12 +252,78 @@ func TestUpdateProduct_ISEUpdateProduct(t *testing.T) {
 	updates := make(map[string]interface{})
 	updates["title"] = *product.Title
 	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
-	mockedRepo.On("UpdateProductRepository", &barcodeId, &updates).Return(errors.New("ISE"))
+	mockedRepo.On("UpdateProductRepository", &barcodeId, &updates, (*uint)(nil)).Return(errors.New("ISE"))
 
-	err := ps.UpdateProductService(barcodeId, product)
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "ISE")
 	mockedRepo.AssertExpectations(t)
 	mockedUtils.AssertExpectations(t)
 }
+
+func TestUpdateProduct_SuccessStockLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	barcodeId := "1"
+	minStock := int64(10)
+	reorderQuantity := int64(40)
+	supplierId := uint(2)
+	product := dto.UpdateProductRequest{
+		MinStock:        &minStock,
+		ReorderQuantity: &reorderQuantity,
+		SupplierId:      &supplierId,
+	}
+
+	updates := map[string]interface{}{
+		"min_stock":        minStock,
+		"reorder_quantity": reorderQuantity,
+		"supplier_id":      supplierId,
+	}
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
+	mockedRepo.On("UpdateProductRepository", &barcodeId, &updates, (*uint)(nil)).Return(nil)
+
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, nil)
+
+	assert.Nil(t, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestUpdateProduct_BadRequestNegativeStockLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	barcodeId := "1"
+	minStock := int64(-1)
+
+	err := ps.UpdateProductService(t.Context(), barcodeId, dto.UpdateProductRequest{MinStock: &minStock}, nil)
+
+	assert.Equal(t, dto.ErrInvalidStockLevel, err)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductByBarcodeId", &barcodeId)
+}
+
+func TestUpdateProduct_VersionMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockedRepo := new(testProduct.MockProductRepository)
+	mockedUtils := new(testUtils.MockFileManagement)
+	ps := service.NewProductService(mockedRepo, mockedUtils, config.Default())
+
+	barcodeId := "1"
+	title := "title-update"
+	version := uint(1)
+	product := dto.UpdateProductRequest{
+		Title: &title,
+	}
+	mockedRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{Version: 2}, true)
+
+	err := ps.UpdateProductService(t.Context(), barcodeId, product, &version)
+
+	assert.Equal(t, dto.ErrVersionMismatch, err)
+	mockedRepo.AssertExpectations(t)
+	mockedRepo.AssertNotCalled(t, "UpdateProductRepository")
+}
@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"bytes"
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	testReport "tiga-putra-cashier-be/test/mocks/report"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSalesSummary_DefaultPeriod(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	filter := dto.ReportQuery{From: "2025-01-01", To: "2025-01-31"}
+	totals := []dto.SalesPeriodTotal{{Period: "2025-01-01", Transactions: 3, Revenue: decimal.NewFromInt(45000), AverageTicket: decimal.NewFromInt(15000)}}
+	mockedRepo.On("RetrieveSalesByPeriodRepository", filter, constant.ReportPeriodDay).Return(totals, nil)
+
+	result, err := rs.GetSalesSummaryService(t.Context(), dto.SalesSummaryQuery{ReportQuery: filter})
+
+	assert.NoError(t, err)
+	assert.Equal(t, totals, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetSalesSummary_InvalidRange(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	_, err := rs.GetSalesSummaryService(t.Context(), dto.SalesSummaryQuery{ReportQuery: dto.ReportQuery{From: "2025-02-01", To: "2025-01-31"}})
+
+	assert.Equal(t, dto.ErrInvalidReportRange, err)
+	mockedRepo.AssertNotCalled(t, "RetrieveSalesByPeriodRepository", mock.Anything, mock.Anything)
+}
+
+func TestGetHourlySales_InvalidDate(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	_, err := rs.GetHourlySalesService(t.Context(), dto.ReportQuery{From: "01-01-2025", To: "2025-01-31"})
+
+	assert.Equal(t, dto.ErrInvalidReportRange, err)
+}
+
+func TestGetCashierSales_Empty(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	filter := dto.ReportQuery{From: "2025-01-01", To: "2025-01-01", StoreId: 2}
+	mockedRepo.On("RetrieveSalesByCashierRepository", filter).Return([]dto.CashierSales(nil), nil)
+
+	result, err := rs.GetCashierSalesService(t.Context(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.CashierSales{}, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetCategorySales_Error(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	filter := dto.ReportQuery{From: "2025-01-01", To: "2025-01-31"}
+	mockedRepo.On("RetrieveSalesByCategoryRepository", filter).Return([]dto.CategorySales(nil), dto.ErrISEReport)
+
+	_, err := rs.GetCategorySalesService(t.Context(), filter)
+
+	assert.Equal(t, dto.ErrISEReport, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetTopProducts_Defaults(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	filter := dto.ReportQuery{From: "2025-01-01", To: "2025-01-31"}
+	products := []dto.ProductSales{{BarcodeId: "1", Title: "Indomie", Quantity: 40, Revenue: decimal.NewFromInt(140000)}}
+	mockedRepo.On("RetrieveTopProductsRepository", filter, constant.ReportRankByRevenue, dto.DEFAULT_TOP_PRODUCTS_LIMIT).Return(products, nil)
+
+	result, err := rs.GetTopProductsService(t.Context(), dto.TopProductsQuery{ReportQuery: filter})
+
+	assert.NoError(t, err)
+	assert.Equal(t, products, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetTopProducts_ByQuantity(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	filter := dto.ReportQuery{From: "2025-01-01", To: "2025-01-31"}
+	mockedRepo.On("RetrieveTopProductsRepository", filter, constant.ReportRankByQuantity, uint16(5)).Return([]dto.ProductSales{}, nil)
+
+	_, err := rs.GetTopProductsService(t.Context(), dto.TopProductsQuery{ReportQuery: filter, By: constant.ReportRankByQuantity, Limit: 5})
+
+	assert.NoError(t, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetStockValuation_Success(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	valuations := []dto.StockValuation{
+		{StoreId: 1, StoreName: "Main Store", BarcodeId: "1", Title: "Indomie", Quantity: 10, UnitPrice: decimal.NewFromInt(3500), Value: decimal.NewFromInt(35000)},
+		{StoreId: 1, StoreName: "Main Store", BarcodeId: "2", Title: "Aqua", Quantity: 4, UnitPrice: decimal.NewFromInt(4000), Value: decimal.NewFromInt(16000)},
+	}
+	mockedRepo.On("StreamStockValuationRepository", uint(1), mock.Anything).Return(valuations, nil)
+
+	result, err := rs.GetStockValuationService(t.Context(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, valuations, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestExportSalesSummary_CSV(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	filter := dto.ReportQuery{From: "2025-01-01", To: "2025-01-02"}
+	totals := []dto.SalesPeriodTotal{
+		{Period: "2025-01-01", Transactions: 3, Revenue: decimal.NewFromInt(45000), AverageTicket: decimal.NewFromInt(15000)},
+		{Period: "2025-01-02", Transactions: 1, Revenue: decimal.NewFromInt(8000), AverageTicket: decimal.NewFromInt(8000)},
+	}
+	mockedRepo.On("RetrieveSalesByPeriodRepository", filter, constant.ReportPeriodDay).Return(totals, nil)
+
+	var buf bytes.Buffer
+	w, _ := utils.NewExportWriter(constant.ExportFormatCSV, "Sales Summary", &buf)
+	err := rs.ExportSalesSummaryService(t.Context(), dto.SalesSummaryQuery{ReportQuery: filter}, w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Period,Transactions,Revenue,Average Ticket\n2025-01-01,3,45000,15000\n2025-01-02,1,8000,8000\n", buf.String())
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestExportTopProducts_InvalidRange(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	var buf bytes.Buffer
+	w, _ := utils.NewExportWriter(constant.ExportFormatCSV, "Top Products", &buf)
+	err := rs.ExportTopProductsService(t.Context(), dto.TopProductsQuery{ReportQuery: dto.ReportQuery{From: "2025-02-01", To: "2025-01-01"}}, w)
+	w.Discard()
+
+	assert.Equal(t, dto.ErrInvalidReportRange, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestExportStockValuation_CSV(t *testing.T) {
+	mockedRepo := new(testReport.MockReportRepository)
+	rs := service.NewReportService(mockedRepo)
+
+	valuations := []dto.StockValuation{
+		{StoreId: 2, StoreName: "Branch", BarcodeId: "1", Title: "Indomie", Quantity: 10, UnitPrice: decimal.NewFromInt(3500), Value: decimal.NewFromInt(35000)},
+	}
+	mockedRepo.On("StreamStockValuationRepository", uint(2), mock.Anything).Return(valuations, nil)
+
+	var buf bytes.Buffer
+	w, _ := utils.NewExportWriter(constant.ExportFormatCSV, "Stock Valuation", &buf)
+	err := rs.ExportStockValuationService(t.Context(), 2, w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Store,Barcode,Title,Quantity,Unit Price,Value\nBranch,1,Indomie,10,3500,35000\n", buf.String())
+	mockedRepo.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproveStockCount_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	variances := []dto.StockVariance{{BarcodeId: "1", SystemQuantity: 10, CountedQuantity: 8, Variance: -2}}
+	mockedRepo.On("ApproveSessionRepository", uint(1), "supervisor", true).Return(variances, nil)
+
+	result, err := ss.ApproveStockCountService(t.Context(), 1, dto.ApproveStockCountRequest{ApprovedBy: "supervisor", IncludeUncounted: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, variances, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestApproveStockCount_Uncounted(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	mockedRepo.On("ApproveSessionRepository", uint(1), "supervisor", false).Return([]dto.StockVariance{}, dto.ErrUncountedProducts)
+
+	_, err := ss.ApproveStockCountService(t.Context(), 1, dto.ApproveStockCountRequest{ApprovedBy: "supervisor"})
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrUncountedProducts, err)
+	mockedRepo.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetStockCountReport_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	startedAt := time.Date(2025, 1, 31, 20, 0, 0, 0, time.UTC)
+	session := entity.StockCountSession{Model: gorm.Model{ID: 1}, Status: constant.StockCountOpen, StartedAt: startedAt}
+	variances := []dto.StockVariance{{BarcodeId: "1", SystemQuantity: 10, CountedQuantity: 8, Variance: -2}}
+	uncounted := []dto.UncountedProduct{{BarcodeId: "2", SystemQuantity: 5}}
+	mockedRepo.On("RetrieveSessionByIdRepository", uint(1)).Return(session, true)
+	mockedRepo.On("RetrieveVariancesRepository", session).Return(variances, nil)
+	mockedRepo.On("RetrieveUncountedProductsRepository", session).Return(uncounted, nil)
+
+	report, err := ss.GetStockCountReportService(t.Context(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), report.Session.Id)
+	assert.Equal(t, variances, report.Variances)
+	assert.Equal(t, uncounted, report.Uncounted)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetStockCountReport_NotFound(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	mockedRepo.On("RetrieveSessionByIdRepository", uint(1)).Return(entity.StockCountSession{}, false)
+
+	_, err := ss.GetStockCountReportService(t.Context(), 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrStockCountNotFound, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetStockCountReport_VarianceError(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	startedAt := time.Date(2025, 1, 31, 20, 0, 0, 0, time.UTC)
+	session := entity.StockCountSession{Model: gorm.Model{ID: 1}, StoreId: 1, StartedAt: startedAt}
+	mockedRepo.On("RetrieveSessionByIdRepository", uint(1)).Return(session, true)
+	mockedRepo.On("RetrieveVariancesRepository", session).Return([]dto.StockVariance{}, dto.ErrISEStockCount)
+
+	_, err := ss.GetStockCountReportService(t.Context(), 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrISEStockCount, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetUncountedProducts_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	startedAt := time.Date(2025, 1, 31, 20, 0, 0, 0, time.UTC)
+	uncounted := []dto.UncountedProduct{{BarcodeId: "2", SystemQuantity: 5}}
+	session := entity.StockCountSession{Model: gorm.Model{ID: 1}, StoreId: 1, StartedAt: startedAt}
+	mockedRepo.On("RetrieveSessionByIdRepository", uint(1)).Return(session, true)
+	mockedRepo.On("RetrieveUncountedProductsRepository", session).Return(uncounted, nil)
+
+	result, err := ss.GetUncountedProductsService(t.Context(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uncounted, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetUncountedProducts_NotFound(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	mockedRepo.On("RetrieveSessionByIdRepository", uint(1)).Return(entity.StockCountSession{}, false)
+
+	_, err := ss.GetUncountedProductsService(t.Context(), 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrStockCountNotFound, err)
+	mockedRepo.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/service"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetReorderSuggestion_GroupBySupplier(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	supplierId := uint(7)
+	candidates := []dto.ReorderCandidate{
+		{
+			LowStockProduct: dto.LowStockProduct{BarcodeId: "1", Title: "Indomie", CurrentStock: 5, MinStock: 10, ReorderQuantity: 12, SupplierId: &supplierId},
+			SupplierName:    "Wings", LeadTimeDays: 3, SoldQuantity: 300,
+		},
+		{
+			LowStockProduct: dto.LowStockProduct{BarcodeId: "2", Title: "Teh Botol", CurrentStock: 2, MinStock: 6, ReorderQuantity: 24, SupplierId: &supplierId},
+			SupplierName:    "Wings", LeadTimeDays: 3, SoldQuantity: 0,
+		},
+		{
+			LowStockProduct: dto.LowStockProduct{BarcodeId: "3", Title: "Sabun", CurrentStock: 0, MinStock: 2},
+			SoldQuantity:    0,
+		},
+	}
+	mockedRepo.On("RetrieveReorderCandidatesRepository", uint(1), mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) > 29*24*time.Hour && time.Since(since) < 31*24*time.Hour
+	})).Return(candidates, nil)
+
+	suggestion, err := ss.GetReorderSuggestionService(t.Context(), dto.ReorderSuggestionQuery{WindowDays: 30})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(30), suggestion.WindowDays)
+	assert.Equal(t, uint(1), suggestion.StoreId)
+	assert.Len(t, suggestion.PurchaseOrders, 2)
+
+	wings := suggestion.PurchaseOrders[0]
+	assert.Equal(t, &supplierId, wings.SupplierId)
+	assert.Len(t, wings.Lines, 2)
+	// 10 a day for 3 days on top of the minimum of 10, minus 5 in stock
+	assert.Equal(t, int64(35), wings.Lines[0].SuggestedQuantity)
+	assert.True(t, decimal.NewFromInt(10).Equal(wings.Lines[0].AverageDailySales))
+	assert.Equal(t, int64(24), wings.Lines[1].SuggestedQuantity)
+
+	unassigned := suggestion.PurchaseOrders[1]
+	assert.Nil(t, unassigned.SupplierId)
+	assert.Equal(t, int64(2), unassigned.Lines[0].SuggestedQuantity)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetReorderSuggestion_DefaultWindow(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	mockedRepo.On("RetrieveReorderCandidatesRepository", uint(1), mock.Anything).Return([]dto.ReorderCandidate{}, nil)
+
+	suggestion, err := ss.GetReorderSuggestionService(t.Context(), dto.ReorderSuggestionQuery{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.DEFAULT_REORDER_SUGGESTION_WINDOW_DAYS, suggestion.WindowDays)
+	assert.Empty(t, suggestion.PurchaseOrders)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetReorderSuggestion_Error(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	mockedRepo.On("RetrieveReorderCandidatesRepository", uint(1), mock.Anything).Return([]dto.ReorderCandidate{}, dto.ErrISEStock)
+
+	_, err := ss.GetReorderSuggestionService(t.Context(), dto.ReorderSuggestionQuery{WindowDays: 7})
+
+	assert.Equal(t, dto.ErrISEStock, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetLowStockProducts_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	products := []dto.LowStockProduct{{BarcodeId: "1", CurrentStock: 1, MinStock: 5}}
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return(products, nil)
+
+	result, err := ss.GetLowStockProductsService(t.Context(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, products, result)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetLowStockProducts_Error(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	mockedRepo.On("RetrieveLowStockProductsRepository", uint(0)).Return([]dto.LowStockProduct{}, dto.ErrISEStock)
+
+	_, err := ss.GetLowStockProductsService(t.Context(), 0)
+
+	assert.Equal(t, dto.ErrISEStock, err)
+	mockedRepo.AssertExpectations(t)
+}
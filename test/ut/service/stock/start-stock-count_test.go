@@ -0,0 +1,57 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStartStockCount_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	mockedRepo.On("RetrieveOpenSessionRepository", uint(1)).Return(entity.StockCountSession{}, false)
+	mockedRepo.On("CreateSessionRepository", mock.MatchedBy(func(session *entity.StockCountSession) bool {
+		return session.Status == constant.StockCountOpen && session.Note == "monthly" && !session.StartedAt.IsZero()
+	})).Return(nil)
+
+	session, err := ss.StartStockCountService(t.Context(), dto.StartStockCountRequest{Note: "monthly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.StockCountOpen, session.Status)
+	assert.Equal(t, "monthly", session.Note)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestStartStockCount_AlreadyOpen(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	mockedRepo.On("RetrieveOpenSessionRepository", uint(1)).Return(entity.StockCountSession{Status: constant.StockCountOpen}, true)
+
+	_, err := ss.StartStockCountService(t.Context(), dto.StartStockCountRequest{})
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrStockCountAlreadyOpen, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestStartStockCount_ISE(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	mockedRepo.On("RetrieveOpenSessionRepository", uint(1)).Return(entity.StockCountSession{}, false)
+	mockedRepo.On("CreateSessionRepository", mock.Anything).Return(dto.ErrISEStockCount)
+
+	_, err := ss.StartStockCountService(t.Context(), dto.StartStockCountRequest{})
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrISEStockCount, err)
+	mockedRepo.AssertExpectations(t)
+}
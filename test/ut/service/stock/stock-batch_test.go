@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReceiveStock_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	expiryDate := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	mockedRepo.On("ReceiveStockRepository", &entity.StockBatch{
+		BarcodeId:         "1",
+		BatchNumber:       "LOT-1",
+		ExpiryDate:        &expiryDate,
+		ReceivedQuantity:  10,
+		RemainingQuantity: 10,
+		StoreId:           1,
+	}, "PO-1").Return(nil)
+
+	batch, err := ss.ReceiveStockService(t.Context(), dto.ReceiveStockRequest{
+		BarcodeId:   "1",
+		BatchNumber: "LOT-1",
+		ExpiryDate:  "2026-12-01",
+		Quantity:    10,
+		Reference:   "PO-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expiryDate, *batch.ExpiryDate)
+	assert.Equal(t, int64(10), batch.RemainingQuantity)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestReceiveStock_InvalidExpiryDate(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	_, err := ss.ReceiveStockService(t.Context(), dto.ReceiveStockRequest{BarcodeId: "1", ExpiryDate: "01/12/2026", Quantity: 10})
+
+	assert.Equal(t, dto.ErrInvalidExpiryDate, err)
+	mockedRepo.AssertNotCalled(t, "ReceiveStockRepository", mock.Anything, mock.Anything)
+}
+
+func TestReceiveStock_ProductNotFound(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	mockedRepo.On("ReceiveStockRepository", mock.Anything, "").Return(dto.ErrProductDoesntExist)
+
+	_, err := ss.ReceiveStockService(t.Context(), dto.ReceiveStockRequest{BarcodeId: "1", Quantity: 10})
+
+	assert.Equal(t, dto.ErrProductDoesntExist, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetExpiringBatches_DefaultWindow(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	mockedRepo.On("RetrieveExpiringBatchesRepository", uint(0), dto.DEFAULT_EXPIRING_WITHIN_DAYS).Return([]dto.ExpiringBatch(nil), nil)
+
+	batches, err := ss.GetExpiringBatchesService(t.Context(), dto.ExpiringBatchQuery{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ExpiringBatch{}, batches)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestWriteOffBatch_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	mockedRepo.On("WriteOffBatchRepository", uint(4), "expired").Return(entity.StockBatch{BarcodeId: "1", ReceivedQuantity: 10}, nil)
+
+	batch, err := ss.WriteOffBatchService(t.Context(), 4, dto.WriteOffBatchRequest{Note: "expired"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), batch.RemainingQuantity)
+	mockedRepo.AssertExpectations(t)
+}
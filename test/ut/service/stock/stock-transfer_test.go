@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateStockTransfer_Success(t *testing.T) {
+	mockedRepo := new(testStock.MockStockTransferRepository)
+	ss := service.NewStockTransferService(mockedRepo)
+
+	mockedRepo.On("CreateTransferRepository", mock.MatchedBy(func(transfer *entity.StockTransfer) bool {
+		return transfer.FromStoreId == 1 && transfer.ToStoreId == 2 && transfer.Status == constant.TransferInTransit
+	}), map[string]int64{"1": 5, "2": 1}).Return(nil)
+
+	transfer, err := ss.CreateStockTransferService(t.Context(), dto.CreateStockTransferRequest{
+		FromStoreId: 1,
+		ToStoreId:   2,
+		CreatedBy:   "staff-1",
+		Items: []dto.StockTransferItemRequest{
+			{BarcodeId: "1", Quantity: 2},
+			{BarcodeId: "2", Quantity: 1},
+			{BarcodeId: "1", Quantity: 3},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.TransferInTransit, transfer.Status)
+	assert.Equal(t, []dto.StockTransferItem{}, transfer.Items)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestCreateStockTransfer_SameStore(t *testing.T) {
+	mockedRepo := new(testStock.MockStockTransferRepository)
+	ss := service.NewStockTransferService(mockedRepo)
+
+	_, err := ss.CreateStockTransferService(t.Context(), dto.CreateStockTransferRequest{FromStoreId: 1, ToStoreId: 1})
+
+	assert.Equal(t, dto.ErrSameStoreTransfer, err)
+	mockedRepo.AssertNotCalled(t, "CreateTransferRepository", mock.Anything, mock.Anything)
+}
+
+func TestGetStockTransferDetail_NotFound(t *testing.T) {
+	mockedRepo := new(testStock.MockStockTransferRepository)
+	ss := service.NewStockTransferService(mockedRepo)
+
+	mockedRepo.On("RetrieveTransferByIdRepository", uint(3)).Return(entity.StockTransfer{}, false)
+
+	_, err := ss.GetStockTransferDetailService(t.Context(), 3)
+
+	assert.Equal(t, dto.ErrTransferNotFound, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestReceiveStockTransfer_NotInTransit(t *testing.T) {
+	mockedRepo := new(testStock.MockStockTransferRepository)
+	ss := service.NewStockTransferService(mockedRepo)
+
+	mockedRepo.On("ReceiveTransferRepository", uint(3), "staff-2").Return(entity.StockTransfer{}, dto.ErrTransferNotInTransit)
+
+	_, err := ss.ReceiveStockTransferService(t.Context(), 3, dto.ReceiveStockTransferRequest{ReceivedBy: "staff-2"})
+
+	assert.Equal(t, dto.ErrTransferNotInTransit, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetStockLevels_Empty(t *testing.T) {
+	mockedRepo := new(testStock.MockStockRepository)
+	ss := service.NewStockService(mockedRepo)
+
+	mockedRepo.On("RetrieveStockLevelsRepository", "1").Return([]dto.StoreStockLevel(nil), nil)
+
+	levels, err := ss.GetStockLevelsService(t.Context(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.StoreStockLevel{}, levels)
+	mockedRepo.AssertExpectations(t)
+}
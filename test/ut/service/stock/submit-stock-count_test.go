@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testStock "tiga-putra-cashier-be/test/mocks/stock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitStockCount_SuccessMergeDuplicates(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	req := dto.SubmitStockCountRequest{
+		DeviceId:  "till-1",
+		CountedBy: "staff-1",
+		Items: []dto.StockCountItemRequest{
+			{BarcodeId: "1", Quantity: 4},
+			{BarcodeId: "2", Quantity: 0},
+			{BarcodeId: "1", Quantity: 6},
+		},
+	}
+	mockedRepo.On("RetrieveExistingBarcodesRepository", []string{"1", "2"}).Return([]string{"1", "2"}, nil)
+	mockedRepo.On("UpsertCountItemsRepository", uint(1), []entity.StockCountItem{
+		{SessionId: 1, BarcodeId: "1", DeviceId: "till-1", CountedBy: "staff-1", CountedQuantity: 10},
+		{SessionId: 1, BarcodeId: "2", DeviceId: "till-1", CountedBy: "staff-1", CountedQuantity: 0},
+	}).Return(nil)
+
+	err := ss.SubmitStockCountService(t.Context(), 1, req)
+
+	assert.NoError(t, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestSubmitStockCount_UnknownBarcode(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	req := dto.SubmitStockCountRequest{
+		DeviceId:  "till-1",
+		CountedBy: "staff-1",
+		Items:     []dto.StockCountItemRequest{{BarcodeId: "1", Quantity: 4}, {BarcodeId: "404", Quantity: 1}},
+	}
+	mockedRepo.On("RetrieveExistingBarcodesRepository", []string{"1", "404"}).Return([]string{"1"}, nil)
+
+	err := ss.SubmitStockCountService(t.Context(), 1, req)
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrUnknownBarcode, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestSubmitStockCount_RetrieveBarcodeError(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	req := dto.SubmitStockCountRequest{
+		DeviceId:  "till-1",
+		CountedBy: "staff-1",
+		Items:     []dto.StockCountItemRequest{{BarcodeId: "1", Quantity: 4}},
+	}
+	mockedRepo.On("RetrieveExistingBarcodesRepository", []string{"1"}).Return([]string{}, dto.ErrISEStockCount)
+
+	err := ss.SubmitStockCountService(t.Context(), 1, req)
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrISEStockCount, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestSubmitStockCount_SessionNotOpen(t *testing.T) {
+	mockedRepo := new(testStock.MockStockCountRepository)
+	ss := service.NewStockCountService(mockedRepo)
+
+	req := dto.SubmitStockCountRequest{
+		DeviceId:  "till-1",
+		CountedBy: "staff-1",
+		Items:     []dto.StockCountItemRequest{{BarcodeId: "1", Quantity: 4}},
+	}
+	mockedRepo.On("RetrieveExistingBarcodesRepository", []string{"1"}).Return([]string{"1"}, nil)
+	mockedRepo.On("UpsertCountItemsRepository", uint(1), []entity.StockCountItem{
+		{SessionId: 1, BarcodeId: "1", DeviceId: "till-1", CountedBy: "staff-1", CountedQuantity: 4},
+	}).Return(dto.ErrStockCountNotOpen)
+
+	err := ss.SubmitStockCountService(t.Context(), 1, req)
+
+	assert.Error(t, err)
+	assert.Equal(t, dto.ErrStockCountNotOpen, err)
+	mockedRepo.AssertExpectations(t)
+}
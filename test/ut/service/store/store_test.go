@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testStore "tiga-putra-cashier-be/test/mocks/store"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetStores_Success(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	mockedRepo.On("RetrieveStoresRepository").Return([]entity.Store{
+		{Model: gorm.Model{ID: 1}, Code: "MAIN", Name: "Main Store", Type: constant.StoreTypeStore},
+	}, nil)
+
+	stores, err := ss.GetStoresService(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Store{{Id: 1, Code: "MAIN", Name: "Main Store", Type: constant.StoreTypeStore}}, stores)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestCreateStore_SuccessDefaultType(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	mockedRepo.On("RetrieveStoreByCodeRepository", "BR2").Return(entity.Store{}, false)
+	mockedRepo.On("CreateStoreRepository", &entity.Store{Code: "BR2", Name: "Branch 2", Type: constant.StoreTypeStore}).Return(nil)
+
+	store, err := ss.CreateStoreService(t.Context(), dto.AddStoreRequest{Code: "BR2", Name: "Branch 2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.StoreTypeStore, store.Type)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestCreateStore_InvalidType(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	_, err := ss.CreateStoreService(t.Context(), dto.AddStoreRequest{Code: "BR2", Name: "Branch 2", Type: "kiosk"})
+
+	assert.Equal(t, dto.ErrInvalidStoreType, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestCreateStore_Exist(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	mockedRepo.On("RetrieveStoreByCodeRepository", "MAIN").Return(entity.Store{Code: "MAIN"}, true)
+
+	_, err := ss.CreateStoreService(t.Context(), dto.AddStoreRequest{Code: "MAIN", Name: "Main Store"})
+
+	assert.Equal(t, dto.ErrStoreExist, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestUpdateStore_Success(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	storeType := constant.StoreTypeWarehouse
+	mockedRepo.On("RetrieveStoreByIdRepository", uint(2)).Return(entity.Store{}, true)
+	mockedRepo.On("UpdateStoreRepository", uint(2), mock.MatchedBy(func(updates *map[string]interface{}) bool {
+		return (*updates)["type"] == constant.StoreTypeWarehouse && len(*updates) == 1
+	})).Return(nil)
+
+	err := ss.UpdateStoreService(t.Context(), 2, dto.UpdateStoreRequest{Type: &storeType})
+
+	assert.NoError(t, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestUpdateStore_NoChanges(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	mockedRepo.On("RetrieveStoreByIdRepository", uint(2)).Return(entity.Store{}, true)
+
+	err := ss.UpdateStoreService(t.Context(), 2, dto.UpdateStoreRequest{})
+
+	assert.Equal(t, dto.ErrNoChangesRequest, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetStorePrices_Empty(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	mockedRepo.On("RetrieveStoreByIdRepository", uint(2)).Return(entity.Store{}, true)
+	mockedRepo.On("RetrieveStorePricesRepository", uint(2)).Return([]dto.StorePrice(nil), nil)
+
+	prices, err := ss.GetStorePricesService(t.Context(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.StorePrice{}, prices)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestSetStorePrice_Success(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ss := service.NewStoreService(mockedRepo, mockedProductRepo)
+
+	barcodeId := "1"
+	mockedRepo.On("RetrieveStoreByIdRepository", uint(2)).Return(entity.Store{}, true)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, true)
+	mockedRepo.On("UpsertStorePriceRepository", &entity.StorePrice{StoreId: 2, BarcodeId: "1", Price: decimal.NewFromInt(5500)}).Return(nil)
+
+	err := ss.SetStorePriceService(t.Context(), 2, "1", dto.SetStorePriceRequest{Price: decimal.NewFromInt(5500)})
+
+	assert.NoError(t, err)
+	mockedRepo.AssertExpectations(t)
+	mockedProductRepo.AssertExpectations(t)
+}
+
+func TestSetStorePrice_InvalidPrice(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	ss := service.NewStoreService(mockedRepo, new(testProduct.MockProductRepository))
+
+	err := ss.SetStorePriceService(t.Context(), 2, "1", dto.SetStorePriceRequest{Price: decimal.NewFromInt(-1)})
+
+	assert.Equal(t, dto.ErrInvalidPrice, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestSetStorePrice_ProductNotFound(t *testing.T) {
+	mockedRepo := new(testStore.MockStoreRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ss := service.NewStoreService(mockedRepo, mockedProductRepo)
+
+	barcodeId := "1"
+	mockedRepo.On("RetrieveStoreByIdRepository", uint(2)).Return(entity.Store{}, true)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
+
+	err := ss.SetStorePriceService(t.Context(), 2, "1", dto.SetStorePriceRequest{Price: decimal.NewFromInt(5500)})
+
+	assert.Equal(t, dto.ErrProductDoesntExist, err)
+	mockedRepo.AssertNotCalled(t, "UpsertStorePriceRepository", mock.Anything)
+}
@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testSupplier "tiga-putra-cashier-be/test/mocks/supplier"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetSuppliers_Success(t *testing.T) {
+	mockedRepo := new(testSupplier.MockSupplierRepository)
+	ss := service.NewSupplierService(mockedRepo)
+
+	mockedRepo.On("RetrieveSuppliersRepository").Return([]entity.Supplier{
+		{Model: gorm.Model{ID: 1}, Name: "Wings", LeadTimeDays: 3},
+	}, nil)
+
+	suppliers, err := ss.GetSuppliersService(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Supplier{{Id: 1, Name: "Wings", LeadTimeDays: 3}}, suppliers)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetSuppliers_NotFound(t *testing.T) {
+	mockedRepo := new(testSupplier.MockSupplierRepository)
+	ss := service.NewSupplierService(mockedRepo)
+
+	mockedRepo.On("RetrieveSuppliersRepository").Return([]entity.Supplier{}, nil)
+
+	_, err := ss.GetSuppliersService(t.Context())
+
+	assert.Equal(t, dto.ErrSuppliersNotFound, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestCreateSupplier_Success(t *testing.T) {
+	mockedRepo := new(testSupplier.MockSupplierRepository)
+	ss := service.NewSupplierService(mockedRepo)
+
+	mockedRepo.On("CreateSupplierRepository", &entity.Supplier{Name: "Wings", LeadTimeDays: 3}).Return(nil)
+
+	supplier, err := ss.CreateSupplierService(t.Context(), dto.AddSupplierRequest{Name: "Wings", LeadTimeDays: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Wings", supplier.Name)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestUpdateSupplier_Success(t *testing.T) {
+	mockedRepo := new(testSupplier.MockSupplierRepository)
+	ss := service.NewSupplierService(mockedRepo)
+
+	leadTime := uint(5)
+	mockedRepo.On("RetrieveSupplierByIdRepository", uint(1)).Return(entity.Supplier{}, true)
+	mockedRepo.On("UpdateSupplierRepository", uint(1), mock.MatchedBy(func(updates *map[string]interface{}) bool {
+		return (*updates)["lead_time_days"] == uint(5) && len(*updates) == 1
+	})).Return(nil)
+
+	err := ss.UpdateSupplierService(t.Context(), 1, dto.UpdateSupplierRequest{LeadTimeDays: &leadTime})
+
+	assert.NoError(t, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestUpdateSupplier_NotFound(t *testing.T) {
+	mockedRepo := new(testSupplier.MockSupplierRepository)
+	ss := service.NewSupplierService(mockedRepo)
+
+	mockedRepo.On("RetrieveSupplierByIdRepository", uint(1)).Return(entity.Supplier{}, false)
+
+	err := ss.UpdateSupplierService(t.Context(), 1, dto.UpdateSupplierRequest{})
+
+	assert.Equal(t, dto.ErrSupplierDoesntExist, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestUpdateSupplier_NoChanges(t *testing.T) {
+	mockedRepo := new(testSupplier.MockSupplierRepository)
+	ss := service.NewSupplierService(mockedRepo)
+
+	mockedRepo.On("RetrieveSupplierByIdRepository", uint(1)).Return(entity.Supplier{}, true)
+
+	err := ss.UpdateSupplierService(t.Context(), 1, dto.UpdateSupplierRequest{})
+
+	assert.Equal(t, dto.ErrNoChangesRequest, err)
+	mockedRepo.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	test "tiga-putra-cashier-be/test/mocks/sync"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestSyncProductsService_FromScratch(t *testing.T) {
+	mockedRepo := new(test.MockSyncRepository)
+	ss := service.NewSyncService(mockedRepo, config.Default())
+	deleted := entity.Product{BarcodeId: "2", Title: "Product 2"}
+	deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	mockedRepo.On("RetrieveProductChangesRepository", (*utils.Cursor)(nil), mock.AnythingOfType("time.Time"), 500).Return([]entity.Product{
+		{BarcodeId: "1", Title: "Product 1", Price: decimal.NewFromInt(1000)},
+		deleted,
+	}, &utils.Cursor{Value: "2026-03-01T08:01:00Z", Id: 2}, nil)
+
+	result, err := ss.SyncProductsService(t.Context(), "")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Products, 1)
+	assert.Equal(t, "1", result.Products[0].BarcodeId)
+	assert.Equal(t, []string{"2"}, result.Deleted)
+	assert.False(t, result.HasMore)
+	cursor, err := utils.DecodeCursor(result.NextToken)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.Cursor{Sort: "sync", Value: "2026-03-01T08:01:00Z", Id: 2}, cursor)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestSyncProductsService_ResumesFromToken(t *testing.T) {
+	mockedRepo := new(test.MockSyncRepository)
+	ss := service.NewSyncService(mockedRepo, config.Default())
+	since := utils.EncodeCursor(utils.Cursor{Sort: "sync", Value: "2026-03-01T08:01:00Z", Id: 2})
+	products := make([]entity.Product, 500)
+	mockedRepo.On("RetrieveProductChangesRepository", &utils.Cursor{Sort: "sync", Value: "2026-03-01T08:01:00Z", Id: 2}, mock.AnythingOfType("time.Time"), 500).
+		Return(products, &utils.Cursor{Value: "2026-03-02T10:00:00Z", Id: 900}, nil)
+
+	result, err := ss.SyncProductsService(t.Context(), since)
+
+	assert.NoError(t, err)
+	assert.True(t, result.HasMore)
+	assert.NotEqual(t, since, result.NextToken)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestSyncProductsService_NothingChanged(t *testing.T) {
+	mockedRepo := new(test.MockSyncRepository)
+	ss := service.NewSyncService(mockedRepo, config.Default())
+	since := utils.EncodeCursor(utils.Cursor{Sort: "sync", Value: "2026-03-01T08:01:00Z", Id: 2})
+	mockedRepo.On("RetrieveProductChangesRepository", mock.Anything, mock.Anything, 500).Return([]entity.Product{}, nil, nil)
+
+	result, err := ss.SyncProductsService(t.Context(), since)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Products)
+	assert.Empty(t, result.Deleted)
+	assert.Equal(t, since, result.NextToken)
+	assert.False(t, result.HasMore)
+}
+
+func TestSyncProductsService_InvalidToken(t *testing.T) {
+	mockedRepo := new(test.MockSyncRepository)
+	ss := service.NewSyncService(mockedRepo, config.Default())
+
+	_, err := ss.SyncProductsService(t.Context(), "not-a-token")
+	assert.Equal(t, dto.ErrInvalidSyncToken, err)
+
+	_, err = ss.SyncProductsService(t.Context(), utils.EncodeCursor(utils.Cursor{Sort: "title", Value: "Indomie", Id: 3}))
+	assert.Equal(t, dto.ErrInvalidSyncToken, err)
+	mockedRepo.AssertNotCalled(t, "RetrieveProductChangesRepository")
+}
+
+func TestSyncProductsService_Error(t *testing.T) {
+	mockedRepo := new(test.MockSyncRepository)
+	ss := service.NewSyncService(mockedRepo, config.Default())
+	mockedRepo.On("RetrieveProductChangesRepository", mock.Anything, mock.Anything, 500).Return([]entity.Product{}, nil, dto.ErrISESync)
+
+	_, err := ss.SyncProductsService(t.Context(), "")
+	assert.Equal(t, dto.ErrISESync, err)
+}
+
+func TestSyncProductsService_HoldsBackUncommittedWrites(t *testing.T) {
+	mockedRepo := new(test.MockSyncRepository)
+	cfg := config.Default()
+	cfg.Database.Timeouts.Bulk = 10 * time.Minute
+	ss := service.NewSyncService(mockedRepo, cfg)
+	var upTo time.Time
+	mockedRepo.On("RetrieveProductChangesRepository", (*utils.Cursor)(nil), mock.AnythingOfType("time.Time"), 500).
+		Run(func(args mock.Arguments) { upTo = args.Get(1).(time.Time) }).
+		Return([]entity.Product{}, nil, nil)
+
+	_, err := ss.SyncProductsService(t.Context(), "")
+
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-10*time.Minute-constant.SyncSafetyMargin), upTo, time.Second)
+}
@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testTransaction "tiga-putra-cashier-be/test/mocks/transaction"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTransaction_Success(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	barcodeMilk, barcodeBread := "1", "2"
+	mockedProductRepo.On("RetrieveStorePriceOverridesRepository", uint(1), []string{"1", "2"}).Return(map[string]decimal.Decimal{}, nil)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeMilk).Return(dto.ProductWithoutTimeStamp{BarcodeId: "1", Title: "Milk", Price: decimal.NewFromInt(5000)}, true)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeBread).Return(dto.ProductWithoutTimeStamp{BarcodeId: "2", Title: "Bread", Price: decimal.NewFromInt(12000)}, true)
+	mockedRepo.On("CreateTransactionRepository", &entity.Transaction{
+		Cashier:       "cashier-1",
+		StoreId:       1,
+		PaymentMethod: "cash",
+		Total:         decimal.NewFromInt(27000),
+		Items: []entity.TransactionItem{
+			{BarcodeId: "1", Title: "Milk", Quantity: 3, UnitPrice: decimal.NewFromInt(5000), Subtotal: decimal.NewFromInt(15000)},
+			{BarcodeId: "2", Title: "Bread", Quantity: 1, UnitPrice: decimal.NewFromInt(12000), Subtotal: decimal.NewFromInt(12000)},
+		},
+	}, false).Return(nil)
+
+	transaction, err := ts.CreateTransactionService(t.Context(), dto.CreateTransactionRequest{
+		Cashier:       "cashier-1",
+		PaymentMethod: "cash",
+		Items: []dto.TransactionItemRequest{
+			{BarcodeId: "1", Quantity: 1},
+			{BarcodeId: "2", Quantity: 1},
+			{BarcodeId: "1", Quantity: 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(27000).Equal(transaction.Total))
+	assert.Len(t, transaction.Items, 2)
+	mockedRepo.AssertExpectations(t)
+	mockedProductRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_SuccessStorePrice(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	barcodeId := "1"
+	mockedProductRepo.On("RetrieveStorePriceOverridesRepository", uint(2), []string{"1"}).Return(map[string]decimal.Decimal{"1": decimal.NewFromInt(5500)}, nil)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{BarcodeId: "1", Title: "Milk", Price: decimal.NewFromInt(5000)}, true)
+	mockedRepo.On("CreateTransactionRepository", mock.MatchedBy(func(transaction *entity.Transaction) bool {
+		return transaction.StoreId == 2 && transaction.Items[0].UnitPrice.Equal(decimal.NewFromInt(5500))
+	}), false).Return(nil)
+
+	transaction, err := ts.CreateTransactionService(t.Context(), dto.CreateTransactionRequest{
+		StoreId: 2,
+		Cashier: "cashier-1",
+		Items:   []dto.TransactionItemRequest{{BarcodeId: "1", Quantity: 2}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), transaction.StoreId)
+	assert.True(t, decimal.NewFromInt(11000).Equal(transaction.Total))
+	mockedRepo.AssertExpectations(t)
+	mockedProductRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_StoreNotFound(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	mockedProductRepo.On("RetrieveStorePriceOverridesRepository", uint(9), []string{"1"}).Return(map[string]decimal.Decimal(nil), dto.ErrStoreDoesntExist)
+
+	_, err := ts.CreateTransactionService(t.Context(), dto.CreateTransactionRequest{
+		StoreId: 9,
+		Cashier: "cashier-1",
+		Items:   []dto.TransactionItemRequest{{BarcodeId: "1", Quantity: 1}},
+	})
+
+	assert.Equal(t, dto.ErrStoreDoesntExist, err)
+	mockedRepo.AssertNotCalled(t, "CreateTransactionRepository", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_OverrideApproverRequired(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	_, err := ts.CreateTransactionService(t.Context(), dto.CreateTransactionRequest{
+		Cashier:      "cashier-1",
+		Items:        []dto.TransactionItemRequest{{BarcodeId: "1", Quantity: 1}},
+		AllowExpired: true,
+	})
+
+	assert.Equal(t, dto.ErrOverrideApproverRequired, err)
+	mockedRepo.AssertNotCalled(t, "CreateTransactionRepository", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_ExpiredOverride(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	barcodeId := "1"
+	mockedProductRepo.On("RetrieveStorePriceOverridesRepository", uint(1), []string{"1"}).Return(map[string]decimal.Decimal{}, nil)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{Title: "Milk", Price: decimal.NewFromInt(5000)}, true)
+	mockedRepo.On("CreateTransactionRepository", mock.MatchedBy(func(transaction *entity.Transaction) bool {
+		return transaction.ExpiredOverrideBy == "supervisor"
+	}), true).Return(nil)
+
+	transaction, err := ts.CreateTransactionService(t.Context(), dto.CreateTransactionRequest{
+		Cashier:      "cashier-1",
+		Items:        []dto.TransactionItemRequest{{BarcodeId: "1", Quantity: 1}},
+		AllowExpired: true,
+		OverrideBy:   "supervisor",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "supervisor", transaction.ExpiredOverrideBy)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_ProductNotFound(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	barcodeId := "1"
+	mockedProductRepo.On("RetrieveStorePriceOverridesRepository", uint(1), []string{"1"}).Return(map[string]decimal.Decimal{}, nil)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{}, false)
+
+	_, err := ts.CreateTransactionService(t.Context(), dto.CreateTransactionRequest{
+		Cashier: "cashier-1",
+		Items:   []dto.TransactionItemRequest{{BarcodeId: "1", Quantity: 1}},
+	})
+
+	assert.Equal(t, dto.ErrProductDoesntExist, err)
+	mockedProductRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_ExpiredBatch(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	barcodeId := "1"
+	mockedProductRepo.On("RetrieveStorePriceOverridesRepository", uint(1), []string{"1"}).Return(map[string]decimal.Decimal{}, nil)
+	mockedProductRepo.On("RetrieveProductByBarcodeId", &barcodeId).Return(dto.ProductWithoutTimeStamp{Price: decimal.NewFromInt(5000)}, true)
+	mockedRepo.On("CreateTransactionRepository", mock.Anything, false).Return(dto.ErrExpiredBatch)
+
+	_, err := ts.CreateTransactionService(t.Context(), dto.CreateTransactionRequest{
+		Cashier: "cashier-1",
+		Items:   []dto.TransactionItemRequest{{BarcodeId: "1", Quantity: 1}},
+	})
+
+	assert.Equal(t, dto.ErrExpiredBatch, err)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestGetTransactionDetail_NotFound(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	mockedRepo.On("RetrieveTransactionByIdRepository", uint(1)).Return(entity.Transaction{}, false)
+
+	_, err := ts.GetTransactionDetailService(t.Context(), 1)
+
+	assert.Equal(t, dto.ErrTransactionNotFound, err)
+	mockedRepo.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/entity"
+	"tiga-putra-cashier-be/service"
+	testProduct "tiga-putra-cashier-be/test/mocks/product"
+	testTransaction "tiga-putra-cashier-be/test/mocks/transaction"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var soldAt = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+func newOfflineSale(clientId string, items ...dto.OfflineSaleItemRequest) dto.OfflineSaleRequest {
+	return dto.OfflineSaleRequest{
+		ClientId:      clientId,
+		Cashier:       "cashier-1",
+		PaymentMethod: "cash",
+		SoldAt:        &soldAt,
+		Items:         items,
+	}
+}
+
+func price(value decimal.Decimal) *decimal.Decimal {
+	return &value
+}
+
+func TestUploadOfflineSales_Accepted(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	mockedProductRepo.On("RetrievePricesAtRepository", uint(1), []string{"1"}, soldAt).Return(map[string]dto.ProductPriceAt{
+		"1": {BarcodeId: "1", Title: "Milk", Price: decimal.NewFromInt(5000)},
+	}, nil)
+	mockedRepo.On("CreateOfflineTransactionRepository", mock.MatchedBy(func(transaction *entity.Transaction) bool {
+		return *transaction.ClientId == "sale-1" && transaction.CreatedAt.Equal(soldAt) && transaction.StoreId == 1 &&
+			transaction.Items[0].Title == "Milk" && transaction.Total.Equal(decimal.NewFromInt(10000))
+	}), []entity.SaleConflict(nil)).Run(func(args mock.Arguments) {
+		args.Get(0).(*entity.Transaction).ID = 9
+	}).Return([]entity.SaleConflict(nil), nil)
+
+	results, err := ts.UploadOfflineSalesService(t.Context(), dto.UploadOfflineSalesRequest{
+		Sales: []dto.OfflineSaleRequest{newOfflineSale("sale-1", dto.OfflineSaleItemRequest{BarcodeId: "1", Quantity: 2, UnitPrice: price(decimal.NewFromInt(5000))})},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.OfflineSaleResult{{ClientId: "sale-1", Status: constant.OfflineSaleAccepted, TransactionId: 9}}, results)
+	mockedRepo.AssertExpectations(t)
+	mockedProductRepo.AssertExpectations(t)
+}
+
+func TestUploadOfflineSales_Conflicts(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	mockedProductRepo.On("RetrievePricesAtRepository", uint(1), []string{"1", "2", "3"}, soldAt).Return(map[string]dto.ProductPriceAt{
+		"1": {BarcodeId: "1", Title: "Milk", Price: decimal.NewFromInt(5000)},
+		"2": {BarcodeId: "2", Title: "Bread", Price: decimal.NewFromInt(12000), Deleted: true},
+	}, nil)
+	expected := []entity.SaleConflict{
+		{BarcodeId: "1", Type: constant.ConflictPriceMismatch, Quantity: 1, Detail: "Sold at 4500, store price was 5000"},
+		{BarcodeId: "2", Type: constant.ConflictProductDeleted, Quantity: 1, Detail: "Product has been deleted"},
+		{BarcodeId: "3", Type: constant.ConflictUnknownProduct, Quantity: 1, Detail: dto.ErrProductDoesntExist.Error()},
+	}
+	mockedRepo.On("CreateOfflineTransactionRepository", mock.MatchedBy(func(transaction *entity.Transaction) bool {
+		return transaction.Items[1].Title == "Bread" && transaction.Items[2].Title == "Jam" && transaction.Total.Equal(decimal.NewFromInt(19500))
+	}), expected).Return(expected, nil)
+
+	results, err := ts.UploadOfflineSalesService(t.Context(), dto.UploadOfflineSalesRequest{
+		Sales: []dto.OfflineSaleRequest{newOfflineSale("sale-1",
+			dto.OfflineSaleItemRequest{BarcodeId: "1", Quantity: 1, UnitPrice: price(decimal.NewFromInt(4500))},
+			dto.OfflineSaleItemRequest{BarcodeId: "2", Quantity: 1, UnitPrice: price(decimal.NewFromInt(12000))},
+			dto.OfflineSaleItemRequest{BarcodeId: "3", Title: "Jam", Quantity: 1, UnitPrice: price(decimal.NewFromInt(3000))},
+		)},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.OfflineSaleConflict, results[0].Status)
+	assert.Len(t, results[0].Conflicts, 3)
+	mockedRepo.AssertExpectations(t)
+}
+
+func TestUploadOfflineSales_DuplicateAndFailed(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	mockedProductRepo.On("RetrievePricesAtRepository", uint(1), []string{"1"}, soldAt).Return(map[string]dto.ProductPriceAt{
+		"1": {BarcodeId: "1", Title: "Milk", Price: decimal.NewFromInt(5000)},
+	}, nil)
+	mockedRepo.On("CreateOfflineTransactionRepository", mock.MatchedBy(func(transaction *entity.Transaction) bool {
+		return *transaction.ClientId == "sale-1"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*entity.Transaction).ID = 4
+	}).Return([]entity.SaleConflict(nil), dto.ErrTransactionUploaded)
+	mockedRepo.On("CreateOfflineTransactionRepository", mock.MatchedBy(func(transaction *entity.Transaction) bool {
+		return *transaction.ClientId == "sale-2"
+	}), mock.Anything).Return([]entity.SaleConflict(nil), dto.ErrToCreateTransaction)
+
+	item := dto.OfflineSaleItemRequest{BarcodeId: "1", Quantity: 1, UnitPrice: price(decimal.NewFromInt(5000))}
+	results, err := ts.UploadOfflineSalesService(t.Context(), dto.UploadOfflineSalesRequest{
+		Sales: []dto.OfflineSaleRequest{newOfflineSale("sale-1", item), newOfflineSale("sale-2", item)},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.OfflineSaleResult{
+		{ClientId: "sale-1", Status: constant.OfflineSaleDuplicate, TransactionId: 4},
+		{ClientId: "sale-2", Status: constant.OfflineSaleFailed, Error: dto.ErrToCreateTransaction.Error()},
+	}, results)
+}
+
+func TestUploadOfflineSales_StoreNotFound(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	mockedProductRepo := new(testProduct.MockProductRepository)
+	ts := service.NewTransactionService(mockedRepo, mockedProductRepo)
+
+	mockedProductRepo.On("RetrievePricesAtRepository", uint(5), []string{"1"}, soldAt).Return(map[string]dto.ProductPriceAt{}, nil)
+	mockedRepo.On("CreateOfflineTransactionRepository", mock.Anything, mock.Anything).Return([]entity.SaleConflict(nil), dto.ErrStoreDoesntExist)
+
+	_, err := ts.UploadOfflineSalesService(t.Context(), dto.UploadOfflineSalesRequest{
+		StoreId: 5,
+		Sales:   []dto.OfflineSaleRequest{newOfflineSale("sale-1", dto.OfflineSaleItemRequest{BarcodeId: "1", Quantity: 1, UnitPrice: price(decimal.Zero)})},
+	})
+
+	assert.Equal(t, dto.ErrStoreDoesntExist, err)
+}
+
+func TestResolveSaleConflict_Success(t *testing.T) {
+	mockedRepo := new(testTransaction.MockTransactionRepository)
+	ts := service.NewTransactionService(mockedRepo, new(testProduct.MockProductRepository))
+
+	resolvedAt := time.Now()
+	mockedRepo.On("ResolveSaleConflictRepository", uint(3), "supervisor-1").Return(entity.SaleConflict{
+		TransactionId: 9, Type: constant.ConflictInsufficientStock, ResolvedBy: "supervisor-1", ResolvedAt: &resolvedAt,
+	}, nil)
+
+	conflict, err := ts.ResolveSaleConflictService(t.Context(), 3, dto.ResolveSaleConflictRequest{ResolvedBy: "supervisor-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "supervisor-1", conflict.ResolvedBy)
+	assert.Equal(t, uint(9), conflict.TransactionId)
+}
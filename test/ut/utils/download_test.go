@@ -0,0 +1,57 @@
+package utils_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadFile_NotPublic(t *testing.T) {
+	var requested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		_, _ = w.Write([]byte("jpeg"))
+	}))
+	t.Cleanup(server.Close)
+	fileManagement := utils.FileInit(nil, config.Default())
+
+	for _, url := range []string{
+		server.URL + "/image.jpg",
+		"http://localhost:1/image.jpg",
+		"http://169.254.169.254/latest/meta-data/image.jpg",
+		"http://10.0.0.1/image.jpg",
+		"http://192.168.1.1/image.jpg",
+		"http://100.100.100.200/image.jpg",
+		"http://[::1]:1/image.jpg",
+		"http://[fd00::1]/image.jpg",
+		"http://[::ffff:127.0.0.1]:1/image.jpg",
+	} {
+		_, err := fileManagement.DownloadFile(t.Context(), url)
+		assert.Equal(t, dto.ErrImageURLNotPublic, err, url)
+	}
+	assert.False(t, requested)
+}
+
+func TestDownloadFile_UnsupportedScheme(t *testing.T) {
+	fileManagement := utils.FileInit(nil, config.Default())
+
+	_, err := fileManagement.DownloadFile(t.Context(), "file:///etc/passwd")
+
+	assert.Equal(t, dto.ErrToDownloadImage, err)
+}
+
+func TestDownloadFile_Canceled(t *testing.T) {
+	fileManagement := utils.FileInit(nil, config.Default())
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := fileManagement.DownloadFile(ctx, "http://93.184.215.14/image.jpg")
+
+	assert.Equal(t, dto.ErrToDownloadImage, err)
+}
@@ -0,0 +1,25 @@
+package utils_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	version, ok := utils.ParseIfMatch(utils.ETag(3))
+	assert.True(t, ok)
+	assert.Equal(t, uint(3), *version)
+
+	for _, header := range []string{"", "*"} {
+		version, ok = utils.ParseIfMatch(header)
+		assert.True(t, ok, header)
+		assert.Nil(t, version, header)
+	}
+
+	for _, header := range []string{"3", `"abc"`, `"-1"`, `"`, `W/"7"`} {
+		_, ok = utils.ParseIfMatch(header)
+		assert.False(t, ok, header)
+	}
+}
@@ -0,0 +1,62 @@
+package utils_test
+
+import (
+	"bytes"
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := utils.NewExportWriter(constant.ExportFormatCSV, "Top Products", &buf)
+	assert.NoError(t, err)
+
+	assert.NoError(t, w.WriteHeader("Barcode", "Title", "Quantity", "Revenue"))
+	assert.NoError(t, w.WriteRow("1", "Indomie, Goreng", int64(40), decimal.RequireFromString("140000.50")))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "Barcode,Title,Quantity,Revenue\n1,\"Indomie, Goreng\",40,140000.5\n", buf.String())
+}
+
+func TestExportWriter_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := utils.NewExportWriter(constant.ExportFormatXLSX, "Top Products", &buf)
+	assert.NoError(t, err)
+
+	supplierId := uint(3)
+	assert.NoError(t, w.WriteHeader("Barcode", "Revenue", "Supplier"))
+	assert.NoError(t, w.WriteRow("1", decimal.NewFromInt(140000), &supplierId))
+	assert.NoError(t, w.Close())
+
+	file, err := excelize.OpenReader(&buf)
+	assert.NoError(t, err)
+	rows, err := file.GetRows("Top Products")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Barcode", "Revenue", "Supplier"}, {"1", "140000", "3"}}, rows)
+}
+
+func TestExportWriter_PDF(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := utils.NewExportWriter(constant.ExportFormatPDF, "Top Products", &buf)
+	assert.NoError(t, err)
+
+	assert.NoError(t, w.WriteHeader("Barcode", "Title"))
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, w.WriteRow("1", "Indomie"))
+	}
+	assert.NoError(t, w.Close())
+
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+}
+
+func TestExportWriter_UnsupportedFormat(t *testing.T) {
+	_, err := utils.NewExportWriter("docx", "Top Products", &bytes.Buffer{})
+
+	assert.Equal(t, dto.ErrUnsupportedExportFormat, err)
+}
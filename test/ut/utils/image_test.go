@@ -0,0 +1,161 @@
+package utils_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodedImage(t *testing.T, width, height int, format string) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width/2; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if format == "png" {
+		require.NoError(t, png.Encode(&buf, img))
+	} else {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	}
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment holding only the orientation tag
+// right after the SOI marker of a jpeg.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], 0x0112)
+	binary.BigEndian.PutUint16(entry[2:], 3)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	payload := append(append([]byte("Exif\x00\x00"), tiff...), append(entry, 0, 0, 0, 0)...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	out = append(out, payload...)
+	return append(out, data[2:]...)
+}
+
+func decodeSize(t *testing.T, data []byte) (int, int) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	return config.Width, config.Height
+}
+
+func TestProcessImage_Sizes(t *testing.T) {
+	processed, err := utils.ProcessImage(bytes.NewReader(encodedImage(t, 1600, 1000, "png")), constant.DefaultMaxUploadSize)
+
+	require.NoError(t, err)
+	require.Len(t, processed, 3)
+	sizes := map[string][2]int{}
+	for _, variant := range processed {
+		width, height := decodeSize(t, variant.Content)
+		sizes[variant.Size] = [2]int{width, height}
+	}
+	assert.Equal(t, [2]int{1600, 1000}, sizes[constant.ImageSizeOriginal])
+	assert.Equal(t, [2]int{800, 500}, sizes[constant.ImageSizeMedium])
+	assert.Equal(t, [2]int{200, 125}, sizes[constant.ImageSizeThumbnail])
+}
+
+func TestProcessImage_Orientation(t *testing.T) {
+	data := withOrientation(encodedImage(t, 300, 100, "jpeg"), 6)
+
+	processed, err := utils.ProcessImage(bytes.NewReader(data), constant.DefaultMaxUploadSize)
+
+	require.NoError(t, err)
+	original := processed[0].Content
+	width, height := decodeSize(t, original)
+	assert.Equal(t, 100, width)
+	assert.Equal(t, 300, height)
+	assert.NotContains(t, string(original), "Exif")
+
+	// Rotated clockwise, the red left half ends up on top.
+	decoded, err := jpeg.Decode(bytes.NewReader(original))
+	require.NoError(t, err)
+	r, _, b, _ := decoded.At(50, 20).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = decoded.At(50, 280).RGBA()
+	assert.Less(t, r, uint32(0x8000))
+}
+
+// A restart marker right after SOI has no length, which the decoder accepts.
+func TestProcessImage_MarkerWithoutLength(t *testing.T) {
+	data := encodedImage(t, 300, 100, "jpeg")
+	data = append([]byte{0xFF, 0xD8, 0xFF, 0xD0, 0x00, 0x00}, data[2:]...)
+
+	assert.NotPanics(t, func() {
+		_, _ = utils.ProcessImage(bytes.NewReader(data), constant.DefaultMaxUploadSize)
+	})
+}
+
+func TestProcessImage_OrientationAfterRestartMarker(t *testing.T) {
+	data := withOrientation(encodedImage(t, 300, 100, "jpeg"), 6)
+	data = append([]byte{0xFF, 0xD8, 0xFF, 0xD0}, data[2:]...)
+
+	processed, err := utils.ProcessImage(bytes.NewReader(data), constant.DefaultMaxUploadSize)
+
+	require.NoError(t, err)
+	width, height := decodeSize(t, processed[0].Content)
+	assert.Equal(t, [2]int{100, 300}, [2]int{width, height})
+}
+
+func TestProcessImage_Invalid(t *testing.T) {
+	_, err := utils.ProcessImage(strings.NewReader("%PDF-1.7 renamed to jpg"), constant.DefaultMaxUploadSize)
+	assert.Equal(t, dto.ErrInvalidImage, err)
+
+	truncated := encodedImage(t, 100, 100, "png")[:100]
+	_, err = utils.ProcessImage(bytes.NewReader(truncated), constant.DefaultMaxUploadSize)
+	assert.Equal(t, dto.ErrInvalidImage, err)
+
+	_, err = utils.ProcessImage(bytes.NewReader(make([]byte, constant.DefaultMaxUploadSize+1)), constant.DefaultMaxUploadSize)
+	assert.Equal(t, dto.ErrLimitSizeExceeded, err)
+}
+
+func TestImageVariantName(t *testing.T) {
+	assert.Equal(t, "abc_thumbnail.jpg", utils.ImageVariantName("abc_original.jpg", constant.ImageSizeThumbnail))
+	assert.Equal(t, "legacy.png", utils.ImageVariantName("legacy.png", constant.ImageSizeThumbnail))
+}
+
+func TestFileManagement_SaveAndDeleteImage(t *testing.T) {
+	root := t.TempDir()
+	fileManagement := utils.FileInit(utils.NewLocalStorage(root), config.Default())
+
+	name, err := fileManagement.SaveImage(t.Context(), bytes.NewReader(encodedImage(t, 400, 400, "png")))
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(name, "_original.jpg"))
+	for _, size := range utils.ImageSizes {
+		file, err := fileManagement.OpenFile(t.Context(), filepath.Join(constant.DefaultImageDir, utils.ImageVariantName(name, size)))
+		require.NoError(t, err, size)
+		_, _ = io.Copy(io.Discard, file)
+		file.Close()
+	}
+
+	require.NoError(t, fileManagement.DeleteImage(t.Context(), name))
+	for _, size := range utils.ImageSizes {
+		_, err := os.Stat(filepath.Join(root, constant.DefaultImageDir, utils.ImageVariantName(name, size)))
+		assert.ErrorIs(t, err, fs.ErrNotExist, size)
+	}
+	assert.NoError(t, fileManagement.DeleteImage(t.Context(), "legacy.png"))
+}
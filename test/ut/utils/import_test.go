@@ -0,0 +1,47 @@
+package utils_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportReader_CSVLineNumbers(t *testing.T) {
+	reader, err := utils.NewImportReader(constant.ExportFormatCSV, bytes.NewBufferString("\ufeffbarcode_id,title\n\n1,Indomie\n"))
+	assert.NoError(t, err)
+
+	header, line, err := reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"barcode_id", "title"}, header)
+	assert.Equal(t, 1, line)
+
+	record, line, err := reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "Indomie"}, record)
+	assert.Equal(t, 3, line)
+
+	_, _, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+	assert.NoError(t, reader.Close())
+}
+
+func TestImportReader_XLSXRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, _ := utils.NewExportWriter(constant.ExportFormatXLSX, "Products", &buf)
+	_ = w.WriteHeader("barcode_id", "title")
+	_ = w.WriteRow("1", "Indomie")
+	assert.NoError(t, w.Close())
+
+	reader, err := utils.NewImportReader(constant.ExportFormatXLSX, &buf)
+	assert.NoError(t, err)
+	_, _, _ = reader.Next()
+	record, line, err := reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "Indomie"}, record)
+	assert.Equal(t, 2, line)
+	assert.NoError(t, reader.Close())
+}
@@ -0,0 +1,27 @@
+package utils_test
+
+import (
+	"testing"
+	"tiga-putra-cashier-be/dto"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := utils.Cursor{Sort: "created_at", Order: "desc", Value: "2025-01-02T03:04:05.123456Z", Id: 42}
+
+	token := utils.EncodeCursor(cursor)
+	decoded, err := utils.DecodeCursor(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+	assert.NotContains(t, token, "=")
+}
+
+func TestCursor_Invalid(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm90IGpzb24", utils.EncodeCursor(utils.Cursor{Sort: "title"})} {
+		_, err := utils.DecodeCursor(token)
+		assert.Equal(t, dto.ErrInvalidCursor, err, token)
+	}
+}
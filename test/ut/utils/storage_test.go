@@ -0,0 +1,244 @@
+package utils_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/utils"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_RoundTrip(t *testing.T) {
+	root := t.TempDir()
+	storage := utils.NewLocalStorage(root)
+
+	err := storage.Put(t.Context(), "assets/image/1.jpg", strings.NewReader("jpeg"), 4, "image/jpeg")
+	require.NoError(t, err)
+
+	file, err := storage.Get(t.Context(), "assets/image/1.jpg")
+	require.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "jpeg", string(content))
+	assert.FileExists(t, filepath.Join(root, "assets", "image", "1.jpg"))
+
+	url, err := storage.SignedURL(t.Context(), "assets/image/1.jpg")
+	assert.NoError(t, err)
+	assert.Empty(t, url)
+
+	assert.NoError(t, storage.Delete(t.Context(), "assets/image/1.jpg"))
+	_, err = storage.Get(t.Context(), "assets/image/1.jpg")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLocalStorage_List(t *testing.T) {
+	storage := utils.NewLocalStorage(t.TempDir())
+	require.NoError(t, storage.Put(t.Context(), "assets/image/1.jpg", strings.NewReader("jpeg"), 4, ""))
+	require.NoError(t, storage.Put(t.Context(), "assets/image/2.jpg", strings.NewReader("jpeg"), 4, ""))
+	require.NoError(t, storage.Put(t.Context(), "assets/other/3.jpg", strings.NewReader("jpeg"), 4, ""))
+
+	files, err := storage.List(t.Context(), "assets/image/")
+
+	require.NoError(t, err)
+	var keys []string
+	for _, file := range files {
+		keys = append(keys, file.Key)
+		assert.WithinDuration(t, time.Now(), file.ModTime, time.Minute)
+	}
+	assert.ElementsMatch(t, []string{"assets/image/1.jpg", "assets/image/2.jpg"}, keys)
+
+	files, err = storage.List(t.Context(), "missing/")
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestLocalStorage_StaysInRoot(t *testing.T) {
+	dir := t.TempDir()
+	storage := utils.NewLocalStorage(filepath.Join(dir, "root"))
+
+	err := storage.Put(t.Context(), "../outside.jpg", strings.NewReader("jpeg"), 4, "")
+
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "root", "outside.jpg"))
+	_, err = os.Stat(filepath.Join(dir, "outside.jpg"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+// fakeS3 is just enough of the S3 API for single part uploads, reads and
+// deletes against path-style bucket URLs.
+func fakeS3(t *testing.T) (*httptest.Server, map[string]string) {
+	var mu sync.Mutex
+	objects := map[string]string{}
+	types := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := r.URL.Path
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+				body = decodeChunks(body)
+			}
+			objects[key] = string(body)
+			types[key] = r.Header.Get("Content-Type")
+			w.Header().Set("ETag", `"etag"`)
+		case http.MethodGet, http.MethodHead:
+			if r.URL.Query().Has("list-type") {
+				listObjects(w, r, objects)
+				return
+			}
+			content, ok := objects[key]
+			if !ok {
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(http.StatusNotFound)
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>`))
+				}
+				return
+			}
+			w.Header().Set("Content-Type", types[key])
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			http.ServeContent(w, r, key, time.Time{}, strings.NewReader(content))
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, types
+}
+
+// listObjects answers a ListObjectsV2 request for the bucket in the path.
+func listObjects(w http.ResponseWriter, r *http.Request, objects map[string]string) {
+	prefix := strings.TrimSuffix(r.URL.Path, "/") + "/" + r.URL.Query().Get("prefix")
+	var contents strings.Builder
+	keys := 0
+	for key, content := range objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		keys++
+		fmt.Fprintf(&contents, "<Contents><Key>%s</Key><LastModified>2025-01-02T03:04:05.000Z</LastModified><Size>%d</Size></Contents>",
+			strings.SplitN(key, "/", 3)[2], len(content))
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, "<ListBucketResult><Name>cashier</Name><KeyCount>%d</KeyCount><IsTruncated>false</IsTruncated>%s</ListBucketResult>", keys, contents.String())
+}
+
+// decodeChunks strips the per-chunk signatures of a streaming upload.
+func decodeChunks(body []byte) []byte {
+	var decoded []byte
+	for len(body) > 0 {
+		header, rest, _ := bytes.Cut(body, []byte("\r\n"))
+		size, _ := strconv.ParseInt(string(bytes.SplitN(header, []byte(";"), 2)[0]), 16, 64)
+		if size == 0 {
+			break
+		}
+		decoded = append(decoded, rest[:size]...)
+		body = rest[size+2:]
+	}
+	return decoded
+}
+
+func TestS3Storage_RoundTrip(t *testing.T) {
+	server, types := fakeS3(t)
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	storage, err := utils.NewS3Storage(endpoint, "key", "secret", "cashier", "us-east-1", false, 0)
+	require.NoError(t, err)
+
+	err = storage.Put(t.Context(), "assets/image/1.jpg", bytes.NewReader([]byte("jpeg")), 4, "")
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", types["/cashier/assets/image/1.jpg"])
+
+	file, err := storage.Get(t.Context(), "assets/image/1.jpg")
+	require.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "jpeg", string(content))
+
+	url, err := storage.SignedURL(t.Context(), "assets/image/1.jpg")
+	assert.NoError(t, err)
+	assert.Empty(t, url)
+
+	assert.NoError(t, storage.Delete(t.Context(), "assets/image/1.jpg"))
+	_, err = storage.Get(t.Context(), "assets/image/1.jpg")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestS3Storage_List(t *testing.T) {
+	server, _ := fakeS3(t)
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	storage, err := utils.NewS3Storage(endpoint, "key", "secret", "cashier", "us-east-1", false, 0)
+	require.NoError(t, err)
+	require.NoError(t, storage.Put(t.Context(), "assets/image/1.jpg", strings.NewReader("jpeg"), 4, ""))
+	require.NoError(t, storage.Put(t.Context(), "assets/other/2.jpg", strings.NewReader("jpeg"), 4, ""))
+
+	files, err := storage.List(t.Context(), "assets/image/")
+
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "assets/image/1.jpg", files[0].Key)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), files[0].ModTime.UTC())
+}
+
+func TestS3Storage_UnknownSize(t *testing.T) {
+	server, types := fakeS3(t)
+	storage, err := utils.NewS3Storage(strings.TrimPrefix(server.URL, "http://"), "key", "secret", "cashier", "us-east-1", false, 0)
+	require.NoError(t, err)
+	fileManagement := utils.FileInit(storage, config.Default())
+
+	err = fileManagement.SaveFile(t.Context(), strings.NewReader("png"), "1.png", "assets/image")
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", types["/cashier/assets/image/1.png"])
+
+	file, err := fileManagement.OpenFile(t.Context(), "assets/image/1.png")
+	require.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "png", string(content))
+}
+
+func TestS3Storage_SignedURL(t *testing.T) {
+	storage, err := utils.NewS3Storage("minio:9000", "key", "secret", "cashier", "us-east-1", false, 15*time.Minute)
+	require.NoError(t, err)
+
+	signed, err := storage.SignedURL(t.Context(), "assets/image/1.jpg")
+
+	require.NoError(t, err)
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "minio:9000", parsed.Host)
+	assert.Equal(t, "/cashier/assets/image/1.jpg", parsed.Path)
+	assert.Equal(t, "900", parsed.Query().Get("X-Amz-Expires"))
+	assert.NotEmpty(t, parsed.Query().Get("X-Amz-Signature"))
+}
+
+func TestS3Storage_Canceled(t *testing.T) {
+	server, _ := fakeS3(t)
+	storage, err := utils.NewS3Storage(strings.TrimPrefix(server.URL, "http://"), "key", "secret", "cashier", "us-east-1", false, 0)
+	require.NoError(t, err)
+	require.NoError(t, storage.Put(t.Context(), "assets/image/1.jpg", strings.NewReader("jpeg"), 4, ""))
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = storage.Get(ctx, "assets/image/1.jpg")
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,77 @@
+package utils_test
+
+import (
+	"errors"
+	"mime/multipart"
+	"strings"
+	"testing"
+	testUtils "tiga-putra-cashier-be/test/mocks/utils"
+	"tiga-putra-cashier-be/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUnitOfWork_Commit(t *testing.T) {
+	mockedUtils := new(testUtils.MockFileManagement)
+	file := &multipart.FileHeader{Filename: "new.jpg"}
+	var steps []string
+	mockedUtils.On("UploadImage", file).Return("new_original.jpg", nil)
+	mockedUtils.On("DeleteImage", "old_original.jpg").Return(errors.New("ISE")).
+		Run(func(mock.Arguments) { steps = append(steps, "delete") })
+
+	uow := utils.NewUnitOfWork(t.Context(), mockedUtils)
+	defer uow.Rollback()
+	image, err := uow.UploadImage(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "new_original.jpg", image)
+	uow.DeleteImage("old_original.jpg")
+	uow.DeleteImage("")
+	err = uow.Commit(func() error {
+		steps = append(steps, "write")
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"write", "delete"}, steps)
+	mockedUtils.AssertExpectations(t)
+	mockedUtils.AssertNotCalled(t, "DeleteImage", "new_original.jpg")
+}
+
+func TestUnitOfWork_CommitFailed(t *testing.T) {
+	mockedUtils := new(testUtils.MockFileManagement)
+	content := strings.NewReader("jpeg")
+	mockedUtils.On("SaveImage", content).Return("new_original.jpg", nil)
+	mockedUtils.On("DeleteImage", "new_original.jpg").Return(nil).Once()
+
+	uow := utils.NewUnitOfWork(t.Context(), mockedUtils)
+	_, err := uow.SaveImage(content)
+	assert.NoError(t, err)
+	uow.DeleteImage("old_original.jpg")
+	err = uow.Commit(func() error {
+		return errors.New("ISE")
+	})
+	uow.Rollback()
+
+	assert.EqualError(t, err, "ISE")
+	mockedUtils.AssertExpectations(t)
+	mockedUtils.AssertNotCalled(t, "DeleteImage", "old_original.jpg")
+}
+
+func TestUnitOfWork_RollbackBeforeCommit(t *testing.T) {
+	mockedUtils := new(testUtils.MockFileManagement)
+	first := &multipart.FileHeader{Filename: "first.jpg"}
+	second := &multipart.FileHeader{Filename: "second.jpg"}
+	mockedUtils.On("UploadImage", first).Return("first_original.jpg", nil)
+	mockedUtils.On("UploadImage", second).Return("", errors.New("ISE"))
+	mockedUtils.On("DeleteImage", "first_original.jpg").Return(nil)
+
+	uow := utils.NewUnitOfWork(t.Context(), mockedUtils)
+	_, err := uow.UploadImage(first)
+	assert.NoError(t, err)
+	_, err = uow.UploadImage(second)
+	assert.Error(t, err)
+	uow.Rollback()
+
+	mockedUtils.AssertExpectations(t)
+}
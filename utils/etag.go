@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+func ETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ParseIfMatch reads the version out of an If-Match header. It returns nil
+// when the header is empty or "*", which match any version, and false when
+// the header holds no version this API handed out. If-Match compares strongly
+// (RFC 9110), so weak tags never match.
+func ParseIfMatch(header string) (*uint, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, true
+	}
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, false
+	}
+	version, err := strconv.ParseUint(header[1:len(header)-1], 10, 0)
+	if err != nil {
+		return nil, false
+	}
+	v := uint(version)
+	return &v, true
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"encoding/csv"
+	"io"
+	"tiga-putra-cashier-be/dto"
+)
+
+type csvExportWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newCSVExportWriter(w io.Writer) ExportWriter {
+	return &csvExportWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvExportWriter) WriteHeader(columns ...string) error {
+	if err := c.writer.Write(columns); err != nil {
+		return dto.ErrToWriteExport
+	}
+	return nil
+}
+
+func (c *csvExportWriter) WriteRow(values ...interface{}) error {
+	c.record = c.record[:0]
+	for _, value := range values {
+		c.record = append(c.record, exportCellText(value))
+	}
+	if err := c.writer.Write(c.record); err != nil {
+		return dto.ErrToWriteExport
+	}
+	return nil
+}
+
+func (c *csvExportWriter) Close() error {
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return dto.ErrToWriteExport
+	}
+	return nil
+}
+
+func (c *csvExportWriter) Discard() {}
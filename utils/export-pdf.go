@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"io"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfFontFamily  = "Helvetica"
+	pdfFontSize    = 8
+	pdfRowHeight   = 6
+	pdfTitleHeight = 10
+)
+
+// pdfExportWriter lays the table out on landscape A4 pages and repeats the
+// header on every page. Unlike the spreadsheet formats a PDF can only be
+// written out once it is complete, so the document is kept until Close.
+type pdfExportWriter struct {
+	pdf       *fpdf.Fpdf
+	out       io.Writer
+	translate func(string) string
+	columns   []string
+	width     float64
+}
+
+func newPDFExportWriter(title string, w io.Writer) ExportWriter {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle(title, true)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFont(pdfFontFamily, "B", 12)
+	pdf.CellFormat(0, pdfTitleHeight, translate(title), "", 1, "L", false, 0, "")
+	return &pdfExportWriter{pdf: pdf, out: w, translate: translate}
+}
+
+func (p *pdfExportWriter) WriteHeader(columns ...string) error {
+	p.columns = columns
+	pageWidth, _ := p.pdf.GetPageSize()
+	left, _, right, _ := p.pdf.GetMargins()
+	if len(columns) > 0 {
+		p.width = (pageWidth - left - right) / float64(len(columns))
+	}
+	p.writeHeader()
+	return p.err()
+}
+
+func (p *pdfExportWriter) WriteRow(values ...interface{}) error {
+	_, pageHeight := p.pdf.GetPageSize()
+	_, _, _, bottom := p.pdf.GetMargins()
+	if p.pdf.GetY()+pdfRowHeight > pageHeight-bottom {
+		p.pdf.AddPage()
+		p.writeHeader()
+	}
+	p.pdf.SetFont(pdfFontFamily, "", pdfFontSize)
+	for _, value := range values {
+		p.pdf.CellFormat(p.width, pdfRowHeight, p.fit(exportCellText(value)), "1", 0, "L", false, 0, "")
+	}
+	p.pdf.Ln(pdfRowHeight)
+	return p.err()
+}
+
+func (p *pdfExportWriter) Close() error {
+	if err := p.pdf.Output(p.out); err != nil {
+		return dto.ErrToWriteExport
+	}
+	return nil
+}
+
+func (p *pdfExportWriter) Discard() {}
+
+func (p *pdfExportWriter) writeHeader() {
+	p.pdf.SetFont(pdfFontFamily, "B", pdfFontSize)
+	p.pdf.SetFillColor(230, 230, 230)
+	for _, column := range p.columns {
+		p.pdf.CellFormat(p.width, pdfRowHeight, p.fit(column), "1", 0, "L", true, 0, "")
+	}
+	p.pdf.Ln(pdfRowHeight)
+}
+
+// fit cuts text that would run into the next column.
+func (p *pdfExportWriter) fit(text string) string {
+	text = p.translate(text)
+	limit := p.width - 2*p.pdf.GetCellMargin()
+	for len(text) > 0 && p.pdf.GetStringWidth(text) > limit {
+		text = text[:len(text)-1]
+	}
+	return text
+}
+
+func (p *pdfExportWriter) err() error {
+	if p.pdf.Err() {
+		return dto.ErrToWriteExport
+	}
+	return nil
+}
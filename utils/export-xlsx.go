@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"io"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxMaxSheetNameLength = 31
+
+// xlsxExportWriter uses the excelize stream writer, which spills rows to a
+// temporary file once they outgrow its buffer instead of keeping the whole
+// sheet in memory.
+type xlsxExportWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+func newXLSXExportWriter(title string, w io.Writer) (ExportWriter, error) {
+	file := excelize.NewFile()
+	sheet := file.GetSheetName(0)
+	if title != "" {
+		if len(title) > xlsxMaxSheetNameLength {
+			title = title[:xlsxMaxSheetNameLength]
+		}
+		if err := file.SetSheetName(sheet, title); err != nil {
+			return nil, dto.ErrToWriteExport
+		}
+		sheet = title
+	}
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, dto.ErrToWriteExport
+	}
+	return &xlsxExportWriter{file: file, stream: stream, out: w}, nil
+}
+
+func (x *xlsxExportWriter) WriteHeader(columns ...string) error {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return x.setRow(values)
+}
+
+func (x *xlsxExportWriter) WriteRow(values ...interface{}) error {
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case decimal.Decimal:
+			cells[i] = v.InexactFloat64()
+		case string, int, int64, uint, uint16, float64:
+			cells[i] = v
+		default:
+			cells[i] = exportCellText(v)
+		}
+	}
+	return x.setRow(cells)
+}
+
+func (x *xlsxExportWriter) setRow(values []interface{}) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return dto.ErrToWriteExport
+	}
+	if err := x.stream.SetRow(cell, values); err != nil {
+		return dto.ErrToWriteExport
+	}
+	return nil
+}
+
+func (x *xlsxExportWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return dto.ErrToWriteExport
+	}
+	if err := x.file.Write(x.out); err != nil {
+		return dto.ErrToWriteExport
+	}
+	return nil
+}
+
+func (x *xlsxExportWriter) Discard() {
+	x.file.Close()
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExportWriter renders a table row by row, so the rows can come straight from a
+// database cursor. Close finishes the file, Discard drops whatever was not
+// written out yet; one of them must be called.
+type ExportWriter interface {
+	WriteHeader(columns ...string) error
+	WriteRow(values ...interface{}) error
+	Close() error
+	Discard()
+}
+
+func NewExportWriter(format, title string, w io.Writer) (ExportWriter, error) {
+	switch format {
+	case constant.ExportFormatCSV:
+		return newCSVExportWriter(w), nil
+	case constant.ExportFormatXLSX:
+		return newXLSXExportWriter(title, w)
+	case constant.ExportFormatPDF:
+		return newPDFExportWriter(title, w), nil
+	}
+	return nil, dto.ErrUnsupportedExportFormat
+}
+
+func ExportContentType(format string) string {
+	switch format {
+	case constant.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case constant.ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case constant.ExportFormatPDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+func ExportFileName(name, format string) string {
+	return fmt.Sprintf("%s.%s", name, format)
+}
+
+func exportCellText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case decimal.Decimal:
+		return v.String()
+	case *uint:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(*v), 10)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.DateOnly)
+	case time.Time:
+		return v.Format(time.DateTime)
+	}
+	return fmt.Sprint(value)
+}
@@ -1,27 +1,50 @@
 package utils
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime/multipart"
-	"os"
-	"path/filepath"
+	"net"
+	"net/http"
+	"net/netip"
+	"path"
 	"strings"
+	"syscall"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
 	"tiga-putra-cashier-be/dto"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type FileManagement interface {
-	UploadFile(file *multipart.FileHeader, filename, path string) error
+	UploadFile(ctx context.Context, file *multipart.FileHeader, filename, path string) error
+	SaveFile(ctx context.Context, content io.Reader, filename, path string) error
+	DownloadFile(ctx context.Context, url string) ([]byte, error)
 	GetFileNameExtension(filename string) string
-	DeleteFile(pathFile string) error
+	OpenFile(ctx context.Context, pathFile string) (io.ReadCloser, error)
+	DeleteFile(ctx context.Context, pathFile string) error
 	GenerateNewFileName(ext string) string
+	UploadImage(ctx context.Context, file *multipart.FileHeader) (string, error)
+	SaveImage(ctx context.Context, content io.Reader) (string, error)
+	DeleteImage(ctx context.Context, filename string) error
 }
-type fileManagementUtils struct{}
 
-func FileInit() FileManagement {
-	return &fileManagementUtils{}
+// fileManagementUtils keeps uploaded files in whichever Storage backend is
+// configured; path and filename are joined into the storage key.
+type fileManagementUtils struct {
+	storage       Storage
+	imageDir      string
+	maxUploadSize int64
+}
+
+func FileInit(storage Storage, cfg *config.Config) FileManagement {
+	return &fileManagementUtils{storage, cfg.Storage.ImageDir, cfg.Storage.MaxUploadSize}
 }
 
 func (f *fileManagementUtils) GetFileNameExtension(filename string) string {
@@ -29,39 +52,149 @@ func (f *fileManagementUtils) GetFileNameExtension(filename string) string {
 	return strings.ToLower(parts[len(parts)-1])
 }
 
-func (f *fileManagementUtils) UploadFile(file *multipart.FileHeader, filename, path string) error {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		if err := os.MkdirAll(path, 0750); err != nil {
-			return dto.ErrToSaveFile
-		}
-	}
-	fullFilePath := fmt.Sprintf("%s/%s", path, filename)
-	filepath.Clean(fullFilePath)
+func (f *fileManagementUtils) UploadFile(ctx context.Context, file *multipart.FileHeader, filename, dir string) error {
 	uploadedFile, err := file.Open()
 	if err != nil {
 		return dto.ErrToSaveFile
 	}
 	defer uploadedFile.Close()
-	targetFile, err := os.Create(fullFilePath)
+	return f.storage.Put(ctx, path.Join(dir, filename), uploadedFile, file.Size, file.Header.Get("Content-Type"))
+}
+
+func (f *fileManagementUtils) SaveFile(ctx context.Context, content io.Reader, filename, dir string) error {
+	return f.storage.Put(ctx, path.Join(dir, filename), content, -1, "")
+}
+
+func (f *fileManagementUtils) UploadImage(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	uploadedFile, err := file.Open()
 	if err != nil {
-		return dto.ErrToSaveFile
+		return "", dto.ErrToSaveFile
 	}
+	defer uploadedFile.Close()
+	return f.SaveImage(ctx, uploadedFile)
+}
 
-	defer targetFile.Close()
-	_, err = io.Copy(targetFile, uploadedFile)
+// SaveImage processes an image into every size of ImageSizes and stores them
+// side by side in the configured image directory. It returns the file name of the original, which
+// is what products keep; the other sizes are derived with ImageVariantName.
+// The sizes already stored are removed when one fails, even once ctx is done.
+func (f *fileManagementUtils) SaveImage(ctx context.Context, content io.Reader) (string, error) {
+	processed, err := ProcessImage(content, f.maxUploadSize)
 	if err != nil {
-		return dto.ErrToSaveFile
+		return "", err
 	}
-	return nil
+	base := uuid.New().String()
+	var saved []string
+	for _, variant := range processed {
+		key := path.Join(f.imageDir, fmt.Sprintf("%s_%s.%s", base, variant.Size, constant.ImageExtension))
+		if err := f.storage.Put(ctx, key, bytes.NewReader(variant.Content), int64(len(variant.Content)), "image/jpeg"); err != nil {
+			for _, key := range saved {
+				_ = f.storage.Delete(context.WithoutCancel(ctx), key)
+			}
+			return "", err
+		}
+		saved = append(saved, key)
+	}
+	return fmt.Sprintf("%s_%s.%s", base, constant.ImageSizeOriginal, constant.ImageExtension), nil
 }
 
-func (f *fileManagementUtils) DeleteFile(pathFile string) error {
-	if err := os.Remove(pathFile); err != nil {
-		return err
+// DeleteImage removes every size of an image. Sizes that are already gone
+// don't count as a failure.
+func (f *fileManagementUtils) DeleteImage(ctx context.Context, filename string) error {
+	deleted := make(map[string]bool)
+	for _, size := range ImageSizes {
+		name := ImageVariantName(filename, size)
+		if deleted[name] {
+			continue
+		}
+		deleted[name] = true
+		if err := f.storage.Delete(ctx, path.Join(f.imageDir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
 	}
 	return nil
 }
 
+// DownloadFile fetches a file over HTTP and refuses anything above the upload
+// size limit without reading the rest of it. The URLs come from users, so it
+// only connects to public addresses, checked on every connection it makes,
+// redirects included, after the host is resolved.
+func (f *fileManagementUtils) DownloadFile(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil || (req.URL.Scheme != "http" && req.URL.Scheme != "https") {
+		return nil, dto.ErrToDownloadImage
+	}
+	res, err := downloadClient.Do(req)
+	if errors.Is(err, errNotPublicAddress) {
+		return nil, dto.ErrImageURLNotPublic
+	} else if err != nil {
+		return nil, dto.ErrToDownloadImage
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, dto.ErrToDownloadImage
+	}
+	if res.ContentLength > f.maxUploadSize {
+		return nil, dto.ErrLimitSizeExceeded
+	}
+	content, err := io.ReadAll(io.LimitReader(res.Body, f.maxUploadSize+1))
+	if err != nil {
+		return nil, dto.ErrToDownloadImage
+	}
+	if int64(len(content)) > f.maxUploadSize {
+		return nil, dto.ErrLimitSizeExceeded
+	}
+	return content, nil
+}
+
+var errNotPublicAddress = errors.New("address is not public")
+
+// downloadClient refuses to connect anywhere but public addresses. It never
+// goes through a proxy, which would connect on its behalf.
+var downloadClient = &http.Client{
+	Timeout: 15 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				addrPort, err := netip.ParseAddrPort(address)
+				if err != nil || !isPublicAddress(addrPort.Addr()) {
+					return errNotPublicAddress
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return http.ErrUseLastResponse
+		}
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return errNotPublicAddress
+		}
+		return nil
+	},
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which clouds also use
+// for internal services.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+func (f *fileManagementUtils) OpenFile(ctx context.Context, pathFile string) (io.ReadCloser, error) {
+	return f.storage.Get(ctx, pathFile)
+}
+
+func (f *fileManagementUtils) DeleteFile(ctx context.Context, pathFile string) error {
+	return f.storage.Delete(ctx, pathFile)
+}
+
 func (f *fileManagementUtils) GenerateNewFileName(ext string) string {
 	return fmt.Sprintf("%s.%s", uuid.New().String(), ext)
 }
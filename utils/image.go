@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+
+	"golang.org/x/image/draw"
+)
+
+type ProcessedImage struct {
+	Size    string
+	Content []byte
+}
+
+// ImageSizes lists every size ProcessImage generates, original first.
+var ImageSizes = []string{constant.ImageSizeOriginal, constant.ImageSizeMedium, constant.ImageSizeThumbnail}
+
+// ProcessImage checks the upload really is a jpeg or png by its content,
+// applies and drops the EXIF orientation and re-encodes it as jpeg in every
+// size of ImageSizes. Re-encoding also leaves the rest of the metadata behind.
+// Uploads above maxSize bytes are refused.
+func ProcessImage(content io.Reader, maxSize int64) ([]ProcessedImage, error) {
+	data, err := io.ReadAll(io.LimitReader(content, maxSize+1))
+	if err != nil {
+		return nil, dto.ErrInvalidImage
+	}
+	if int64(len(data)) > maxSize {
+		return nil, dto.ErrLimitSizeExceeded
+	}
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, dto.ErrInvalidImage
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, dto.ErrInvalidImage
+	}
+	if config.Width*config.Height > constant.MaxImagePixels {
+		return nil, dto.ErrImageTooLarge
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, dto.ErrInvalidImage
+	}
+
+	// jpeg has no transparency, so flatten onto white rather than black.
+	bounds := decoded.Bounds()
+	original := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(original, original.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(original, original.Bounds(), decoded, bounds.Min, draw.Over)
+	if contentType == "image/jpeg" {
+		original = orient(original, jpegOrientation(data))
+	}
+
+	medium := fit(original, constant.MediumImageSize)
+	sizes := map[string]image.Image{
+		constant.ImageSizeOriginal:  original,
+		constant.ImageSizeMedium:    medium,
+		constant.ImageSizeThumbnail: fit(medium, constant.ThumbnailImageSize),
+	}
+	var processed []ProcessedImage
+	for _, size := range ImageSizes {
+		var encoded bytes.Buffer
+		if err := jpeg.Encode(&encoded, sizes[size], &jpeg.Options{Quality: constant.ImageQuality}); err != nil {
+			return nil, dto.ErrToSaveFile
+		}
+		processed = append(processed, ProcessedImage{size, encoded.Bytes()})
+	}
+	return processed, nil
+}
+
+// ImageVariantName derives the file name of another size from the name of a
+// processed original. Images uploaded before processing existed only have
+// the one file, so their name is returned unchanged.
+func ImageVariantName(image, size string) string {
+	suffix := "_" + constant.ImageSizeOriginal + "." + constant.ImageExtension
+	if !strings.HasSuffix(image, suffix) {
+		return image
+	}
+	return strings.TrimSuffix(image, suffix) + "_" + size + "." + constant.ImageExtension
+}
+
+// fit scales img down so its longest side is at most size, keeping the
+// aspect ratio. Smaller images are returned as they are.
+func fit(img *image.RGBA, size int) *image.RGBA {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width <= size && height <= size {
+		return img
+	}
+	if width >= height {
+		width, height = size, max(1, height*size/width)
+	} else {
+		width, height = max(1, width*size/height), size
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return scaled
+}
+
+// orient turns img upright according to an EXIF orientation value, 1 to 8.
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	outWidth, outHeight := width, height
+	if orientation >= 5 {
+		outWidth, outHeight = height, width
+	}
+	out := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			copy(out.Pix[out.PixOffset(dx, dy):out.PixOffset(dx, dy)+4], img.Pix[img.PixOffset(x, y):img.PixOffset(x, y)+4])
+		}
+	}
+	return out
+}
+
+// jpegOrientation reads the orientation tag from the EXIF segment of a jpeg,
+// returning 1 (upright) when there is none. Markers are walked the way
+// image/jpeg does, so anything it decodes is read without going out of range.
+func jpegOrientation(data []byte) int {
+	for offset := 2; offset+2 <= len(data) && data[offset] == 0xFF; {
+		marker := data[offset+1]
+		switch {
+		case marker == 0xFF:
+			// Fill byte before the marker.
+			offset++
+			continue
+		case marker == 0x01 || marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7):
+			// TEM, SOI and RSTn stand alone without a length.
+			offset += 2
+			continue
+		case marker == 0xD9 || marker == 0xDA:
+			return 1
+		}
+		if offset+4 > len(data) {
+			break
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if length < 2 || offset+2+length > len(data) {
+			break
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 1
+}
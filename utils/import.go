@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ImportReader reads a spreadsheet one record at a time, together with the line
+// or row number it came from. Next returns io.EOF after the last record.
+type ImportReader interface {
+	Next() ([]string, int, error)
+	Close() error
+}
+
+func NewImportReader(format string, r io.Reader) (ImportReader, error) {
+	switch format {
+	case constant.ExportFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		return &csvImportReader{reader: reader}, nil
+	case constant.ExportFormatXLSX:
+		return newXLSXImportReader(r)
+	}
+	return nil, dto.ErrWrongImportFileExtension
+}
+
+type csvImportReader struct {
+	reader  *csv.Reader
+	started bool
+}
+
+func (c *csvImportReader) Next() ([]string, int, error) {
+	record, err := c.reader.Read()
+	if err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	if err != nil {
+		return nil, 0, dto.ErrInvalidImportFile
+	}
+	line, _ := c.reader.FieldPos(0)
+	// Spreadsheet programs often save CSV with a byte order mark.
+	if !c.started && len(record) > 0 {
+		record[0] = strings.TrimPrefix(record[0], "\ufeff")
+	}
+	c.started = true
+	return record, line, nil
+}
+
+func (c *csvImportReader) Close() error {
+	return nil
+}
+
+// xlsxImportReader reads the first sheet with the excelize row iterator, which
+// doesn't build the whole sheet in memory.
+type xlsxImportReader struct {
+	file *excelize.File
+	rows *excelize.Rows
+	row  int
+}
+
+func newXLSXImportReader(r io.Reader) (ImportReader, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, dto.ErrInvalidImportFile
+	}
+	rows, err := file.Rows(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, dto.ErrInvalidImportFile
+	}
+	return &xlsxImportReader{file: file, rows: rows}, nil
+}
+
+func (x *xlsxImportReader) Next() ([]string, int, error) {
+	if !x.rows.Next() {
+		if err := x.rows.Error(); err != nil {
+			return nil, 0, dto.ErrInvalidImportFile
+		}
+		return nil, 0, io.EOF
+	}
+	x.row++
+	record, err := x.rows.Columns()
+	if err != nil {
+		return nil, 0, dto.ErrInvalidImportFile
+	}
+	return record, x.row, nil
+}
+
+func (x *xlsxImportReader) Close() error {
+	x.rows.Close()
+	return x.file.Close()
+}
@@ -1,9 +1,66 @@
 package utils
 
-import "gorm.io/gorm"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"tiga-putra-cashier-be/dto"
 
-func Paginate(limit, offset uint16) func(db *gorm.DB) *gorm.DB {
+	"gorm.io/gorm"
+)
+
+func Paginate(limit, offset int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Limit(limit).Offset(offset)
+	}
+}
+
+// Cursor marks the last row of a keyset page: the value it was sorted by and
+// its id, which breaks ties. Sort and Order record the ordering the page used,
+// so a cursor can't be replayed against another one.
+type Cursor struct {
+	Sort  string `json:"s,omitempty"`
+	Order string `json:"o,omitempty"`
+	Value string `json:"v,omitempty"`
+	Id    uint   `json:"i"`
+}
+
+// EncodeCursor turns a cursor into the opaque token clients send back.
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(token string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, dto.ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Id == 0 {
+		return Cursor{}, dto.ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// Keyset orders by column then idColumn and, given a cursor, starts right
+// after it. Rows inserted between two pages can't shift later pages the way
+// they do with offsets. An empty column sorts by idColumn alone.
+func Keyset(column, idColumn string, desc bool, after *Cursor, limit int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		return db.Limit(int(limit)).Offset(int(offset))
+		direction, operator := "ASC", ">"
+		if desc {
+			direction, operator = "DESC", "<"
+		}
+		if after != nil {
+			if column == "" {
+				db = db.Where(idColumn+" "+operator+" ?", after.Id)
+			} else {
+				db = db.Where("("+column+", "+idColumn+") "+operator+" (?, ?)", after.Value, after.Id)
+			}
+		}
+		if column != "" {
+			db = db.Order(column + " " + direction)
+		}
+		return db.Order(idColumn + " " + direction).Limit(limit)
 	}
 }
@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"tiga-putra-cashier-be/config"
+	"tiga-putra-cashier-be/constant"
+	"tiga-putra-cashier-be/dto"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type (
+	// Storage keeps files under slash separated keys such as
+	// "assets/image/<name>". A missing key is reported as fs.ErrNotExist.
+	// Remote backends give up on a call once ctx is done.
+	Storage interface {
+		Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+		// SignedURL returns a temporary URL clients can fetch the key from
+		// directly, or "" when the file has to be served by the app.
+		SignedURL(ctx context.Context, key string) (string, error)
+		// List returns every file whose key starts with prefix.
+		List(ctx context.Context, prefix string) ([]StoredFile, error)
+	}
+	StoredFile struct {
+		Key     string
+		ModTime time.Time
+	}
+	localStorage struct {
+		root string
+	}
+	s3Storage struct {
+		client *minio.Client
+		bucket string
+		expiry time.Duration
+	}
+)
+
+// NewStorage picks the configured backend: "s3" keeps files in an
+// S3-compatible bucket, "local" keeps them on the local disk.
+func NewStorage(cfg *config.Config) (Storage, error) {
+	if cfg.Storage.Driver != constant.StorageDriverS3 {
+		return NewLocalStorage("."), nil
+	}
+	s3 := cfg.Storage.S3
+	return NewS3Storage(s3.Endpoint, s3.AccessKey, s3.SecretKey, s3.Bucket, s3.Region, s3.UseSSL, s3.PresignExpiry)
+}
+
+func NewLocalStorage(root string) Storage {
+	return &localStorage{root}
+}
+
+// NewS3Storage talks to any S3-compatible endpoint, e.g. "s3.amazonaws.com"
+// or a MinIO host:port. An expiry of zero or less disables presigned URLs so
+// images are streamed through the app instead.
+func NewS3Storage(endpoint, accessKey, secretKey, bucket, region string, useSSL bool, expiry time.Duration) (Storage, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s3Storage{client, bucket, expiry}, nil
+}
+
+// path keeps the key inside the storage root whatever it contains.
+func (l *localStorage) path(key string) string {
+	return filepath.Join(l.root, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+func (l *localStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	fullFilePath := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(fullFilePath), 0750); err != nil {
+		return dto.ErrToSaveFile
+	}
+	targetFile, err := os.Create(fullFilePath)
+	if err != nil {
+		return dto.ErrToSaveFile
+	}
+	defer targetFile.Close()
+	if _, err := io.Copy(targetFile, content); err != nil {
+		return dto.ErrToSaveFile
+	}
+	return nil
+}
+
+func (l *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(l.path(key))
+}
+
+func (l *localStorage) Delete(ctx context.Context, key string) error {
+	return os.Remove(l.path(key))
+}
+
+func (l *localStorage) SignedURL(ctx context.Context, key string) (string, error) {
+	return "", nil
+}
+
+func (l *localStorage) List(ctx context.Context, prefix string) ([]StoredFile, error) {
+	// Only the directory the prefix ends in has to be walked, which for a
+	// prefix ending in "/" is the prefix itself.
+	var files []StoredFile
+	err := filepath.WalkDir(l.path(path.Dir(prefix+"x")), func(fullFilePath string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		key, err := filepath.Rel(l.root, fullFilePath)
+		if err != nil {
+			return err
+		}
+		key = filepath.ToSlash(key)
+		if strings.HasPrefix(key, prefix) {
+			files = append(files, StoredFile{key, info.ModTime()})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(key))
+	}
+	// Stored files are images capped at the upload size limit, so buffering
+	// one of unknown size is cheaper than the multipart upload S3 needs otherwise.
+	if size < 0 {
+		buffered, err := io.ReadAll(content)
+		if err != nil {
+			return dto.ErrToSaveFile
+		}
+		content, size = bytes.NewReader(buffered), int64(len(buffered))
+	}
+	_, err := s.client.PutObject(ctx, s.bucket, key, content, size, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return dto.ErrToSaveFile
+	}
+	return nil
+}
+
+// Get stats the object first because GetObject only reports a missing key
+// once the body is read. The body is read under ctx too, so it stops
+// streaming once the caller is gone.
+func (s *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s3Error("get", key, err)
+	}
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, s3Error("get", key, err)
+	}
+	return object, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return s3Error("delete", key, s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}))
+}
+
+func (s *s3Storage) SignedURL(ctx context.Context, key string) (string, error) {
+	if s.expiry <= 0 {
+		return "", nil
+	}
+	url, err := s.client.PresignedGetObject(ctx, s.bucket, key, s.expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return url.String(), nil
+}
+
+func (s *s3Storage) List(ctx context.Context, prefix string) ([]StoredFile, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	var files []StoredFile
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		files = append(files, StoredFile{object.Key, object.LastModified})
+	}
+	return files, nil
+}
+
+// s3Error reports a missing key the way os does, so callers can check for
+// fs.ErrNotExist whichever backend is configured.
+func s3Error(op, key string, err error) error {
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return &fs.PathError{Op: op, Path: key, Err: fs.ErrNotExist}
+	}
+	return err
+}
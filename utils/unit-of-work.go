@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+)
+
+// UnitOfWork ties image changes to the database write they belong to. Images
+// are uploaded straight away but removed again when the write fails, while
+// images to delete are only deleted once the write succeeded, so a failure at
+// any point leaves the product pointing at files that exist.
+//
+// Callers defer Rollback right after creating it, which undoes the uploads of
+// a flow that returns before Commit and does nothing after it. Uploads stop
+// with ctx, while the clean up runs even when the request is gone.
+type UnitOfWork struct {
+	ctx            context.Context
+	fileManagement FileManagement
+	uploaded       []string
+	deleted        []string
+	done           bool
+}
+
+func NewUnitOfWork(ctx context.Context, fileManagement FileManagement) *UnitOfWork {
+	return &UnitOfWork{ctx: ctx, fileManagement: fileManagement}
+}
+
+func (u *UnitOfWork) UploadImage(file *multipart.FileHeader) (string, error) {
+	image, err := u.fileManagement.UploadImage(u.ctx, file)
+	if err != nil {
+		return "", err
+	}
+	u.uploaded = append(u.uploaded, image)
+	return image, nil
+}
+
+func (u *UnitOfWork) SaveImage(content io.Reader) (string, error) {
+	image, err := u.fileManagement.SaveImage(u.ctx, content)
+	if err != nil {
+		return "", err
+	}
+	u.uploaded = append(u.uploaded, image)
+	return image, nil
+}
+
+// DeleteImage marks an image to be deleted on Commit.
+func (u *UnitOfWork) DeleteImage(image string) {
+	if image != "" {
+		u.deleted = append(u.deleted, image)
+	}
+}
+
+// Commit runs the database write. When it fails the uploads are removed and
+// its error is returned; otherwise the marked images are deleted. The write
+// has committed by then, so an image that fails to go is only left behind
+// for the image garbage collection.
+func (u *UnitOfWork) Commit(write func() error) error {
+	if err := write(); err != nil {
+		u.Rollback()
+		return err
+	}
+	u.done = true
+	for _, image := range u.deleted {
+		_ = u.fileManagement.DeleteImage(context.WithoutCancel(u.ctx), image)
+	}
+	return nil
+}
+
+// Rollback removes the uploads unless the unit of work already finished.
+func (u *UnitOfWork) Rollback() {
+	if u.done {
+		return
+	}
+	u.done = true
+	for _, image := range u.uploaded {
+		_ = u.fileManagement.DeleteImage(context.WithoutCancel(u.ctx), image)
+	}
+}